@@ -20,21 +20,18 @@ func authRequired(ctx gcontext.Context, req interface{}, handler grpc.UnaryHandl
 		logrus.Debugln("rpc: auth denied because token can not be gathered from header contest")
 		return nil, grpc.Errorf(codes.Unauthenticated, err.Error())
 	}
-	user, err := ovpm.GetUserByToken(token)
+	user, tok, err := ovpm.AuthenticateToken(token)
 	if err != nil {
-		logrus.Debugln("rpc: auth denied because user with this token can not be found")
+		logrus.Debugf("rpc: auth denied because token can not be authenticated: %v", err)
 		return nil, grpc.Errorf(codes.Unauthenticated, "access denied")
 	}
 
-	// Set user's permissions according to it's criterias.
-	var permissions permset.Permset
-	if user.IsAdmin() {
-		permissions = permset.New(ovpm.AdminPerms()...)
-	} else {
-		permissions = permset.New(ovpm.UserPerms()...)
-	}
+	// Set user's permissions according to it's roles.
+	// Scoped tokens can only use some of the user's permissions.
+	permissions := permset.New(tok.Restrict(user.GetPerms())...)
 
 	newCtx := NewUsernameContext(ctx, user.GetUsername())
+	newCtx = NewTokenContext(newCtx, tok)
 	newCtx = permset.NewContext(newCtx, permissions)
 	return handler(newCtx, req)
 }
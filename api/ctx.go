@@ -3,8 +3,13 @@ package api
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
+	"github.com/master312/ovpm"
 	gcontext "golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 type apiKey int
@@ -12,6 +17,7 @@ type apiKey int
 const (
 	originTypeKey apiKey = iota
 	userKey
+	tokenKey
 )
 
 // OriginType indicates where the gRPC request actually came from.
@@ -52,3 +58,36 @@ func GetUsernameFromContext(ctx gcontext.Context) (string, error) {
 	}
 	return username, nil
 }
+
+// NewTokenContext creates a new ctx from the api token that the request is authenticated with.
+func NewTokenContext(ctx gcontext.Context, token *ovpm.Token) context.Context {
+	return context.WithValue(ctx, tokenKey, token)
+}
+
+// GetTokenFromContext returns the api token that the request is authenticated with.
+//
+// It returns nil if the request isn't authenticated with a token. e.g. it's coming from the cli.
+func GetTokenFromContext(ctx gcontext.Context) *ovpm.Token {
+	token, _ := ctx.Value(tokenKey).(*ovpm.Token)
+	return token
+}
+
+// GetRemoteAddrFromContext returns the ip of the client that the request is coming from.
+//
+// For the requests coming through the REST gateway, it's the address that the gateway has appended
+// to the x-forwarded-for metadata; the addresses before it are set by the client and can't be trusted.
+// It returns an empty string if the ip can't be determined.
+func GetRemoteAddrFromContext(ctx gcontext.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if fwd := md["x-forwarded-for"]; len(fwd) > 0 {
+			hops := strings.Split(fwd[len(fwd)-1], ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+	}
+	return ""
+}
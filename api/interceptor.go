@@ -3,14 +3,19 @@ package api
 import (
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/asaskevich/govalidator"
 	"github.com/master312/ovpm"
 	"github.com/master312/ovpm/permset"
 	gcontext "golang.org/x/net/context"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 // AuthUnaryInterceptor is a interceptor function.
@@ -61,6 +66,12 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 		// AuthService methods
 		case "/pb.AuthService/Status":
 			return authRequired(ctx, req, handler)
+		case "/pb.AuthService/Logout":
+			return authRequired(ctx, req, handler)
+		case "/pb.AuthService/ListLockouts":
+			return authRequired(ctx, req, handler)
+		case "/pb.AuthService/ClearLockout":
+			return authRequired(ctx, req, handler)
 
 		// UserService methods
 		case "/pb.UserService/List":
@@ -75,6 +86,16 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 			return authRequired(ctx, req, handler)
 		case "/pb.UserService/GenConfig":
 			return authRequired(ctx, req, handler)
+		case "/pb.UserService/Disable":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/Enable":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/CreateProfileLink":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/ListInvitations":
+			return authRequired(ctx, req, handler)
+		case "/pb.UserService/RevokeInvitation":
+			return authRequired(ctx, req, handler)
 
 		// VPNService methods
 		case "/pb.VPNService/Status":
@@ -85,6 +106,14 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 			return authRequired(ctx, req, handler)
 		case "/pb.VPNService/Restart":
 			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/FirewallShow":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/IPPoolList":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/IPPoolCreate":
+			return authRequired(ctx, req, handler)
+		case "/pb.VPNService/IPPoolDelete":
+			return authRequired(ctx, req, handler)
 
 		// NetworkService methods
 		case "/pb.NetworkService/Create":
@@ -101,9 +130,164 @@ func AuthUnaryInterceptor(ctx gcontext.Context, req interface{}, info *grpc.Unar
 			return authRequired(ctx, req, handler)
 		case "/pb.NetworkService/Dissociate":
 			return authRequired(ctx, req, handler)
+		case "/pb.NetworkService/SetRules":
+			return authRequired(ctx, req, handler)
+		case "/pb.NetworkService/Update":
+			return authRequired(ctx, req, handler)
+		case "/pb.NetworkService/SetDNS":
+			return authRequired(ctx, req, handler)
+		case "/pb.NetworkService/Check":
+			return authRequired(ctx, req, handler)
+		case "/pb.NetworkService/Map":
+			return authRequired(ctx, req, handler)
+
+		// TokenService methods
+		case "/pb.TokenService/Create":
+			return authRequired(ctx, req, handler)
+		case "/pb.TokenService/List":
+			return authRequired(ctx, req, handler)
+		case "/pb.TokenService/Revoke":
+			return authRequired(ctx, req, handler)
+
+		// ServiceAccountService methods
+		case "/pb.ServiceAccountService/Create":
+			return authRequired(ctx, req, handler)
+		case "/pb.ServiceAccountService/List":
+			return authRequired(ctx, req, handler)
+		case "/pb.ServiceAccountService/Delete":
+			return authRequired(ctx, req, handler)
+
+		// RoleService methods
+		case "/pb.RoleService/List":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/Create":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/Update":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/Delete":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/Assign":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/Unassign":
+			return authRequired(ctx, req, handler)
+		case "/pb.RoleService/ListPerms":
+			return authRequired(ctx, req, handler)
+
+		// GroupService methods
+		case "/pb.GroupService/List":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/Create":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/Update":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/Delete":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/AddMember":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/RemoveMember":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/Associate":
+			return authRequired(ctx, req, handler)
+		case "/pb.GroupService/Dissociate":
+			return authRequired(ctx, req, handler)
 		default:
 			logrus.Debugf("rpc: auth not required for endpoint: '%s'", info.FullMethod)
 		}
 	}
 	return handler(ctx, req)
 }
+
+// RateLimitUnaryInterceptor returns an interceptor that limits the rate of the requests of each
+// remote client to the rate and the burst of the limiter. Requests over the limit are rejected
+// with codes.ResourceExhausted.
+//
+// Clients are told apart by their peer addresses; the requests that come through the REST gateway
+// are told apart by the addresses that it forwards. Requests of the cli, which come from the
+// loopback, aren't limited.
+func RateLimitUnaryInterceptor(limiter *rate.Limiter) grpc.UnaryServerInterceptor {
+	clients := newClientLimiters(limiter.Limit(), limiter.Burst())
+	return func(ctx gcontext.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		addr := rateLimitAddr(ctx)
+		if addr == "" {
+			return handler(ctx, req)
+		}
+		if !clients.allow(addr) {
+			logrus.Debugf("rpc: rate limit exceeded: '%s' from %s", info.FullMethod, addr)
+			return nil, grpc.Errorf(codes.ResourceExhausted, "rate limit exceeded, try again later")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitAddr returns the address that the request is rate limited by, or "" if it isn't limited.
+// The forwarded addresses are only trusted from the loopback, where the REST gateway is.
+func rateLimitAddr(ctx gcontext.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md["x-forwarded-for"]) == 0 {
+		return ""
+	}
+	addr := GetRemoteAddrFromContext(ctx)
+	if ip := net.ParseIP(addr); ip != nil && ip.IsLoopback() {
+		return ""
+	}
+	return addr
+}
+
+// clientLimiters holds a rate limiter for each client address. The limiters that are idle long
+// enough to be full again are dropped, since new ones are the same.
+type clientLimiters struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	idle     time.Duration
+	limiters map[string]*clientLimiter
+	pruned   time.Time
+}
+
+type clientLimiter struct {
+	*rate.Limiter
+	seen time.Time
+}
+
+func newClientLimiters(limit rate.Limit, burst int) *clientLimiters {
+	idle := time.Minute
+	if limit > 0 {
+		if d := time.Duration(float64(burst) / float64(limit) * float64(time.Second)); d > idle {
+			idle = d
+		}
+	}
+	return &clientLimiters{limit: limit, burst: burst, idle: idle, limiters: make(map[string]*clientLimiter), pruned: time.Now()}
+}
+
+// allow reports whether a request of the client at addr may happen now.
+func (c *clientLimiters) allow(addr string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.pruned) > c.idle {
+		for a, l := range c.limiters {
+			if now.Sub(l.seen) > c.idle {
+				delete(c.limiters, a)
+			}
+		}
+		c.pruned = now
+	}
+	l, ok := c.limiters[addr]
+	if !ok {
+		l = &clientLimiter{Limiter: rate.NewLimiter(c.limit, c.burst)}
+		c.limiters[addr] = l
+	}
+	l.seen = now
+	return l.AllowN(now, 1)
+}
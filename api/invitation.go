@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/master312/ovpm"
+	"github.com/sirupsen/logrus"
+)
+
+// invitationPage is the form that the invited users set their passwords with.
+var invitationPage = template.Must(template.New("invitation").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>VPN Invitation</title>
+</head>
+<body>
+<h3>VPN Invitation</h3>
+{{ if .Error }}<p style="color: #F44336">{{ .Error }}</p>
+{{ end }}{{ if .Username }}<p>Set a password for <b>{{ .Username }}</b> to download your VPN profile. You can log in
+with it later to download the profile again.</p>
+<form method="post">
+<p><label>Password <input type="password" name="password" autocomplete="new-password" required autofocus></label></p>
+<p><label>Confirm Password <input type="password" name="confirm" autocomplete="new-password" required></label></p>
+{{ if .TOTPSecret }}<p>Two-factor authentication is required. Add the key below to your authenticator app, or
+<a href="{{ .TOTPKeyURI }}">open it</a> on your phone, and enter the code that it shows. You will need the codes
+besides your password whenever you log in.</p>
+<p><code>{{ .TOTPSecret }}</code></p>
+<p><label>Code <input type="text" name="totp" inputmode="numeric" autocomplete="one-time-code" required></label></p>
+{{ end }}<button type="submit">Accept and Download</button>
+</form>
+{{ end }}</body>
+</html>
+`))
+
+// invitationHandler serves the invitations of the pending users:
+//
+//	GET  /api/v1/invitation/<token>   asks for the password of the invited user, and its totp code if it's required
+//	POST /api/v1/invitation/<token>   sets the password, activates the user and downloads its vpn profile
+type invitationHandler struct{}
+
+func newInvitationHandler() *invitationHandler {
+	return &invitationHandler{}
+}
+
+func (h *invitationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The token is in the path, it must not leak to the caches or the other sites.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	token := strings.TrimPrefix(r.URL.Path, ovpm.InvitationPath)
+	username, secret, err := ovpm.CheckInvitation(token)
+	if err != nil {
+		h.render(w, http.StatusNotFound, "", "", "This invitation is invalid or expired.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.render(w, http.StatusOK, username, secret, "")
+	case http.MethodPost:
+		password := r.PostFormValue("password")
+		if password != r.PostFormValue("confirm") {
+			h.render(w, http.StatusBadRequest, username, secret, "Passwords don't match.")
+			return
+		}
+		remoteAddr, _, _ := net.SplitHostPort(r.RemoteAddr)
+		_, profile, err := ovpm.AcceptInvitation(token, password, r.PostFormValue("totp"), remoteAddr)
+		if err != nil {
+			logrus.Debugf("invitation can not be accepted from %s: %v", remoteAddr, err)
+			// The invitation might be accepted concurrently.
+			if _, _, err := ovpm.CheckInvitation(token); err != nil {
+				username = ""
+			}
+			h.render(w, http.StatusBadRequest, username, secret, "The invitation can not be accepted, please try again.")
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-openvpn-profile")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", username+".ovpn"))
+		w.Write([]byte(profile))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *invitationHandler) render(w http.ResponseWriter, status int, username, secret, msg string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	var uri template.URL
+	if secret != "" {
+		uri = template.URL(ovpm.TOTPKeyURI(username, secret))
+	}
+	invitationPage.Execute(w, struct {
+		Username, TOTPSecret, Error string
+		TOTPKeyURI                  template.URL
+	}{username, secret, msg, uri})
+}
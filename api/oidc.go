@@ -0,0 +1,213 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"github.com/master312/ovpm"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcPathPrefix     = "/api/v1/auth/oidc/"
+	oidcStateCookie    = "ovpm_oidc_state"
+	oidcBackchannelEvt = "http://schemas.openid.net/event/backchannel-logout"
+	oidcLogoutTokenAge = 5 * time.Minute
+)
+
+// oidcHandler serves the OpenID Connect authorization code flow:
+//
+//	GET  /api/v1/auth/oidc/info                 whether the single sign-on is enabled
+//	GET  /api/v1/auth/oidc/login                redirects to the provider
+//	GET  /api/v1/auth/oidc/callback             redirects to /login#token=<token> after a successful login
+//	POST /api/v1/auth/oidc/backchannel-logout   ends the sessions that the provider logged out
+type oidcHandler struct {
+	ctx context.Context
+
+	lock           sync.Mutex
+	provider       *oidc.Provider
+	verifier       *oidc.IDTokenVerifier
+	logoutVerifier *oidc.IDTokenVerifier
+}
+
+func newOIDCHandler(ctx context.Context) *oidcHandler {
+	return &oidcHandler{ctx: ctx}
+}
+
+// discover fetches the provider metadata on the first use, so that an unreachable
+// provider doesn't prevent the daemon from starting.
+func (h *oidcHandler) discover(cfg *ovpm.OIDCConfig) (*oauth2.Config, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.provider == nil {
+		provider, err := oidc.NewProvider(h.ctx, cfg.IssuerURL)
+		if err != nil {
+			return nil, fmt.Errorf("oidc provider discovery failed %s: %v", cfg.IssuerURL, err)
+		}
+		h.provider = provider
+		h.verifier = provider.Verifier(&oidc.Config{ClientID: cfg.ClientID})
+		// Logout tokens don't necessarily have an expiry; their age is checked instead.
+		h.logoutVerifier = provider.Verifier(&oidc.Config{ClientID: cfg.ClientID, SkipExpiryCheck: true})
+	}
+	return &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Endpoint:     h.provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, cfg.Scopes...),
+	}, nil
+}
+
+func (h *oidcHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cfg := ovpm.GetOIDCConfig()
+	path := strings.TrimPrefix(r.URL.Path, oidcPathPrefix)
+	if path == "info" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]bool{"enabled": cfg != nil})
+		return
+	}
+	if cfg == nil {
+		http.NotFound(w, r)
+		return
+	}
+	oauth2Config, err := h.discover(cfg)
+	if err != nil {
+		logrus.Error(err)
+		http.Error(w, "oidc provider is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch path {
+	case "login":
+		h.login(w, r, oauth2Config)
+	case "callback":
+		h.callback(w, r, cfg, oauth2Config)
+	case "backchannel-logout":
+		h.backchannelLogout(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *oidcHandler) login(w http.ResponseWriter, r *http.Request, oauth2Config *oauth2.Config) {
+	state, nonce := randomHex(16), randomHex(16)
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state + "." + nonce,
+		Path:     oidcPathPrefix,
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(oauth2Config.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce)), http.StatusFound)
+}
+
+func (h *oidcHandler) callback(w http.ResponseWriter, r *http.Request, cfg *ovpm.OIDCConfig, oauth2Config *oauth2.Config) {
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		http.Error(w, "oidc state is missing", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: oidcPathPrefix, MaxAge: -1})
+	parts := strings.SplitN(cookie.Value, ".", 2)
+	if len(parts) != 2 || r.URL.Query().Get("state") != parts[0] {
+		http.Error(w, "oidc state mismatch", http.StatusBadRequest)
+		return
+	}
+	if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+		logrus.Debugf("oidc: provider returned error: %s: %s", errMsg, r.URL.Query().Get("error_description"))
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+
+	oauth2Token, err := oauth2Config.Exchange(r.Context(), r.URL.Query().Get("code"))
+	if err != nil {
+		logrus.Debugf("oidc: code exchange failed: %v", err)
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok {
+		http.Error(w, "oidc login failed: id token is missing", http.StatusUnauthorized)
+		return
+	}
+	idToken, err := h.verifier.Verify(r.Context(), rawIDToken)
+	if err != nil || idToken.Nonce != parts[1] {
+		logrus.Debugf("oidc: id token verification failed: %v", err)
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+	id, err := cfg.Identity(claims, idToken.Expiry)
+	if err != nil {
+		logrus.Debugln(err)
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+	_, token, err := ovpm.LoginOIDC(id)
+	if err != nil {
+		logrus.Debugln(err)
+		http.Error(w, "oidc login failed", http.StatusUnauthorized)
+		return
+	}
+	// Fragments aren't sent to servers, so the token doesn't end up in access logs.
+	http.Redirect(w, r, "/login#token="+url.QueryEscape(token), http.StatusFound)
+}
+
+// backchannelLogout implements OpenID Connect Back-Channel Logout 1.0.
+func (h *oidcHandler) backchannelLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	logoutToken, err := h.logoutVerifier.Verify(r.Context(), r.PostFormValue("logout_token"))
+	if err != nil {
+		logrus.Debugf("oidc: logout token verification failed: %v", err)
+		http.Error(w, "invalid logout token", http.StatusBadRequest)
+		return
+	}
+
+	var claims struct {
+		SessionID string                     `json:"sid"`
+		Nonce     string                     `json:"nonce"`
+		Events    map[string]json.RawMessage `json:"events"`
+	}
+	if err := logoutToken.Claims(&claims); err != nil {
+		http.Error(w, "invalid logout token", http.StatusBadRequest)
+		return
+	}
+	if _, ok := claims.Events[oidcBackchannelEvt]; !ok || claims.Nonce != "" || time.Since(logoutToken.IssuedAt) > oidcLogoutTokenAge {
+		http.Error(w, "invalid logout token", http.StatusBadRequest)
+		return
+	}
+	if err := ovpm.EndOIDCSession(logoutToken.Subject, claims.SessionID); err != nil {
+		http.Error(w, "invalid logout token", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
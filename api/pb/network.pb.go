@@ -35,10 +35,11 @@ type NetworkCreateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Cidr string `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Via  string `protobuf:"bytes,4,opt,name=via,proto3" json:"via,omitempty"`
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cidr  string `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Type  string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Via   string `protobuf:"bytes,4,opt,name=via,proto3" json:"via,omitempty"`
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"` // username of the vpn client that a CLIENTNET is behind
 }
 
 func (x *NetworkCreateRequest) Reset() {
@@ -101,6 +102,13 @@ func (x *NetworkCreateRequest) GetVia() string {
 	return ""
 }
 
+func (x *NetworkCreateRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
 type NetworkListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -229,8 +237,9 @@ type NetworkAssociateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Username string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Rules    []string `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"` // e.g. tcp/22,443; unrestricted if empty
 }
 
 func (x *NetworkAssociateRequest) Reset() {
@@ -279,6 +288,13 @@ func (x *NetworkAssociateRequest) GetUsername() string {
 	return ""
 }
 
+func (x *NetworkAssociateRequest) GetRules() []string {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
 type NetworkDissociateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -381,21 +397,19 @@ func (x *NetworkGetAssociatedUsersRequest) GetName() string {
 	return ""
 }
 
-type Network struct {
+type NetworkSetRulesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name                string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Cidr                string   `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
-	Type                string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	CreatedAt           string   `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	AssociatedUsernames []string `protobuf:"bytes,5,rep,name=associated_usernames,json=associatedUsernames,proto3" json:"associated_usernames,omitempty"`
-	Via                 string   `protobuf:"bytes,6,opt,name=via,proto3" json:"via,omitempty"`
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Username  string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // either username or group_name must be set
+	GroupName string   `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Rules     []string `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"` // lifts the restrictions if empty
 }
 
-func (x *Network) Reset() {
-	*x = Network{}
+func (x *NetworkSetRulesRequest) Reset() {
+	*x = NetworkSetRulesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -403,13 +417,13 @@ func (x *Network) Reset() {
 	}
 }
 
-func (x *Network) String() string {
+func (x *NetworkSetRulesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Network) ProtoMessage() {}
+func (*NetworkSetRulesRequest) ProtoMessage() {}
 
-func (x *Network) ProtoReflect() protoreflect.Message {
+func (x *NetworkSetRulesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -421,64 +435,52 @@ func (x *Network) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Network.ProtoReflect.Descriptor instead.
-func (*Network) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkSetRulesRequest.ProtoReflect.Descriptor instead.
+func (*NetworkSetRulesRequest) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Network) GetName() string {
+func (x *NetworkSetRulesRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Network) GetCidr() string {
-	if x != nil {
-		return x.Cidr
-	}
-	return ""
-}
-
-func (x *Network) GetType() string {
+func (x *NetworkSetRulesRequest) GetUsername() string {
 	if x != nil {
-		return x.Type
+		return x.Username
 	}
 	return ""
 }
 
-func (x *Network) GetCreatedAt() string {
+func (x *NetworkSetRulesRequest) GetGroupName() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.GroupName
 	}
 	return ""
 }
 
-func (x *Network) GetAssociatedUsernames() []string {
+func (x *NetworkSetRulesRequest) GetRules() []string {
 	if x != nil {
-		return x.AssociatedUsernames
+		return x.Rules
 	}
 	return nil
 }
 
-func (x *Network) GetVia() string {
-	if x != nil {
-		return x.Via
-	}
-	return ""
-}
-
-type NetworkType struct {
+type NetworkUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName string `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"` // leaves the name as it is if empty
+	Cidr    string `protobuf:"bytes,3,opt,name=cidr,proto3" json:"cidr,omitempty"`                      // leaves the cidr as it is if empty
+	Via     string `protobuf:"bytes,4,opt,name=via,proto3" json:"via,omitempty"`                        // leaves the via as it is if empty; vpn-server routes the network via the vpn server
 }
 
-func (x *NetworkType) Reset() {
-	*x = NetworkType{}
+func (x *NetworkUpdateRequest) Reset() {
+	*x = NetworkUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,13 +488,13 @@ func (x *NetworkType) Reset() {
 	}
 }
 
-func (x *NetworkType) String() string {
+func (x *NetworkUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkType) ProtoMessage() {}
+func (*NetworkUpdateRequest) ProtoMessage() {}
 
-func (x *NetworkType) ProtoReflect() protoreflect.Message {
+func (x *NetworkUpdateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -504,35 +506,51 @@ func (x *NetworkType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkType.ProtoReflect.Descriptor instead.
-func (*NetworkType) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkUpdateRequest.ProtoReflect.Descriptor instead.
+func (*NetworkUpdateRequest) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *NetworkType) GetType() string {
+func (x *NetworkUpdateRequest) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
 	return ""
 }
 
-func (x *NetworkType) GetDescription() string {
+func (x *NetworkUpdateRequest) GetNewName() string {
 	if x != nil {
-		return x.Description
+		return x.NewName
 	}
 	return ""
 }
 
-type NetworkCreateResponse struct {
+func (x *NetworkUpdateRequest) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *NetworkUpdateRequest) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
+}
+
+type NetworkSetDNSRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Domain  string   `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"` // removes the split dns if empty
+	Servers []string `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
 }
 
-func (x *NetworkCreateResponse) Reset() {
-	*x = NetworkCreateResponse{}
+func (x *NetworkSetDNSRequest) Reset() {
+	*x = NetworkSetDNSRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -540,13 +558,13 @@ func (x *NetworkCreateResponse) Reset() {
 	}
 }
 
-func (x *NetworkCreateResponse) String() string {
+func (x *NetworkSetDNSRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkCreateResponse) ProtoMessage() {}
+func (*NetworkSetDNSRequest) ProtoMessage() {}
 
-func (x *NetworkCreateResponse) ProtoReflect() protoreflect.Message {
+func (x *NetworkSetDNSRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -558,28 +576,40 @@ func (x *NetworkCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkCreateResponse.ProtoReflect.Descriptor instead.
-func (*NetworkCreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkSetDNSRequest.ProtoReflect.Descriptor instead.
+func (*NetworkSetDNSRequest) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *NetworkCreateResponse) GetNetwork() *Network {
+func (x *NetworkSetDNSRequest) GetName() string {
 	if x != nil {
-		return x.Network
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkSetDNSRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *NetworkSetDNSRequest) GetServers() []string {
+	if x != nil {
+		return x.Servers
 	}
 	return nil
 }
 
-type NetworkListResponse struct {
+type NetworkCheckRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Networks []*Network `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
 }
 
-func (x *NetworkListResponse) Reset() {
-	*x = NetworkListResponse{}
+func (x *NetworkCheckRequest) Reset() {
+	*x = NetworkCheckRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -587,13 +617,13 @@ func (x *NetworkListResponse) Reset() {
 	}
 }
 
-func (x *NetworkListResponse) String() string {
+func (x *NetworkCheckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkListResponse) ProtoMessage() {}
+func (*NetworkCheckRequest) ProtoMessage() {}
 
-func (x *NetworkListResponse) ProtoReflect() protoreflect.Message {
+func (x *NetworkCheckRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,28 +635,22 @@ func (x *NetworkListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkListResponse.ProtoReflect.Descriptor instead.
-func (*NetworkListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkCheckRequest.ProtoReflect.Descriptor instead.
+func (*NetworkCheckRequest) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *NetworkListResponse) GetNetworks() []*Network {
-	if x != nil {
-		return x.Networks
-	}
-	return nil
-}
-
-type NetworkDeleteResponse struct {
+type NetworkMapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MappedCidr string `protobuf:"bytes,2,opt,name=mapped_cidr,json=mappedCidr,proto3" json:"mapped_cidr,omitempty"` // removes the mapping if empty
 }
 
-func (x *NetworkDeleteResponse) Reset() {
-	*x = NetworkDeleteResponse{}
+func (x *NetworkMapRequest) Reset() {
+	*x = NetworkMapRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -634,13 +658,13 @@ func (x *NetworkDeleteResponse) Reset() {
 	}
 }
 
-func (x *NetworkDeleteResponse) String() string {
+func (x *NetworkMapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkDeleteResponse) ProtoMessage() {}
+func (*NetworkMapRequest) ProtoMessage() {}
 
-func (x *NetworkDeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *NetworkMapRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,28 +676,48 @@ func (x *NetworkDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkDeleteResponse.ProtoReflect.Descriptor instead.
-func (*NetworkDeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NetworkMapRequest.ProtoReflect.Descriptor instead.
+func (*NetworkMapRequest) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *NetworkDeleteResponse) GetNetwork() *Network {
+func (x *NetworkMapRequest) GetName() string {
 	if x != nil {
-		return x.Network
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type NetworkGetAllTypesResponse struct {
+func (x *NetworkMapRequest) GetMappedCidr() string {
+	if x != nil {
+		return x.MappedCidr
+	}
+	return ""
+}
+
+type Network struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Types []*NetworkType `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Name                string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cidr                string        `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	Type                string        `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedAt           string        `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AssociatedUsernames []string      `protobuf:"bytes,5,rep,name=associated_usernames,json=associatedUsernames,proto3" json:"associated_usernames,omitempty"`
+	Via                 string        `protobuf:"bytes,6,opt,name=via,proto3" json:"via,omitempty"`
+	AssociatedGroups    []string      `protobuf:"bytes,7,rep,name=associated_groups,json=associatedGroups,proto3" json:"associated_groups,omitempty"`
+	Acls                []*NetworkACL `protobuf:"bytes,8,rep,name=acls,proto3" json:"acls,omitempty"`
+	NatMode             string        `protobuf:"bytes,9,opt,name=nat_mode,json=natMode,proto3" json:"nat_mode,omitempty"`
+	SnatTo              string        `protobuf:"bytes,10,opt,name=snat_to,json=snatTo,proto3" json:"snat_to,omitempty"`
+	Owner               string        `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
+	MappedCidr          string        `protobuf:"bytes,12,opt,name=mapped_cidr,json=mappedCidr,proto3" json:"mapped_cidr,omitempty"` // virtual network that the clients reach the network at
+	DnsDomain           string        `protobuf:"bytes,13,opt,name=dns_domain,json=dnsDomain,proto3" json:"dns_domain,omitempty"`    // domain that is resolved by dns_servers for the associated clients
+	DnsServers          []string      `protobuf:"bytes,14,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
 }
 
-func (x *NetworkGetAllTypesResponse) Reset() {
-	*x = NetworkGetAllTypesResponse{}
+func (x *Network) Reset() {
+	*x = Network{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_network_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,13 +725,13 @@ func (x *NetworkGetAllTypesResponse) Reset() {
 	}
 }
 
-func (x *NetworkGetAllTypesResponse) String() string {
+func (x *Network) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkGetAllTypesResponse) ProtoMessage() {}
+func (*Network) ProtoMessage() {}
 
-func (x *NetworkGetAllTypesResponse) ProtoReflect() protoreflect.Message {
+func (x *Network) ProtoReflect() protoreflect.Message {
 	mi := &file_network_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -699,79 +743,136 @@ func (x *NetworkGetAllTypesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkGetAllTypesResponse.ProtoReflect.Descriptor instead.
-func (*NetworkGetAllTypesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Network.ProtoReflect.Descriptor instead.
+func (*Network) Descriptor() ([]byte, []int) {
 	return file_network_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *NetworkGetAllTypesResponse) GetTypes() []*NetworkType {
+func (x *Network) GetName() string {
 	if x != nil {
-		return x.Types
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Network) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *Network) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Network) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Network) GetAssociatedUsernames() []string {
+	if x != nil {
+		return x.AssociatedUsernames
 	}
 	return nil
 }
 
-type NetworkAssociateResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Network) GetVia() string {
+	if x != nil {
+		return x.Via
+	}
+	return ""
 }
 
-func (x *NetworkAssociateResponse) Reset() {
-	*x = NetworkAssociateResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_network_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Network) GetAssociatedGroups() []string {
+	if x != nil {
+		return x.AssociatedGroups
 	}
+	return nil
 }
 
-func (x *NetworkAssociateResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Network) GetAcls() []*NetworkACL {
+	if x != nil {
+		return x.Acls
+	}
+	return nil
 }
 
-func (*NetworkAssociateResponse) ProtoMessage() {}
+func (x *Network) GetNatMode() string {
+	if x != nil {
+		return x.NatMode
+	}
+	return ""
+}
 
-func (x *NetworkAssociateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_network_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Network) GetSnatTo() string {
+	if x != nil {
+		return x.SnatTo
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use NetworkAssociateResponse.ProtoReflect.Descriptor instead.
-func (*NetworkAssociateResponse) Descriptor() ([]byte, []int) {
-	return file_network_proto_rawDescGZIP(), []int{13}
+func (x *Network) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
 }
 
-type NetworkDissociateResponse struct {
+func (x *Network) GetMappedCidr() string {
+	if x != nil {
+		return x.MappedCidr
+	}
+	return ""
+}
+
+func (x *Network) GetDnsDomain() string {
+	if x != nil {
+		return x.DnsDomain
+	}
+	return ""
+}
+
+func (x *Network) GetDnsServers() []string {
+	if x != nil {
+		return x.DnsServers
+	}
+	return nil
+}
+
+type NetworkACL struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Username  string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	GroupName string   `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Rules     []string `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
 }
 
-func (x *NetworkDissociateResponse) Reset() {
-	*x = NetworkDissociateResponse{}
+func (x *NetworkACL) Reset() {
+	*x = NetworkACL{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_network_proto_msgTypes[14]
+		mi := &file_network_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NetworkDissociateResponse) String() string {
+func (x *NetworkACL) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NetworkDissociateResponse) ProtoMessage() {}
+func (*NetworkACL) ProtoMessage() {}
 
-func (x *NetworkDissociateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_network_proto_msgTypes[14]
+func (x *NetworkACL) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,23 +883,715 @@ func (x *NetworkDissociateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NetworkDissociateResponse.ProtoReflect.Descriptor instead.
-func (*NetworkDissociateResponse) Descriptor() ([]byte, []int) {
-	return file_network_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use NetworkACL.ProtoReflect.Descriptor instead.
+func (*NetworkACL) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{13}
 }
 
-type NetworkGetAssociatedUsersResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
+func (x *NetworkACL) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *NetworkACL) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *NetworkACL) GetRules() []string {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type NetworkType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *NetworkType) Reset() {
+	*x = NetworkType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkType) ProtoMessage() {}
+
+func (x *NetworkType) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkType.ProtoReflect.Descriptor instead.
+func (*NetworkType) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NetworkType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NetworkType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type NetworkCreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+}
+
+func (x *NetworkCreateResponse) Reset() {
+	*x = NetworkCreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkCreateResponse) ProtoMessage() {}
+
+func (x *NetworkCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkCreateResponse.ProtoReflect.Descriptor instead.
+func (*NetworkCreateResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NetworkCreateResponse) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+type NetworkListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Networks []*Network `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+}
+
+func (x *NetworkListResponse) Reset() {
+	*x = NetworkListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkListResponse) ProtoMessage() {}
+
+func (x *NetworkListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkListResponse.ProtoReflect.Descriptor instead.
+func (*NetworkListResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NetworkListResponse) GetNetworks() []*Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+type NetworkDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+}
+
+func (x *NetworkDeleteResponse) Reset() {
+	*x = NetworkDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkDeleteResponse) ProtoMessage() {}
+
+func (x *NetworkDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkDeleteResponse.ProtoReflect.Descriptor instead.
+func (*NetworkDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NetworkDeleteResponse) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+type NetworkGetAllTypesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Types []*NetworkType `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+}
+
+func (x *NetworkGetAllTypesResponse) Reset() {
+	*x = NetworkGetAllTypesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkGetAllTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkGetAllTypesResponse) ProtoMessage() {}
+
+func (x *NetworkGetAllTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkGetAllTypesResponse.ProtoReflect.Descriptor instead.
+func (*NetworkGetAllTypesResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NetworkGetAllTypesResponse) GetTypes() []*NetworkType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type NetworkAssociateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NetworkAssociateResponse) Reset() {
+	*x = NetworkAssociateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkAssociateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkAssociateResponse) ProtoMessage() {}
+
+func (x *NetworkAssociateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkAssociateResponse.ProtoReflect.Descriptor instead.
+func (*NetworkAssociateResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{19}
+}
+
+type NetworkDissociateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NetworkDissociateResponse) Reset() {
+	*x = NetworkDissociateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkDissociateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkDissociateResponse) ProtoMessage() {}
+
+func (x *NetworkDissociateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkDissociateResponse.ProtoReflect.Descriptor instead.
+func (*NetworkDissociateResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{20}
+}
+
+type NetworkSetRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NetworkSetRulesResponse) Reset() {
+	*x = NetworkSetRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkSetRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSetRulesResponse) ProtoMessage() {}
+
+func (x *NetworkSetRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSetRulesResponse.ProtoReflect.Descriptor instead.
+func (*NetworkSetRulesResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{21}
+}
+
+type NetworkUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+}
+
+func (x *NetworkUpdateResponse) Reset() {
+	*x = NetworkUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkUpdateResponse) ProtoMessage() {}
+
+func (x *NetworkUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkUpdateResponse.ProtoReflect.Descriptor instead.
+func (*NetworkUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NetworkUpdateResponse) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+type NetworkSetDNSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+}
+
+func (x *NetworkSetDNSResponse) Reset() {
+	*x = NetworkSetDNSResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkSetDNSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSetDNSResponse) ProtoMessage() {}
+
+func (x *NetworkSetDNSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSetDNSResponse.ProtoReflect.Descriptor instead.
+func (*NetworkSetDNSResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NetworkSetDNSResponse) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+type NetworkCIDRBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // vpn, network, mapped or interface
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cidr string `protobuf:"bytes,3,opt,name=cidr,proto3" json:"cidr,omitempty"`
+}
+
+func (x *NetworkCIDRBlock) Reset() {
+	*x = NetworkCIDRBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkCIDRBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkCIDRBlock) ProtoMessage() {}
+
+func (x *NetworkCIDRBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkCIDRBlock.ProtoReflect.Descriptor instead.
+func (*NetworkCIDRBlock) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NetworkCIDRBlock) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NetworkCIDRBlock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkCIDRBlock) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+type NetworkCIDRConflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block    *NetworkCIDRBlock `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Conflict *NetworkCIDRBlock `protobuf:"bytes,2,opt,name=conflict,proto3" json:"conflict,omitempty"`
+	Message  string            `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *NetworkCIDRConflict) Reset() {
+	*x = NetworkCIDRConflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkCIDRConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkCIDRConflict) ProtoMessage() {}
+
+func (x *NetworkCIDRConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkCIDRConflict.ProtoReflect.Descriptor instead.
+func (*NetworkCIDRConflict) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NetworkCIDRConflict) GetBlock() *NetworkCIDRBlock {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *NetworkCIDRConflict) GetConflict() *NetworkCIDRBlock {
+	if x != nil {
+		return x.Conflict
+	}
+	return nil
+}
+
+func (x *NetworkCIDRConflict) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type NetworkCheckResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Conflicts []*NetworkCIDRConflict `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+}
+
+func (x *NetworkCheckResponse) Reset() {
+	*x = NetworkCheckResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkCheckResponse) ProtoMessage() {}
+
+func (x *NetworkCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkCheckResponse.ProtoReflect.Descriptor instead.
+func (*NetworkCheckResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *NetworkCheckResponse) GetConflicts() []*NetworkCIDRConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type NetworkMapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network *Network `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+}
+
+func (x *NetworkMapResponse) Reset() {
+	*x = NetworkMapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_network_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkMapResponse) ProtoMessage() {}
+
+func (x *NetworkMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_network_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkMapResponse.ProtoReflect.Descriptor instead.
+func (*NetworkMapResponse) Descriptor() ([]byte, []int) {
+	return file_network_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NetworkMapResponse) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+type NetworkGetAssociatedUsersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	Usernames []string `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
 }
 
 func (x *NetworkGetAssociatedUsersResponse) Reset() {
 	*x = NetworkGetAssociatedUsersResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_network_proto_msgTypes[15]
+		mi := &file_network_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -811,7 +1604,7 @@ func (x *NetworkGetAssociatedUsersResponse) String() string {
 func (*NetworkGetAssociatedUsersResponse) ProtoMessage() {}
 
 func (x *NetworkGetAssociatedUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_network_proto_msgTypes[15]
+	mi := &file_network_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +1617,7 @@ func (x *NetworkGetAssociatedUsersResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use NetworkGetAssociatedUsersResponse.ProtoReflect.Descriptor instead.
 func (*NetworkGetAssociatedUsersResponse) Descriptor() ([]byte, []int) {
-	return file_network_proto_rawDescGZIP(), []int{15}
+	return file_network_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *NetworkGetAssociatedUsersResponse) GetUsernames() []string {
@@ -840,120 +1633,234 @@ var file_network_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x02, 0x70, 0x62, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
 	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x64, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61,
+	0x6f, 0x22, 0x7a, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
 	0x04, 0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64,
 	0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x61, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x61, 0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a,
-	0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x49, 0x0a, 0x17, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x4a, 0x0a, 0x18, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x73,
-	0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x14, 0x0a,
+	0x12, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x1b, 0x0a, 0x19, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a, 0x17,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x4a, 0x0a,
+	0x18, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x36, 0x0a, 0x20, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65,
+	0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x36, 0x0a,
-	0x20, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63,
-	0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa9, 0x01, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x14,
-	0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x61, 0x73, 0x73, 0x6f,
-	0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12,
-	0x10, 0x0a, 0x03, 0x76, 0x69, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69,
-	0x61, 0x22, 0x43, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x3e, 0x0a, 0x13, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
-	0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x43, 0x0a, 0x1a, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x1a, 0x0a, 0x18, 0x4e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x41, 0x0a, 0x21, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47,
-	0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65,
-	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73,
-	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x32, 0x8e, 0x06, 0x0a, 0x0e, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x06, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
-	0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x2f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x55, 0x0a, 0x04,
-	0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70,
-	0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x6c,
-	0x69, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e,
-	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x65, 0x22, 0x7d, 0x0a, 0x16, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x52,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x22, 0x6b, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6e, 0x65, 0x77, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6e, 0x65, 0x77, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x64, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x76,
+	0x69, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x61, 0x22, 0x5c, 0x0a,
+	0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x48, 0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x61, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x61, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x61, 0x70, 0x70, 0x65, 0x64, 0x43, 0x69, 0x64, 0x72, 0x22, 0xa5, 0x03, 0x0a,
+	0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x14, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x13, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x61, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x61, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x73, 0x73, 0x6f,
+	0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x22, 0x0a, 0x04, 0x61, 0x63, 0x6c, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x41, 0x43, 0x4c, 0x52, 0x04, 0x61, 0x63, 0x6c, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x61, 0x74,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x61, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6e, 0x61, 0x74, 0x5f, 0x74, 0x6f, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6e, 0x61, 0x74, 0x54, 0x6f, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x63, 0x69,
+	0x64, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x70, 0x70, 0x65, 0x64,
+	0x43, 0x69, 0x64, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x22, 0x5d, 0x0a, 0x0a, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41,
+	0x43, 0x4c, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x25, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x3e, 0x0a, 0x13, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x27, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x25, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x43, 0x0a, 0x1a, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x1a, 0x0a,
+	0x18, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x53, 0x65, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x22, 0x3e, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x44,
+	0x4e, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x22, 0x4e, 0x0a, 0x10, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x49, 0x44, 0x52,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x69, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x64,
+	0x72, 0x22, 0x8d, 0x01, 0x0a, 0x13, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x49, 0x44,
+	0x52, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x49, 0x44, 0x52, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x43, 0x49, 0x44, 0x52, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x08, 0x63,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x4d, 0x0a, 0x14, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
+	0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x49, 0x44, 0x52, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73,
+	0x22, 0x3b, 0x0a, 0x12, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x61, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x41, 0x0a,
+	0x21, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x32, 0xed, 0x09, 0x0a, 0x0e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x60, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x77, 0x6f, 0x72, 0x6b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x71, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54,
-	0x79, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x61, 0x70,
-	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x67, 0x65, 0x74,
-	0x61, 0x6c, 0x6c, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x8d, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74,
-	0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12,
-	0x24, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41,
-	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55,
-	0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x67, 0x65, 0x74, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
-	0x74, 0x65, 0x64, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x6c, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f,
-	0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41,
-	0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
-	0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69,
-	0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63,
-	0x69, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44,
-	0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x69, 0x73, 0x73, 0x6f,
-	0x63, 0x69, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x55, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x06,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x71,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x2e,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70,
+	0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x67, 0x65, 0x74, 0x61, 0x6c, 0x6c, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x12, 0x8d, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x47, 0x65, 0x74, 0x41, 0x73,
+	0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x67,
+	0x65, 0x74, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x64, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x12, 0x6c, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x1b,
+	0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1e, 0x22, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2f, 0x61, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12,
+	0x70, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63,
+	0x69, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x3a, 0x01,
+	0x2a, 0x12, 0x68, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x2e,
+	0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x52, 0x75, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x22, 0x18,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f,
+	0x73, 0x65, 0x74, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x60, 0x0a, 0x06, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x60, 0x0a,
+	0x06, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65,
+	0x74, 0x44, 0x4e, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x73, 0x65, 0x74, 0x64, 0x6e, 0x73, 0x3a, 0x01, 0x2a, 0x12,
+	0x59, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x54, 0x0a, 0x03, 0x4d, 0x61,
+	0x70, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x61,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x6d, 0x61, 0x70, 0x3a, 0x01, 0x2a,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -968,7 +1875,7 @@ func file_network_proto_rawDescGZIP() []byte {
 	return file_network_proto_rawDescData
 }
 
-var file_network_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_network_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_network_proto_goTypes = []interface{}{
 	(*NetworkCreateRequest)(nil),              // 0: pb.NetworkCreateRequest
 	(*NetworkListRequest)(nil),                // 1: pb.NetworkListRequest
@@ -977,40 +1884,70 @@ var file_network_proto_goTypes = []interface{}{
 	(*NetworkAssociateRequest)(nil),           // 4: pb.NetworkAssociateRequest
 	(*NetworkDissociateRequest)(nil),          // 5: pb.NetworkDissociateRequest
 	(*NetworkGetAssociatedUsersRequest)(nil),  // 6: pb.NetworkGetAssociatedUsersRequest
-	(*Network)(nil),                           // 7: pb.Network
-	(*NetworkType)(nil),                       // 8: pb.NetworkType
-	(*NetworkCreateResponse)(nil),             // 9: pb.NetworkCreateResponse
-	(*NetworkListResponse)(nil),               // 10: pb.NetworkListResponse
-	(*NetworkDeleteResponse)(nil),             // 11: pb.NetworkDeleteResponse
-	(*NetworkGetAllTypesResponse)(nil),        // 12: pb.NetworkGetAllTypesResponse
-	(*NetworkAssociateResponse)(nil),          // 13: pb.NetworkAssociateResponse
-	(*NetworkDissociateResponse)(nil),         // 14: pb.NetworkDissociateResponse
-	(*NetworkGetAssociatedUsersResponse)(nil), // 15: pb.NetworkGetAssociatedUsersResponse
+	(*NetworkSetRulesRequest)(nil),            // 7: pb.NetworkSetRulesRequest
+	(*NetworkUpdateRequest)(nil),              // 8: pb.NetworkUpdateRequest
+	(*NetworkSetDNSRequest)(nil),              // 9: pb.NetworkSetDNSRequest
+	(*NetworkCheckRequest)(nil),               // 10: pb.NetworkCheckRequest
+	(*NetworkMapRequest)(nil),                 // 11: pb.NetworkMapRequest
+	(*Network)(nil),                           // 12: pb.Network
+	(*NetworkACL)(nil),                        // 13: pb.NetworkACL
+	(*NetworkType)(nil),                       // 14: pb.NetworkType
+	(*NetworkCreateResponse)(nil),             // 15: pb.NetworkCreateResponse
+	(*NetworkListResponse)(nil),               // 16: pb.NetworkListResponse
+	(*NetworkDeleteResponse)(nil),             // 17: pb.NetworkDeleteResponse
+	(*NetworkGetAllTypesResponse)(nil),        // 18: pb.NetworkGetAllTypesResponse
+	(*NetworkAssociateResponse)(nil),          // 19: pb.NetworkAssociateResponse
+	(*NetworkDissociateResponse)(nil),         // 20: pb.NetworkDissociateResponse
+	(*NetworkSetRulesResponse)(nil),           // 21: pb.NetworkSetRulesResponse
+	(*NetworkUpdateResponse)(nil),             // 22: pb.NetworkUpdateResponse
+	(*NetworkSetDNSResponse)(nil),             // 23: pb.NetworkSetDNSResponse
+	(*NetworkCIDRBlock)(nil),                  // 24: pb.NetworkCIDRBlock
+	(*NetworkCIDRConflict)(nil),               // 25: pb.NetworkCIDRConflict
+	(*NetworkCheckResponse)(nil),              // 26: pb.NetworkCheckResponse
+	(*NetworkMapResponse)(nil),                // 27: pb.NetworkMapResponse
+	(*NetworkGetAssociatedUsersResponse)(nil), // 28: pb.NetworkGetAssociatedUsersResponse
 }
 var file_network_proto_depIdxs = []int32{
-	7,  // 0: pb.NetworkCreateResponse.network:type_name -> pb.Network
-	7,  // 1: pb.NetworkListResponse.networks:type_name -> pb.Network
-	7,  // 2: pb.NetworkDeleteResponse.network:type_name -> pb.Network
-	8,  // 3: pb.NetworkGetAllTypesResponse.types:type_name -> pb.NetworkType
-	0,  // 4: pb.NetworkService.Create:input_type -> pb.NetworkCreateRequest
-	1,  // 5: pb.NetworkService.List:input_type -> pb.NetworkListRequest
-	2,  // 6: pb.NetworkService.Delete:input_type -> pb.NetworkDeleteRequest
-	3,  // 7: pb.NetworkService.GetAllTypes:input_type -> pb.NetworkGetAllTypesRequest
-	6,  // 8: pb.NetworkService.GetAssociatedUsers:input_type -> pb.NetworkGetAssociatedUsersRequest
-	4,  // 9: pb.NetworkService.Associate:input_type -> pb.NetworkAssociateRequest
-	5,  // 10: pb.NetworkService.Dissociate:input_type -> pb.NetworkDissociateRequest
-	9,  // 11: pb.NetworkService.Create:output_type -> pb.NetworkCreateResponse
-	10, // 12: pb.NetworkService.List:output_type -> pb.NetworkListResponse
-	11, // 13: pb.NetworkService.Delete:output_type -> pb.NetworkDeleteResponse
-	12, // 14: pb.NetworkService.GetAllTypes:output_type -> pb.NetworkGetAllTypesResponse
-	15, // 15: pb.NetworkService.GetAssociatedUsers:output_type -> pb.NetworkGetAssociatedUsersResponse
-	13, // 16: pb.NetworkService.Associate:output_type -> pb.NetworkAssociateResponse
-	14, // 17: pb.NetworkService.Dissociate:output_type -> pb.NetworkDissociateResponse
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 0: pb.Network.acls:type_name -> pb.NetworkACL
+	12, // 1: pb.NetworkCreateResponse.network:type_name -> pb.Network
+	12, // 2: pb.NetworkListResponse.networks:type_name -> pb.Network
+	12, // 3: pb.NetworkDeleteResponse.network:type_name -> pb.Network
+	14, // 4: pb.NetworkGetAllTypesResponse.types:type_name -> pb.NetworkType
+	12, // 5: pb.NetworkUpdateResponse.network:type_name -> pb.Network
+	12, // 6: pb.NetworkSetDNSResponse.network:type_name -> pb.Network
+	24, // 7: pb.NetworkCIDRConflict.block:type_name -> pb.NetworkCIDRBlock
+	24, // 8: pb.NetworkCIDRConflict.conflict:type_name -> pb.NetworkCIDRBlock
+	25, // 9: pb.NetworkCheckResponse.conflicts:type_name -> pb.NetworkCIDRConflict
+	12, // 10: pb.NetworkMapResponse.network:type_name -> pb.Network
+	0,  // 11: pb.NetworkService.Create:input_type -> pb.NetworkCreateRequest
+	1,  // 12: pb.NetworkService.List:input_type -> pb.NetworkListRequest
+	2,  // 13: pb.NetworkService.Delete:input_type -> pb.NetworkDeleteRequest
+	3,  // 14: pb.NetworkService.GetAllTypes:input_type -> pb.NetworkGetAllTypesRequest
+	6,  // 15: pb.NetworkService.GetAssociatedUsers:input_type -> pb.NetworkGetAssociatedUsersRequest
+	4,  // 16: pb.NetworkService.Associate:input_type -> pb.NetworkAssociateRequest
+	5,  // 17: pb.NetworkService.Dissociate:input_type -> pb.NetworkDissociateRequest
+	7,  // 18: pb.NetworkService.SetRules:input_type -> pb.NetworkSetRulesRequest
+	8,  // 19: pb.NetworkService.Update:input_type -> pb.NetworkUpdateRequest
+	9,  // 20: pb.NetworkService.SetDNS:input_type -> pb.NetworkSetDNSRequest
+	10, // 21: pb.NetworkService.Check:input_type -> pb.NetworkCheckRequest
+	11, // 22: pb.NetworkService.Map:input_type -> pb.NetworkMapRequest
+	15, // 23: pb.NetworkService.Create:output_type -> pb.NetworkCreateResponse
+	16, // 24: pb.NetworkService.List:output_type -> pb.NetworkListResponse
+	17, // 25: pb.NetworkService.Delete:output_type -> pb.NetworkDeleteResponse
+	18, // 26: pb.NetworkService.GetAllTypes:output_type -> pb.NetworkGetAllTypesResponse
+	28, // 27: pb.NetworkService.GetAssociatedUsers:output_type -> pb.NetworkGetAssociatedUsersResponse
+	19, // 28: pb.NetworkService.Associate:output_type -> pb.NetworkAssociateResponse
+	20, // 29: pb.NetworkService.Dissociate:output_type -> pb.NetworkDissociateResponse
+	21, // 30: pb.NetworkService.SetRules:output_type -> pb.NetworkSetRulesResponse
+	22, // 31: pb.NetworkService.Update:output_type -> pb.NetworkUpdateResponse
+	23, // 32: pb.NetworkService.SetDNS:output_type -> pb.NetworkSetDNSResponse
+	26, // 33: pb.NetworkService.Check:output_type -> pb.NetworkCheckResponse
+	27, // 34: pb.NetworkService.Map:output_type -> pb.NetworkMapResponse
+	23, // [23:35] is the sub-list for method output_type
+	11, // [11:23] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_network_proto_init() }
@@ -1104,7 +2041,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Network); i {
+			switch v := v.(*NetworkSetRulesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1116,7 +2053,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkType); i {
+			switch v := v.(*NetworkUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1128,7 +2065,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkCreateResponse); i {
+			switch v := v.(*NetworkSetDNSRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1140,7 +2077,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkListResponse); i {
+			switch v := v.(*NetworkCheckRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1152,7 +2089,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkDeleteResponse); i {
+			switch v := v.(*NetworkMapRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1164,7 +2101,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkGetAllTypesResponse); i {
+			switch v := v.(*Network); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1176,7 +2113,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkAssociateResponse); i {
+			switch v := v.(*NetworkACL); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1188,7 +2125,7 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkDissociateResponse); i {
+			switch v := v.(*NetworkType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,6 +2137,162 @@ func file_network_proto_init() {
 			}
 		}
 		file_network_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkGetAllTypesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkAssociateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkDissociateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkSetRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkUpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkSetDNSResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkCIDRBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkCIDRConflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkCheckResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkMapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_network_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NetworkGetAssociatedUsersResponse); i {
 			case 0:
 				return &v.state
@@ -1218,7 +2311,7 @@ func file_network_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_network_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1251,6 +2344,11 @@ type NetworkServiceClient interface {
 	GetAssociatedUsers(ctx context.Context, in *NetworkGetAssociatedUsersRequest, opts ...grpc.CallOption) (*NetworkGetAssociatedUsersResponse, error)
 	Associate(ctx context.Context, in *NetworkAssociateRequest, opts ...grpc.CallOption) (*NetworkAssociateResponse, error)
 	Dissociate(ctx context.Context, in *NetworkDissociateRequest, opts ...grpc.CallOption) (*NetworkDissociateResponse, error)
+	SetRules(ctx context.Context, in *NetworkSetRulesRequest, opts ...grpc.CallOption) (*NetworkSetRulesResponse, error)
+	Update(ctx context.Context, in *NetworkUpdateRequest, opts ...grpc.CallOption) (*NetworkUpdateResponse, error)
+	SetDNS(ctx context.Context, in *NetworkSetDNSRequest, opts ...grpc.CallOption) (*NetworkSetDNSResponse, error)
+	Check(ctx context.Context, in *NetworkCheckRequest, opts ...grpc.CallOption) (*NetworkCheckResponse, error)
+	Map(ctx context.Context, in *NetworkMapRequest, opts ...grpc.CallOption) (*NetworkMapResponse, error)
 }
 
 type networkServiceClient struct {
@@ -1324,6 +2422,51 @@ func (c *networkServiceClient) Dissociate(ctx context.Context, in *NetworkDissoc
 	return out, nil
 }
 
+func (c *networkServiceClient) SetRules(ctx context.Context, in *NetworkSetRulesRequest, opts ...grpc.CallOption) (*NetworkSetRulesResponse, error) {
+	out := new(NetworkSetRulesResponse)
+	err := c.cc.Invoke(ctx, "/pb.NetworkService/SetRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) Update(ctx context.Context, in *NetworkUpdateRequest, opts ...grpc.CallOption) (*NetworkUpdateResponse, error) {
+	out := new(NetworkUpdateResponse)
+	err := c.cc.Invoke(ctx, "/pb.NetworkService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) SetDNS(ctx context.Context, in *NetworkSetDNSRequest, opts ...grpc.CallOption) (*NetworkSetDNSResponse, error) {
+	out := new(NetworkSetDNSResponse)
+	err := c.cc.Invoke(ctx, "/pb.NetworkService/SetDNS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) Check(ctx context.Context, in *NetworkCheckRequest, opts ...grpc.CallOption) (*NetworkCheckResponse, error) {
+	out := new(NetworkCheckResponse)
+	err := c.cc.Invoke(ctx, "/pb.NetworkService/Check", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) Map(ctx context.Context, in *NetworkMapRequest, opts ...grpc.CallOption) (*NetworkMapResponse, error) {
+	out := new(NetworkMapResponse)
+	err := c.cc.Invoke(ctx, "/pb.NetworkService/Map", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceServer is the server API for NetworkService service.
 type NetworkServiceServer interface {
 	Create(context.Context, *NetworkCreateRequest) (*NetworkCreateResponse, error)
@@ -1333,6 +2476,11 @@ type NetworkServiceServer interface {
 	GetAssociatedUsers(context.Context, *NetworkGetAssociatedUsersRequest) (*NetworkGetAssociatedUsersResponse, error)
 	Associate(context.Context, *NetworkAssociateRequest) (*NetworkAssociateResponse, error)
 	Dissociate(context.Context, *NetworkDissociateRequest) (*NetworkDissociateResponse, error)
+	SetRules(context.Context, *NetworkSetRulesRequest) (*NetworkSetRulesResponse, error)
+	Update(context.Context, *NetworkUpdateRequest) (*NetworkUpdateResponse, error)
+	SetDNS(context.Context, *NetworkSetDNSRequest) (*NetworkSetDNSResponse, error)
+	Check(context.Context, *NetworkCheckRequest) (*NetworkCheckResponse, error)
+	Map(context.Context, *NetworkMapRequest) (*NetworkMapResponse, error)
 }
 
 // UnimplementedNetworkServiceServer can be embedded to have forward compatible implementations.
@@ -1360,6 +2508,21 @@ func (*UnimplementedNetworkServiceServer) Associate(context.Context, *NetworkAss
 func (*UnimplementedNetworkServiceServer) Dissociate(context.Context, *NetworkDissociateRequest) (*NetworkDissociateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Dissociate not implemented")
 }
+func (*UnimplementedNetworkServiceServer) SetRules(context.Context, *NetworkSetRulesRequest) (*NetworkSetRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRules not implemented")
+}
+func (*UnimplementedNetworkServiceServer) Update(context.Context, *NetworkUpdateRequest) (*NetworkUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (*UnimplementedNetworkServiceServer) SetDNS(context.Context, *NetworkSetDNSRequest) (*NetworkSetDNSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDNS not implemented")
+}
+func (*UnimplementedNetworkServiceServer) Check(context.Context, *NetworkCheckRequest) (*NetworkCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (*UnimplementedNetworkServiceServer) Map(context.Context, *NetworkMapRequest) (*NetworkMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Map not implemented")
+}
 
 func RegisterNetworkServiceServer(s *grpc.Server, srv NetworkServiceServer) {
 	s.RegisterService(&_NetworkService_serviceDesc, srv)
@@ -1491,6 +2654,96 @@ func _NetworkService_Dissociate_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkService_SetRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkSetRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).SetRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NetworkService/SetRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).SetRules(ctx, req.(*NetworkSetRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NetworkService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).Update(ctx, req.(*NetworkUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_SetDNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkSetDNSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).SetDNS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NetworkService/SetDNS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).SetDNS(ctx, req.(*NetworkSetDNSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NetworkService/Check",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).Check(ctx, req.(*NetworkCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_Map_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).Map(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.NetworkService/Map",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).Map(ctx, req.(*NetworkMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.NetworkService",
 	HandlerType: (*NetworkServiceServer)(nil),
@@ -1523,6 +2776,26 @@ var _NetworkService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Dissociate",
 			Handler:    _NetworkService_Dissociate_Handler,
 		},
+		{
+			MethodName: "SetRules",
+			Handler:    _NetworkService_SetRules_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _NetworkService_Update_Handler,
+		},
+		{
+			MethodName: "SetDNS",
+			Handler:    _NetworkService_SetDNS_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _NetworkService_Check_Handler,
+		},
+		{
+			MethodName: "Map",
+			Handler:    _NetworkService_Map_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "network.proto",
@@ -274,9 +274,21 @@ type VPNUpdateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IpBlock string     `protobuf:"bytes,1,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
-	Dns     string     `protobuf:"bytes,2,opt,name=dns,proto3" json:"dns,omitempty"`
-	LzoPref VPNLZOPref `protobuf:"varint,3,opt,name=lzo_pref,json=lzoPref,proto3,enum=pb.VPNLZOPref" json:"lzo_pref,omitempty"`
+	IpBlock            string           `protobuf:"bytes,1,opt,name=ip_block,json=ipBlock,proto3" json:"ip_block,omitempty"`
+	Dns                string           `protobuf:"bytes,2,opt,name=dns,proto3" json:"dns,omitempty"` // comma separated
+	LzoPref            VPNLZOPref       `protobuf:"varint,3,opt,name=lzo_pref,json=lzoPref,proto3,enum=pb.VPNLZOPref" json:"lzo_pref,omitempty"`
+	NatMode            string           `protobuf:"bytes,4,opt,name=nat_mode,json=natMode,proto3" json:"nat_mode,omitempty"` // masquerade, snat or none
+	SnatTo             string           `protobuf:"bytes,5,opt,name=snat_to,json=snatTo,proto3" json:"snat_to,omitempty"`
+	EgressIface        string           `protobuf:"bytes,6,opt,name=egress_iface,json=egressIface,proto3" json:"egress_iface,omitempty"` // "auto" detects it from the default route
+	NetworkNats        []*VPNNetworkNAT `protobuf:"bytes,7,rep,name=network_nats,json=networkNats,proto3" json:"network_nats,omitempty"`
+	Domain             string           `protobuf:"bytes,8,opt,name=domain,proto3" json:"domain,omitempty"` // left untouched if empty, unless reset_domain is set
+	ResetDomain        bool             `protobuf:"varint,9,opt,name=reset_domain,json=resetDomain,proto3" json:"reset_domain,omitempty"`
+	SearchDomains      []string         `protobuf:"bytes,10,rep,name=search_domains,json=searchDomains,proto3" json:"search_domains,omitempty"` // left untouched if empty, unless reset_search_domains is set
+	ResetSearchDomains bool             `protobuf:"varint,11,opt,name=reset_search_domains,json=resetSearchDomains,proto3" json:"reset_search_domains,omitempty"`
+	Ntp                []string         `protobuf:"bytes,12,rep,name=ntp,proto3" json:"ntp,omitempty"` // left untouched if empty, unless reset_ntp is set
+	ResetNtp           bool             `protobuf:"varint,13,opt,name=reset_ntp,json=resetNtp,proto3" json:"reset_ntp,omitempty"`
+	Wins               []string         `protobuf:"bytes,14,rep,name=wins,proto3" json:"wins,omitempty"` // left untouched if empty, unless reset_wins is set
+	ResetWins          bool             `protobuf:"varint,15,opt,name=reset_wins,json=resetWins,proto3" json:"reset_wins,omitempty"`
 }
 
 func (x *VPNUpdateRequest) Reset() {
@@ -332,6 +344,153 @@ func (x *VPNUpdateRequest) GetLzoPref() VPNLZOPref {
 	return VPNLZOPref_USE_LZO_NOPREF
 }
 
+func (x *VPNUpdateRequest) GetNatMode() string {
+	if x != nil {
+		return x.NatMode
+	}
+	return ""
+}
+
+func (x *VPNUpdateRequest) GetSnatTo() string {
+	if x != nil {
+		return x.SnatTo
+	}
+	return ""
+}
+
+func (x *VPNUpdateRequest) GetEgressIface() string {
+	if x != nil {
+		return x.EgressIface
+	}
+	return ""
+}
+
+func (x *VPNUpdateRequest) GetNetworkNats() []*VPNNetworkNAT {
+	if x != nil {
+		return x.NetworkNats
+	}
+	return nil
+}
+
+func (x *VPNUpdateRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *VPNUpdateRequest) GetResetDomain() bool {
+	if x != nil {
+		return x.ResetDomain
+	}
+	return false
+}
+
+func (x *VPNUpdateRequest) GetSearchDomains() []string {
+	if x != nil {
+		return x.SearchDomains
+	}
+	return nil
+}
+
+func (x *VPNUpdateRequest) GetResetSearchDomains() bool {
+	if x != nil {
+		return x.ResetSearchDomains
+	}
+	return false
+}
+
+func (x *VPNUpdateRequest) GetNtp() []string {
+	if x != nil {
+		return x.Ntp
+	}
+	return nil
+}
+
+func (x *VPNUpdateRequest) GetResetNtp() bool {
+	if x != nil {
+		return x.ResetNtp
+	}
+	return false
+}
+
+func (x *VPNUpdateRequest) GetWins() []string {
+	if x != nil {
+		return x.Wins
+	}
+	return nil
+}
+
+func (x *VPNUpdateRequest) GetResetWins() bool {
+	if x != nil {
+		return x.ResetWins
+	}
+	return false
+}
+
+type VPNNetworkNAT struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkName string `protobuf:"bytes,1,opt,name=network_name,json=networkName,proto3" json:"network_name,omitempty"`
+	NatMode     string `protobuf:"bytes,2,opt,name=nat_mode,json=natMode,proto3" json:"nat_mode,omitempty"` // masquerade, snat, none or inherit
+	SnatTo      string `protobuf:"bytes,3,opt,name=snat_to,json=snatTo,proto3" json:"snat_to,omitempty"`
+}
+
+func (x *VPNNetworkNAT) Reset() {
+	*x = VPNNetworkNAT{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNNetworkNAT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNNetworkNAT) ProtoMessage() {}
+
+func (x *VPNNetworkNAT) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNNetworkNAT.ProtoReflect.Descriptor instead.
+func (*VPNNetworkNAT) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VPNNetworkNAT) GetNetworkName() string {
+	if x != nil {
+		return x.NetworkName
+	}
+	return ""
+}
+
+func (x *VPNNetworkNAT) GetNatMode() string {
+	if x != nil {
+		return x.NatMode
+	}
+	return ""
+}
+
+func (x *VPNNetworkNAT) GetSnatTo() string {
+	if x != nil {
+		return x.SnatTo
+	}
+	return ""
+}
+
 type VPNRestartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -341,7 +500,7 @@ type VPNRestartRequest struct {
 func (x *VPNRestartRequest) Reset() {
 	*x = VPNRestartRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[3]
+		mi := &file_vpn_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -354,7 +513,7 @@ func (x *VPNRestartRequest) String() string {
 func (*VPNRestartRequest) ProtoMessage() {}
 
 func (x *VPNRestartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[3]
+	mi := &file_vpn_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +526,201 @@ func (x *VPNRestartRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VPNRestartRequest.ProtoReflect.Descriptor instead.
 func (*VPNRestartRequest) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{3}
+	return file_vpn_proto_rawDescGZIP(), []int{4}
+}
+
+type VPNFirewallShowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNFirewallShowRequest) Reset() {
+	*x = VPNFirewallShowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNFirewallShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNFirewallShowRequest) ProtoMessage() {}
+
+func (x *VPNFirewallShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNFirewallShowRequest.ProtoReflect.Descriptor instead.
+func (*VPNFirewallShowRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{5}
+}
+
+type VPNIPPoolListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNIPPoolListRequest) Reset() {
+	*x = VPNIPPoolListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNIPPoolListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNIPPoolListRequest) ProtoMessage() {}
+
+func (x *VPNIPPoolListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNIPPoolListRequest.ProtoReflect.Descriptor instead.
+func (*VPNIPPoolListRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{6}
+}
+
+type VPNIPPoolCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Start  string `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`   // first address of the range
+	End    string `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`       // last address of the range
+	Subnet string `protobuf:"bytes,4,opt,name=subnet,proto3" json:"subnet,omitempty"` // network beside the vpn network in the CIDR form, instead of the range
+}
+
+func (x *VPNIPPoolCreateRequest) Reset() {
+	*x = VPNIPPoolCreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNIPPoolCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNIPPoolCreateRequest) ProtoMessage() {}
+
+func (x *VPNIPPoolCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNIPPoolCreateRequest.ProtoReflect.Descriptor instead.
+func (*VPNIPPoolCreateRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VPNIPPoolCreateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VPNIPPoolCreateRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *VPNIPPoolCreateRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *VPNIPPoolCreateRequest) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
+}
+
+type VPNIPPoolDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *VPNIPPoolDeleteRequest) Reset() {
+	*x = VPNIPPoolDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNIPPoolDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNIPPoolDeleteRequest) ProtoMessage() {}
+
+func (x *VPNIPPoolDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNIPPoolDeleteRequest.ProtoReflect.Descriptor instead.
+func (*VPNIPPoolDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VPNIPPoolDeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
 type VPNStatusResponse struct {
@@ -375,26 +728,36 @@ type VPNStatusResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	Hostname     string `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Port         string `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
-	Cert         string `protobuf:"bytes,5,opt,name=cert,proto3" json:"cert,omitempty"`
-	CaCert       string `protobuf:"bytes,6,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
-	Net          string `protobuf:"bytes,7,opt,name=net,proto3" json:"net,omitempty"`
-	Mask         string `protobuf:"bytes,8,opt,name=mask,proto3" json:"mask,omitempty"`
-	CreatedAt    string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Proto        string `protobuf:"bytes,10,opt,name=proto,proto3" json:"proto,omitempty"`
-	Dns          string `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
-	ExpiresAt    string `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	CaExpiresAt  string `protobuf:"bytes,13,opt,name=ca_expires_at,json=caExpiresAt,proto3" json:"ca_expires_at,omitempty"`
-	UseLzo       bool   `protobuf:"varint,14,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	Name          string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SerialNumber  string   `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Hostname      string   `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Port          string   `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
+	Cert          string   `protobuf:"bytes,5,opt,name=cert,proto3" json:"cert,omitempty"`
+	CaCert        string   `protobuf:"bytes,6,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
+	Net           string   `protobuf:"bytes,7,opt,name=net,proto3" json:"net,omitempty"`
+	Mask          string   `protobuf:"bytes,8,opt,name=mask,proto3" json:"mask,omitempty"`
+	CreatedAt     string   `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Proto         string   `protobuf:"bytes,10,opt,name=proto,proto3" json:"proto,omitempty"`
+	Dns           string   `protobuf:"bytes,11,opt,name=dns,proto3" json:"dns,omitempty"`
+	ExpiresAt     string   `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CaExpiresAt   string   `protobuf:"bytes,13,opt,name=ca_expires_at,json=caExpiresAt,proto3" json:"ca_expires_at,omitempty"`
+	UseLzo        bool     `protobuf:"varint,14,opt,name=use_lzo,json=useLzo,proto3" json:"use_lzo,omitempty"`
+	NatEnabled    bool     `protobuf:"varint,15,opt,name=nat_enabled,json=natEnabled,proto3" json:"nat_enabled,omitempty"`
+	NatError      string   `protobuf:"bytes,16,opt,name=nat_error,json=natError,proto3" json:"nat_error,omitempty"`
+	NatAttempts   int32    `protobuf:"varint,17,opt,name=nat_attempts,json=natAttempts,proto3" json:"nat_attempts,omitempty"`
+	NatMode       string   `protobuf:"bytes,18,opt,name=nat_mode,json=natMode,proto3" json:"nat_mode,omitempty"`
+	SnatTo        string   `protobuf:"bytes,19,opt,name=snat_to,json=snatTo,proto3" json:"snat_to,omitempty"`
+	EgressIface   string   `protobuf:"bytes,20,opt,name=egress_iface,json=egressIface,proto3" json:"egress_iface,omitempty"`
+	Domain        string   `protobuf:"bytes,21,opt,name=domain,proto3" json:"domain,omitempty"`
+	SearchDomains []string `protobuf:"bytes,22,rep,name=search_domains,json=searchDomains,proto3" json:"search_domains,omitempty"`
+	Ntp           []string `protobuf:"bytes,23,rep,name=ntp,proto3" json:"ntp,omitempty"`
+	Wins          []string `protobuf:"bytes,24,rep,name=wins,proto3" json:"wins,omitempty"`
 }
 
 func (x *VPNStatusResponse) Reset() {
 	*x = VPNStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[4]
+		mi := &file_vpn_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -407,7 +770,7 @@ func (x *VPNStatusResponse) String() string {
 func (*VPNStatusResponse) ProtoMessage() {}
 
 func (x *VPNStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[4]
+	mi := &file_vpn_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +783,7 @@ func (x *VPNStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VPNStatusResponse.ProtoReflect.Descriptor instead.
 func (*VPNStatusResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{4}
+	return file_vpn_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *VPNStatusResponse) GetName() string {
@@ -516,34 +879,282 @@ func (x *VPNStatusResponse) GetCaExpiresAt() string {
 
 func (x *VPNStatusResponse) GetUseLzo() bool {
 	if x != nil {
-		return x.UseLzo
+		return x.UseLzo
+	}
+	return false
+}
+
+func (x *VPNStatusResponse) GetNatEnabled() bool {
+	if x != nil {
+		return x.NatEnabled
+	}
+	return false
+}
+
+func (x *VPNStatusResponse) GetNatError() string {
+	if x != nil {
+		return x.NatError
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetNatAttempts() int32 {
+	if x != nil {
+		return x.NatAttempts
+	}
+	return 0
+}
+
+func (x *VPNStatusResponse) GetNatMode() string {
+	if x != nil {
+		return x.NatMode
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetSnatTo() string {
+	if x != nil {
+		return x.SnatTo
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetEgressIface() string {
+	if x != nil {
+		return x.EgressIface
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *VPNStatusResponse) GetSearchDomains() []string {
+	if x != nil {
+		return x.SearchDomains
+	}
+	return nil
+}
+
+func (x *VPNStatusResponse) GetNtp() []string {
+	if x != nil {
+		return x.Ntp
+	}
+	return nil
+}
+
+func (x *VPNStatusResponse) GetWins() []string {
+	if x != nil {
+		return x.Wins
+	}
+	return nil
+}
+
+type VPNInitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNInitResponse) Reset() {
+	*x = VPNInitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNInitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNInitResponse) ProtoMessage() {}
+
+func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNInitResponse.ProtoReflect.Descriptor instead.
+func (*VPNInitResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{10}
+}
+
+type VPNUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNUpdateResponse) Reset() {
+	*x = VPNUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNUpdateResponse) ProtoMessage() {}
+
+func (x *VPNUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNUpdateResponse.ProtoReflect.Descriptor instead.
+func (*VPNUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{11}
+}
+
+type VPNRestartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VPNRestartResponse) Reset() {
+	*x = VPNRestartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNRestartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNRestartResponse) ProtoMessage() {}
+
+func (x *VPNRestartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNRestartResponse.ProtoReflect.Descriptor instead.
+func (*VPNRestartResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{12}
+}
+
+type VPNFirewallShowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	Dump    string `protobuf:"bytes,2,opt,name=dump,proto3" json:"dump,omitempty"`
+}
+
+func (x *VPNFirewallShowResponse) Reset() {
+	*x = VPNFirewallShowResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vpn_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VPNFirewallShowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VPNFirewallShowResponse) ProtoMessage() {}
+
+func (x *VPNFirewallShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VPNFirewallShowResponse.ProtoReflect.Descriptor instead.
+func (*VPNFirewallShowResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VPNFirewallShowResponse) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *VPNFirewallShowResponse) GetDump() string {
+	if x != nil {
+		return x.Dump
 	}
-	return false
+	return ""
 }
 
-type VPNInitResponse struct {
+type VPNIPPool struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Start  string   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End    string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Size   int32    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Used   int32    `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`    // leased addresses, including the static reservations in the range
+	Subnet string   `protobuf:"bytes,6,opt,name=subnet,proto3" json:"subnet,omitempty"` // network of the pool, if it's beside the vpn network
+	Groups []string `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty"` // groups that the pool is assigned to
+	Users  []string `protobuf:"bytes,8,rep,name=users,proto3" json:"users,omitempty"`   // users that the pool is assigned to
 }
 
-func (x *VPNInitResponse) Reset() {
-	*x = VPNInitResponse{}
+func (x *VPNIPPool) Reset() {
+	*x = VPNIPPool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[5]
+		mi := &file_vpn_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VPNInitResponse) String() string {
+func (x *VPNIPPool) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNInitResponse) ProtoMessage() {}
+func (*VPNIPPool) ProtoMessage() {}
 
-func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[5]
+func (x *VPNIPPool) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,72 +1165,92 @@ func (x *VPNInitResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VPNInitResponse.ProtoReflect.Descriptor instead.
-func (*VPNInitResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VPNIPPool.ProtoReflect.Descriptor instead.
+func (*VPNIPPool) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{14}
 }
 
-type VPNUpdateResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VPNIPPool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *VPNUpdateResponse) Reset() {
-	*x = VPNUpdateResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VPNIPPool) GetStart() string {
+	if x != nil {
+		return x.Start
 	}
+	return ""
 }
 
-func (x *VPNUpdateResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VPNIPPool) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
 }
 
-func (*VPNUpdateResponse) ProtoMessage() {}
+func (x *VPNIPPool) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
 
-func (x *VPNUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VPNIPPool) GetUsed() int32 {
+	if x != nil {
+		return x.Used
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use VPNUpdateResponse.ProtoReflect.Descriptor instead.
-func (*VPNUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{6}
+func (x *VPNIPPool) GetSubnet() string {
+	if x != nil {
+		return x.Subnet
+	}
+	return ""
 }
 
-type VPNRestartResponse struct {
+func (x *VPNIPPool) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *VPNIPPool) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type VPNIPPoolListResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Pools []*VPNIPPool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
 }
 
-func (x *VPNRestartResponse) Reset() {
-	*x = VPNRestartResponse{}
+func (x *VPNIPPoolListResponse) Reset() {
+	*x = VPNIPPoolListResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_vpn_proto_msgTypes[7]
+		mi := &file_vpn_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VPNRestartResponse) String() string {
+func (x *VPNIPPoolListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VPNRestartResponse) ProtoMessage() {}
+func (*VPNIPPoolListResponse) ProtoMessage() {}
 
-func (x *VPNRestartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vpn_proto_msgTypes[7]
+func (x *VPNIPPoolListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vpn_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,9 +1261,16 @@ func (x *VPNRestartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VPNRestartResponse.ProtoReflect.Descriptor instead.
-func (*VPNRestartResponse) Descriptor() ([]byte, []int) {
-	return file_vpn_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use VPNIPPoolListResponse.ProtoReflect.Descriptor instead.
+func (*VPNIPPoolListResponse) Descriptor() ([]byte, []int) {
+	return file_vpn_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VPNIPPoolListResponse) GetPools() []*VPNIPPool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
 }
 
 var File_vpn_proto protoreflect.FileDescriptor
@@ -659,71 +1297,179 @@ var file_vpn_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x54,
 	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a,
 	0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x22,
-	0x6a, 0x0a, 0x10, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10,
-	0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73,
-	0x12, 0x29, 0x0a, 0x08, 0x6c, 0x7a, 0x6f, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72,
-	0x65, 0x66, 0x52, 0x07, 0x6c, 0x7a, 0x6f, 0x50, 0x72, 0x65, 0x66, 0x22, 0x13, 0x0a, 0x11, 0x56,
-	0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0xf2, 0x02, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65,
-	0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
-	0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
-	0x65, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6e, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61,
-	0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70,
-	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x5f, 0x65,
-	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x61, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x22, 0x11, 0x0a, 0x0f, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x0a,
-	0x12, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2a, 0x28, 0x0a, 0x08, 0x56, 0x50, 0x4e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55,
-	0x44, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x02, 0x2a, 0x49, 0x0a,
-	0x0a, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x0e, 0x55,
-	0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10, 0x00, 0x12,
-	0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c,
-	0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x44,
-	0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x32, 0xda, 0x02, 0x0a, 0x0a, 0x56, 0x50, 0x4e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
-	0x76, 0x70, 0x6e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x04, 0x49, 0x6e,
-	0x69, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49,
-	0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e,
-	0x2f, 0x69, 0x6e, 0x69, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x54, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
-	0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
-	0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x55,
-	0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56,
-	0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15,
-	0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x72, 0x65,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0xed, 0x03, 0x0a, 0x10, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x70, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x6e,
+	0x73, 0x12, 0x29, 0x0a, 0x08, 0x6c, 0x7a, 0x6f, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50,
+	0x72, 0x65, 0x66, 0x52, 0x07, 0x6c, 0x7a, 0x6f, 0x50, 0x72, 0x65, 0x66, 0x12, 0x19, 0x0a, 0x08,
+	0x6e, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6e, 0x61, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6e, 0x61, 0x74, 0x5f,
+	0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6e, 0x61, 0x74, 0x54, 0x6f,
+	0x12, 0x21, 0x0a, 0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x66, 0x61, 0x63, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x66,
+	0x61, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x6e,
+	0x61, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x56,
+	0x50, 0x4e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x41, 0x54, 0x52, 0x0b, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x74, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72,
+	0x65, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x73, 0x65, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x6e, 0x74, 0x70, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x74, 0x70, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x6e, 0x74, 0x70, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x74, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x77, 0x69, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x74, 0x57, 0x69, 0x6e, 0x73, 0x22,
+	0x66, 0x0a, 0x0d, 0x56, 0x50, 0x4e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x41, 0x54,
+	0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x61, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x73, 0x6e, 0x61, 0x74, 0x5f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x6e, 0x61, 0x74, 0x54, 0x6f, 0x22, 0x13, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x52, 0x65,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x18, 0x0a, 0x16,
+	0x56, 0x50, 0x4e, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x68, 0x6f, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50,
+	0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x6c,
+	0x0a, 0x16, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x22, 0x2c, 0x0a, 0x16,
+	0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x8f, 0x05, 0x0a, 0x11, 0x56,
+	0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x65, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x64, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x41, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x61, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x7a,
+	0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x73, 0x65, 0x4c, 0x7a, 0x6f, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x74, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6e, 0x61, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x61, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x61, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x6e, 0x61, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x6e, 0x61, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x6e, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6e, 0x61, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73,
+	0x6e, 0x61, 0x74, 0x5f, 0x74, 0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6e,
+	0x61, 0x74, 0x54, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69,
+	0x66, 0x61, 0x63, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x49, 0x66, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x74, 0x70, 0x18, 0x17, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x74, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73,
+	0x18, 0x18, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x22, 0x11, 0x0a, 0x0f,
+	0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x13, 0x0a, 0x11, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x47, 0x0a, 0x17, 0x56, 0x50,
+	0x4e, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x75, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x75, 0x6d, 0x70, 0x22, 0xb5, 0x01, 0x0a, 0x09, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x15, 0x56,
+	0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f,
+	0x6f, 0x6c, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x2a, 0x28, 0x0a, 0x08, 0x56, 0x50, 0x4e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x50, 0x52, 0x45, 0x46, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x44, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43,
+	0x50, 0x10, 0x02, 0x2a, 0x49, 0x0a, 0x0a, 0x56, 0x50, 0x4e, 0x4c, 0x5a, 0x4f, 0x50, 0x72, 0x65,
+	0x66, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x4e, 0x4f, 0x50,
+	0x52, 0x45, 0x46, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x53, 0x45, 0x5f, 0x4c, 0x5a, 0x4f,
+	0x5f, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x53, 0x45,
+	0x5f, 0x4c, 0x5a, 0x4f, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x32, 0xfa,
+	0x05, 0x0a, 0x0a, 0x56, 0x50, 0x4e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x4c, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
+	0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70,
+	0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x69, 0x6e, 0x69, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x54,
+	0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50,
+	0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x3a, 0x01, 0x2a, 0x12, 0x55, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x52,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
+	0x76, 0x70, 0x6e, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x65, 0x0a, 0x0c, 0x46,
+	0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x1a, 0x2e, 0x70, 0x62,
+	0x2e, 0x56, 0x50, 0x4e, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x68, 0x6f, 0x77,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e,
+	0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x66, 0x69, 0x72, 0x65, 0x77, 0x61,
+	0x6c, 0x6c, 0x12, 0x5d, 0x0a, 0x0a, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x69, 0x70, 0x70, 0x6f, 0x6f,
+	0x6c, 0x12, 0x6b, 0x0a, 0x0c, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e,
+	0x22, 0x19, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x69, 0x70,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x6b,
+	0x0a, 0x0c, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a,
+	0x2e, 0x70, 0x62, 0x2e, 0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e,
+	0x56, 0x50, 0x4e, 0x49, 0x50, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x70, 0x6e, 0x2f, 0x69, 0x70, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -739,35 +1485,53 @@ func file_vpn_proto_rawDescGZIP() []byte {
 }
 
 var file_vpn_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_vpn_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_vpn_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_vpn_proto_goTypes = []interface{}{
-	(VPNProto)(0),              // 0: pb.VPNProto
-	(VPNLZOPref)(0),            // 1: pb.VPNLZOPref
-	(*VPNStatusRequest)(nil),   // 2: pb.VPNStatusRequest
-	(*VPNInitRequest)(nil),     // 3: pb.VPNInitRequest
-	(*VPNUpdateRequest)(nil),   // 4: pb.VPNUpdateRequest
-	(*VPNRestartRequest)(nil),  // 5: pb.VPNRestartRequest
-	(*VPNStatusResponse)(nil),  // 6: pb.VPNStatusResponse
-	(*VPNInitResponse)(nil),    // 7: pb.VPNInitResponse
-	(*VPNUpdateResponse)(nil),  // 8: pb.VPNUpdateResponse
-	(*VPNRestartResponse)(nil), // 9: pb.VPNRestartResponse
+	(VPNProto)(0),                   // 0: pb.VPNProto
+	(VPNLZOPref)(0),                 // 1: pb.VPNLZOPref
+	(*VPNStatusRequest)(nil),        // 2: pb.VPNStatusRequest
+	(*VPNInitRequest)(nil),          // 3: pb.VPNInitRequest
+	(*VPNUpdateRequest)(nil),        // 4: pb.VPNUpdateRequest
+	(*VPNNetworkNAT)(nil),           // 5: pb.VPNNetworkNAT
+	(*VPNRestartRequest)(nil),       // 6: pb.VPNRestartRequest
+	(*VPNFirewallShowRequest)(nil),  // 7: pb.VPNFirewallShowRequest
+	(*VPNIPPoolListRequest)(nil),    // 8: pb.VPNIPPoolListRequest
+	(*VPNIPPoolCreateRequest)(nil),  // 9: pb.VPNIPPoolCreateRequest
+	(*VPNIPPoolDeleteRequest)(nil),  // 10: pb.VPNIPPoolDeleteRequest
+	(*VPNStatusResponse)(nil),       // 11: pb.VPNStatusResponse
+	(*VPNInitResponse)(nil),         // 12: pb.VPNInitResponse
+	(*VPNUpdateResponse)(nil),       // 13: pb.VPNUpdateResponse
+	(*VPNRestartResponse)(nil),      // 14: pb.VPNRestartResponse
+	(*VPNFirewallShowResponse)(nil), // 15: pb.VPNFirewallShowResponse
+	(*VPNIPPool)(nil),               // 16: pb.VPNIPPool
+	(*VPNIPPoolListResponse)(nil),   // 17: pb.VPNIPPoolListResponse
 }
 var file_vpn_proto_depIdxs = []int32{
-	0, // 0: pb.VPNInitRequest.proto_pref:type_name -> pb.VPNProto
-	1, // 1: pb.VPNUpdateRequest.lzo_pref:type_name -> pb.VPNLZOPref
-	2, // 2: pb.VPNService.Status:input_type -> pb.VPNStatusRequest
-	3, // 3: pb.VPNService.Init:input_type -> pb.VPNInitRequest
-	4, // 4: pb.VPNService.Update:input_type -> pb.VPNUpdateRequest
-	5, // 5: pb.VPNService.Restart:input_type -> pb.VPNRestartRequest
-	6, // 6: pb.VPNService.Status:output_type -> pb.VPNStatusResponse
-	7, // 7: pb.VPNService.Init:output_type -> pb.VPNInitResponse
-	8, // 8: pb.VPNService.Update:output_type -> pb.VPNUpdateResponse
-	9, // 9: pb.VPNService.Restart:output_type -> pb.VPNRestartResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: pb.VPNInitRequest.proto_pref:type_name -> pb.VPNProto
+	1,  // 1: pb.VPNUpdateRequest.lzo_pref:type_name -> pb.VPNLZOPref
+	5,  // 2: pb.VPNUpdateRequest.network_nats:type_name -> pb.VPNNetworkNAT
+	16, // 3: pb.VPNIPPoolListResponse.pools:type_name -> pb.VPNIPPool
+	2,  // 4: pb.VPNService.Status:input_type -> pb.VPNStatusRequest
+	3,  // 5: pb.VPNService.Init:input_type -> pb.VPNInitRequest
+	4,  // 6: pb.VPNService.Update:input_type -> pb.VPNUpdateRequest
+	6,  // 7: pb.VPNService.Restart:input_type -> pb.VPNRestartRequest
+	7,  // 8: pb.VPNService.FirewallShow:input_type -> pb.VPNFirewallShowRequest
+	8,  // 9: pb.VPNService.IPPoolList:input_type -> pb.VPNIPPoolListRequest
+	9,  // 10: pb.VPNService.IPPoolCreate:input_type -> pb.VPNIPPoolCreateRequest
+	10, // 11: pb.VPNService.IPPoolDelete:input_type -> pb.VPNIPPoolDeleteRequest
+	11, // 12: pb.VPNService.Status:output_type -> pb.VPNStatusResponse
+	12, // 13: pb.VPNService.Init:output_type -> pb.VPNInitResponse
+	13, // 14: pb.VPNService.Update:output_type -> pb.VPNUpdateResponse
+	14, // 15: pb.VPNService.Restart:output_type -> pb.VPNRestartResponse
+	15, // 16: pb.VPNService.FirewallShow:output_type -> pb.VPNFirewallShowResponse
+	17, // 17: pb.VPNService.IPPoolList:output_type -> pb.VPNIPPoolListResponse
+	17, // 18: pb.VPNService.IPPoolCreate:output_type -> pb.VPNIPPoolListResponse
+	17, // 19: pb.VPNService.IPPoolDelete:output_type -> pb.VPNIPPoolListResponse
+	12, // [12:20] is the sub-list for method output_type
+	4,  // [4:12] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_vpn_proto_init() }
@@ -813,7 +1577,7 @@ func file_vpn_proto_init() {
 			}
 		}
 		file_vpn_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNRestartRequest); i {
+			switch v := v.(*VPNNetworkNAT); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -825,7 +1589,7 @@ func file_vpn_proto_init() {
 			}
 		}
 		file_vpn_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNStatusResponse); i {
+			switch v := v.(*VPNRestartRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -837,7 +1601,7 @@ func file_vpn_proto_init() {
 			}
 		}
 		file_vpn_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNInitResponse); i {
+			switch v := v.(*VPNFirewallShowRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -849,7 +1613,7 @@ func file_vpn_proto_init() {
 			}
 		}
 		file_vpn_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VPNUpdateResponse); i {
+			switch v := v.(*VPNIPPoolListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -861,6 +1625,66 @@ func file_vpn_proto_init() {
 			}
 		}
 		file_vpn_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNIPPoolCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNIPPoolDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNInitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNUpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VPNRestartResponse); i {
 			case 0:
 				return &v.state
@@ -872,6 +1696,42 @@ func file_vpn_proto_init() {
 				return nil
 			}
 		}
+		file_vpn_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNFirewallShowResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNIPPool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vpn_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VPNIPPoolListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -879,7 +1739,7 @@ func file_vpn_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_vpn_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -910,6 +1770,10 @@ type VPNServiceClient interface {
 	Init(ctx context.Context, in *VPNInitRequest, opts ...grpc.CallOption) (*VPNInitResponse, error)
 	Update(ctx context.Context, in *VPNUpdateRequest, opts ...grpc.CallOption) (*VPNUpdateResponse, error)
 	Restart(ctx context.Context, in *VPNRestartRequest, opts ...grpc.CallOption) (*VPNRestartResponse, error)
+	FirewallShow(ctx context.Context, in *VPNFirewallShowRequest, opts ...grpc.CallOption) (*VPNFirewallShowResponse, error)
+	IPPoolList(ctx context.Context, in *VPNIPPoolListRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error)
+	IPPoolCreate(ctx context.Context, in *VPNIPPoolCreateRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error)
+	IPPoolDelete(ctx context.Context, in *VPNIPPoolDeleteRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error)
 }
 
 type vPNServiceClient struct {
@@ -956,12 +1820,52 @@ func (c *vPNServiceClient) Restart(ctx context.Context, in *VPNRestartRequest, o
 	return out, nil
 }
 
+func (c *vPNServiceClient) FirewallShow(ctx context.Context, in *VPNFirewallShowRequest, opts ...grpc.CallOption) (*VPNFirewallShowResponse, error) {
+	out := new(VPNFirewallShowResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/FirewallShow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) IPPoolList(ctx context.Context, in *VPNIPPoolListRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error) {
+	out := new(VPNIPPoolListResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/IPPoolList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) IPPoolCreate(ctx context.Context, in *VPNIPPoolCreateRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error) {
+	out := new(VPNIPPoolListResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/IPPoolCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vPNServiceClient) IPPoolDelete(ctx context.Context, in *VPNIPPoolDeleteRequest, opts ...grpc.CallOption) (*VPNIPPoolListResponse, error) {
+	out := new(VPNIPPoolListResponse)
+	err := c.cc.Invoke(ctx, "/pb.VPNService/IPPoolDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VPNServiceServer is the server API for VPNService service.
 type VPNServiceServer interface {
 	Status(context.Context, *VPNStatusRequest) (*VPNStatusResponse, error)
 	Init(context.Context, *VPNInitRequest) (*VPNInitResponse, error)
 	Update(context.Context, *VPNUpdateRequest) (*VPNUpdateResponse, error)
 	Restart(context.Context, *VPNRestartRequest) (*VPNRestartResponse, error)
+	FirewallShow(context.Context, *VPNFirewallShowRequest) (*VPNFirewallShowResponse, error)
+	IPPoolList(context.Context, *VPNIPPoolListRequest) (*VPNIPPoolListResponse, error)
+	IPPoolCreate(context.Context, *VPNIPPoolCreateRequest) (*VPNIPPoolListResponse, error)
+	IPPoolDelete(context.Context, *VPNIPPoolDeleteRequest) (*VPNIPPoolListResponse, error)
 }
 
 // UnimplementedVPNServiceServer can be embedded to have forward compatible implementations.
@@ -980,6 +1884,18 @@ func (*UnimplementedVPNServiceServer) Update(context.Context, *VPNUpdateRequest)
 func (*UnimplementedVPNServiceServer) Restart(context.Context, *VPNRestartRequest) (*VPNRestartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
 }
+func (*UnimplementedVPNServiceServer) FirewallShow(context.Context, *VPNFirewallShowRequest) (*VPNFirewallShowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FirewallShow not implemented")
+}
+func (*UnimplementedVPNServiceServer) IPPoolList(context.Context, *VPNIPPoolListRequest) (*VPNIPPoolListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IPPoolList not implemented")
+}
+func (*UnimplementedVPNServiceServer) IPPoolCreate(context.Context, *VPNIPPoolCreateRequest) (*VPNIPPoolListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IPPoolCreate not implemented")
+}
+func (*UnimplementedVPNServiceServer) IPPoolDelete(context.Context, *VPNIPPoolDeleteRequest) (*VPNIPPoolListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IPPoolDelete not implemented")
+}
 
 func RegisterVPNServiceServer(s *grpc.Server, srv VPNServiceServer) {
 	s.RegisterService(&_VPNService_serviceDesc, srv)
@@ -1057,6 +1973,78 @@ func _VPNService_Restart_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VPNService_FirewallShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNFirewallShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).FirewallShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/FirewallShow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).FirewallShow(ctx, req.(*VPNFirewallShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_IPPoolList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNIPPoolListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).IPPoolList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/IPPoolList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).IPPoolList(ctx, req.(*VPNIPPoolListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_IPPoolCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNIPPoolCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).IPPoolCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/IPPoolCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).IPPoolCreate(ctx, req.(*VPNIPPoolCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VPNService_IPPoolDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VPNIPPoolDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VPNServiceServer).IPPoolDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VPNService/IPPoolDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VPNServiceServer).IPPoolDelete(ctx, req.(*VPNIPPoolDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _VPNService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.VPNService",
 	HandlerType: (*VPNServiceServer)(nil),
@@ -1077,6 +2065,22 @@ var _VPNService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Restart",
 			Handler:    _VPNService_Restart_Handler,
 		},
+		{
+			MethodName: "FirewallShow",
+			Handler:    _VPNService_FirewallShow_Handler,
+		},
+		{
+			MethodName: "IPPoolList",
+			Handler:    _VPNService_IPPoolList_Handler,
+		},
+		{
+			MethodName: "IPPoolCreate",
+			Handler:    _VPNService_IPPoolCreate_Handler,
+		},
+		{
+			MethodName: "IPPoolDelete",
+			Handler:    _VPNService_IPPoolDelete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vpn.proto",
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/master312/ovpm"
+	"github.com/sirupsen/logrus"
+)
+
+// profileLinkPage is the form that asks for the secret that unlocks a profile link.
+var profileLinkPage = template.Must(template.New("profile-link").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Download VPN Profile</title>
+</head>
+<body>
+<h3>Download VPN Profile</h3>
+{{ if .Error }}<p style="color: #F44336">{{ .Error }}</p>
+{{ end }}{{ if .Valid }}<form method="post">
+<label>{{ if .Passcode }}Passcode{{ else }}Password{{ end }}
+<input type="password" name="secret" autocomplete="off" required autofocus>
+</label>
+{{ if not .Passcode }}<label>Authenticator code, if you use two-factor authentication
+<input type="text" name="totp" inputmode="numeric" autocomplete="one-time-code">
+</label>
+{{ end }}<button type="submit">Download</button>
+</form>
+<p>The link can only be used once.</p>
+{{ end }}</body>
+</html>
+`))
+
+// profileLinkHandler serves the one-time profile download links:
+//
+//	GET  /api/v1/profile-link/<token>   asks for the password or the passcode of the link
+//	POST /api/v1/profile-link/<token>   downloads the vpn profile, if the posted secret unlocks the link
+type profileLinkHandler struct{}
+
+func newProfileLinkHandler() *profileLinkHandler {
+	return &profileLinkHandler{}
+}
+
+func (h *profileLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The token is in the path, it must not leak to the caches or the other sites.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	token := strings.TrimPrefix(r.URL.Path, ovpm.ProfileLinkPath)
+	passcode, err := ovpm.IsProfileLinkPasscode(token)
+	if err != nil {
+		h.render(w, http.StatusNotFound, false, false, "This link is invalid or expired.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.render(w, http.StatusOK, true, passcode, "")
+	case http.MethodPost:
+		remoteAddr, _, _ := net.SplitHostPort(r.RemoteAddr)
+		username, profile, err := ovpm.RedeemProfileLink(token, r.PostFormValue("secret"), r.PostFormValue("totp"), remoteAddr)
+		if err != nil {
+			logrus.Debugf("profile link can not be redeemed from %s: %v", remoteAddr, err)
+			// The link might be revoked after too many failed attempts.
+			_, err := ovpm.IsProfileLinkPasscode(token)
+			h.render(w, http.StatusForbidden, err == nil, passcode, "The profile can not be downloaded, please try again.")
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-openvpn-profile")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", username+".ovpn"))
+		w.Write([]byte(profile))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *profileLinkHandler) render(w http.ResponseWriter, status int, valid, passcode bool, msg string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	profileLinkPage.Execute(w, struct {
+		Valid, Passcode bool
+		Error           string
+	}{valid, passcode, msg})
+}
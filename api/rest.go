@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
 	"github.com/master312/ovpm/api/pb"
 	"github.com/master312/ovpm/bundle"
 	assetfs "github.com/elazarl/go-bindata-assetfs"
@@ -48,6 +49,29 @@ func NewRESTServer(grpcPort string) (http.Handler, context.CancelFunc, error) {
 		return nil, cancel, err
 	}
 
+	err = pb.RegisterTokenServiceHandlerFromEndpoint(ctx, gmux, endPoint, opts)
+	if err != nil {
+		return nil, cancel, err
+	}
+
+	err = pb.RegisterServiceAccountServiceHandlerFromEndpoint(ctx, gmux, endPoint, opts)
+	if err != nil {
+		return nil, cancel, err
+	}
+
+	err = pb.RegisterRoleServiceHandlerFromEndpoint(ctx, gmux, endPoint, opts)
+	if err != nil {
+		return nil, cancel, err
+	}
+
+	err = pb.RegisterGroupServiceHandlerFromEndpoint(ctx, gmux, endPoint, opts)
+	if err != nil {
+		return nil, cancel, err
+	}
+
+	mux.Handle(oidcPathPrefix, newOIDCHandler(ctx))
+	mux.Handle(ovpm.ProfileLinkPath, newProfileLinkHandler())
+	mux.Handle(ovpm.InvitationPath, newInvitationHandler())
 	mux.HandleFunc("/api/specs/", specsHandler)
 	mware := middleware.Redoc(middleware.RedocOpts{
 		BasePath: "/api/docs/",
@@ -69,6 +93,21 @@ func NewRESTServer(grpcPort string) (http.Handler, context.CancelFunc, error) {
 		SpecURL:  "/api/specs/auth.swagger.json",
 		Path:     "auth",
 	}, mware)
+	mware = middleware.Redoc(middleware.RedocOpts{
+		BasePath: "/api/docs/",
+		SpecURL:  "/api/specs/token.swagger.json",
+		Path:     "token",
+	}, mware)
+	mware = middleware.Redoc(middleware.RedocOpts{
+		BasePath: "/api/docs/",
+		SpecURL:  "/api/specs/role.swagger.json",
+		Path:     "role",
+	}, mware)
+	mware = middleware.Redoc(middleware.RedocOpts{
+		BasePath: "/api/docs/",
+		SpecURL:  "/api/specs/group.swagger.json",
+		Path:     "group",
+	}, mware)
 	mux.Handle("/api/", mware)
 	mux.Handle("/", http.FileServer(
 		&assetfs.AssetFS{Asset: bundle.Asset, AssetDir: bundle.AssetDir, Prefix: "bundle"}))
@@ -104,6 +143,24 @@ func specsHandler(w http.ResponseWriter, r *http.Request) {
 			logrus.Warn(err)
 		}
 		w.Write(vpnData)
+	case "/api/specs/token.swagger.json":
+		tokenData, err := bundle.Asset("bundle/token.swagger.json")
+		if err != nil {
+			logrus.Warn(err)
+		}
+		w.Write(tokenData)
+	case "/api/specs/role.swagger.json":
+		roleData, err := bundle.Asset("bundle/role.swagger.json")
+		if err != nil {
+			logrus.Warn(err)
+		}
+		w.Write(roleData)
+	case "/api/specs/group.swagger.json":
+		groupData, err := bundle.Asset("bundle/group.swagger.json")
+		if err != nil {
+			logrus.Warn(err)
+		}
+		w.Write(groupData)
 	}
 }
 
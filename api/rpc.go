@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"go.uber.org/thriftrw/ptr"
 	"os"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/master312/ovpm/permset"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
 )
 
 type AuthService struct{}
@@ -34,6 +36,9 @@ func (s *AuthService) Status(ctx context.Context, req *pb.AuthStatusRequest) (*p
 		return &pb.AuthStatusResponse{User: &userResp, IsRoot: true}, nil
 	}
 	user, err := ovpm.GetUser(username)
+	if err != nil {
+		user, err = ovpm.GetServiceAccount(username)
+	}
 	if err != nil {
 		logrus.Debugln(err)
 		return nil, grpc.Errorf(codes.Unauthenticated, "user not found with the provided credentials")
@@ -49,15 +54,16 @@ func (s *AuthService) Status(ctx context.Context, req *pb.AuthStatusRequest) (*p
 func (s *AuthService) Authenticate(ctx context.Context, req *pb.AuthAuthenticateRequest) (*pb.AuthAuthenticateResponse, error) {
 	logrus.Debug("rpc call: auth authenticate")
 
-	user, err := ovpm.GetUser(req.Username)
+	user, err := ovpm.AuthenticateWithTOTP(req.Username, req.Password, req.Totp, GetRemoteAddrFromContext(ctx))
 	if err != nil {
-		return nil, grpc.Errorf(codes.Unauthenticated, "user not found with the provided credentials")
-	}
-	if !user.CheckPassword(req.Password) {
+		logrus.Debugln(err)
+		if _, ok := err.(*ovpm.LockoutError); ok {
+			return nil, grpc.Errorf(codes.ResourceExhausted, "%v", err)
+		}
 		return nil, grpc.Errorf(codes.Unauthenticated, "user not found with the provided credentials")
 	}
 
-	token, err := user.RenewToken()
+	token, err := user.NewSessionToken()
 	if err != nil {
 		return nil, grpc.Errorf(codes.Internal, "token can not be generated")
 	}
@@ -65,6 +71,96 @@ func (s *AuthService) Authenticate(ctx context.Context, req *pb.AuthAuthenticate
 	return &pb.AuthAuthenticateResponse{Token: token}, nil
 }
 
+func (s *AuthService) Logout(ctx context.Context, req *pb.AuthLogoutRequest) (*pb.AuthLogoutResponse, error) {
+	logrus.Debug("rpc call: auth logout")
+
+	// Requests without a token (e.g. from the cli) have nothing to log out of.
+	if token := GetTokenFromContext(ctx); token != nil {
+		if err := token.Revoke(); err != nil {
+			return nil, grpc.Errorf(codes.Internal, "token can not be revoked")
+		}
+	}
+	return &pb.AuthLogoutResponse{}, nil
+}
+
+func (s *AuthService) ListLockouts(ctx context.Context, req *pb.AuthListLockoutsRequest) (*pb.AuthLockoutsResponse, error) {
+	logrus.Debug("rpc call: auth list lockouts")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageLockoutsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageLockoutsPerm is required for this operation")
+	}
+
+	lockouts, err := ovpm.GetLockouts()
+	if err != nil {
+		logrus.Errorf("lockouts can not be fetched: %v", err)
+		return nil, grpc.Errorf(codes.Internal, "lockouts can not be fetched")
+	}
+
+	var resp pb.AuthLockoutsResponse
+	for _, l := range lockouts {
+		resp.Lockouts = append(resp.Lockouts, &pb.AuthLockoutsResponse_Lockout{
+			Kind:          l.GetKind(),
+			Key:           l.GetKey(),
+			Failures:      int32(l.GetFailures()),
+			LastFailureAt: l.GetLastFailureAt().UTC().Format(time.RFC3339),
+			LockedUntil:   l.GetLockedUntil().UTC().Format(time.RFC3339),
+		})
+	}
+	return &resp, nil
+}
+
+func (s *AuthService) ClearLockout(ctx context.Context, req *pb.AuthClearLockoutRequest) (*pb.AuthClearLockoutResponse, error) {
+	logrus.Debug("rpc call: auth clear lockout")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageLockoutsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageLockoutsPerm is required for this operation")
+	}
+
+	username, _ := GetUsernameFromContext(ctx)
+	if req.All {
+		if err := ovpm.ClearAllLockouts(username); err != nil {
+			return nil, err
+		}
+		return &pb.AuthClearLockoutResponse{}, nil
+	}
+	if err := ovpm.ClearLockout(req.Kind, req.Key, username); err != nil {
+		return nil, err
+	}
+	return &pb.AuthClearLockoutResponse{}, nil
+}
+
+func (s *AuthService) RequestPasswordReset(ctx context.Context, req *pb.AuthRequestPasswordResetRequest) (*pb.AuthRequestPasswordResetResponse, error) {
+	logrus.Debug("rpc call: auth request password reset")
+	if !ovpm.IsSMTPEnabled() {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "email notifications are not enabled")
+	}
+
+	// Whether the user exists or has an email address isn't revealed to the caller.
+	if err := ovpm.RequestPasswordReset(req.Username, GetRemoteAddrFromContext(ctx)); err != nil {
+		logrus.Warnf("password reset can not be requested: %v", err)
+	}
+	return &pb.AuthRequestPasswordResetResponse{}, nil
+}
+
+func (s *AuthService) ResetPassword(ctx context.Context, req *pb.AuthResetPasswordRequest) (*pb.AuthResetPasswordResponse, error) {
+	logrus.Debug("rpc call: auth reset password")
+	if err := ovpm.ResetPasswordWithToken(req.Token, req.Password, GetRemoteAddrFromContext(ctx)); err != nil {
+		logrus.Debugln(err)
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &pb.AuthResetPasswordResponse{}, nil
+}
+
 type UserService struct{}
 
 func (s *UserService) List(ctx context.Context, req *pb.UserListRequest) (*pb.UserResponse, error) {
@@ -103,6 +199,21 @@ func (s *UserService) List(ctx context.Context, req *pb.UserListRequest) (*pb.Us
 			BytesReceived:      bytesReceived,
 			ExpiresAt:          user.ExpiresAt().UTC().Format(time.RFC3339),
 			Description:        user.GetDescription(),
+			Roles:              roleNames(user),
+			Dns:                user.GetPushOptions().DNS,
+			Routes:             user.GetPushOptions().Routes,
+			RedirectGateway:    user.GetPushOptions().RedirectGW,
+			Pushes:             user.GetPushOptions().Pushes,
+			Inactive:           user.GetPushOptions().Inactive,
+			IpPool:             user.GetIPPool(),
+			ValidFrom:          formatTime(user.GetValidFrom()),
+			ValidUntil:         formatTime(user.GetValidUntil()),
+			IsDisabled:         user.IsDisabled(),
+			IsSuspended:        user.IsSuspended(),
+			CertExpiring:       user.IsCertExpiring(time.Now()),
+			CertLifetime:       uint64(user.GetCertLifetime() / time.Second),
+			Email:              user.GetEmail(),
+			IsPending:          user.IsPending(),
 		})
 	}
 
@@ -122,10 +233,61 @@ func (s *UserService) Create(ctx context.Context, req *pb.UserCreateRequest) (*p
 	}
 
 	var ut []*pb.UserResponse_User
-	user, err := ovpm.CreateNewUser(req.Username, req.Password, req.NoGw, req.HostId, req.IsAdmin, req.Description)
+	validFrom, err := parseTime(req.ValidFrom)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	validUntil, err := parseTime(req.ValidUntil)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := ovpm.ValidateValidity(validFrom, validUntil); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := ovpm.ValidateEmail(req.Email); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if req.Invite && req.Password != "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invited users set their own passwords")
+	}
+	if req.Invite && ovpm.GetWebURL() == "" {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "web url is not configured, users can't be invited without it")
+	}
+	var user *ovpm.User
+	if req.Invite {
+		user, err = ovpm.CreatePendingUser(req.Username, req.NoGw, req.HostId, req.IsAdmin, req.Description)
+	} else {
+		user, err = ovpm.CreateNewUser(req.Username, req.Password, req.NoGw, req.HostId, req.IsAdmin, req.Description)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if validFrom != nil || validUntil != nil {
+		if err := user.SetValidity(validFrom, validUntil); err != nil {
+			return nil, err
+		}
+	}
+	if req.Email != "" {
+		if err := user.SetEmail(req.Email); err != nil {
+			return nil, err
+		}
+		if ovpm.IsSMTPEnabled() && !req.Invite {
+			if err := user.NotifyWelcome(); err != nil {
+				logrus.Errorf("welcome message can not be sent: %v", err)
+			}
+		}
+	}
+	var invitationURL string
+	if req.Invite {
+		issuer, _ := GetUsernameFromContext(ctx)
+		// The invitation is emailed to the address of the user, if it's set above.
+		if _, invitationURL, err = user.Invite(issuer, time.Duration(req.InvitationTtl)*time.Second); err != nil {
+			if err := user.Delete(); err != nil {
+				logrus.Errorf("pending user can not be deleted: %v", err)
+			}
+			return nil, err
+		}
+	}
 
 	pbUser := pb.UserResponse_User{
 		Username:           user.GetUsername(),
@@ -134,6 +296,10 @@ func (s *UserService) Create(ctx context.Context, req *pb.UserCreateRequest) (*p
 		HostId:             user.GetHostID(),
 		IsAdmin:            user.IsAdmin(),
 		Description:        user.GetDescription(),
+		ValidFrom:          formatTime(user.GetValidFrom()),
+		ValidUntil:         formatTime(user.GetValidUntil()),
+		IsPending:          user.IsPending(),
+		InvitationUrl:      invitationURL,
 	}
 	ut = append(ut, &pbUser)
 
@@ -181,8 +347,48 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 		return nil, grpc.Errorf(codes.Unauthenticated, "username not found with the provided credentials")
 	}
 
+	pushOpts, pushChanged := userPushOptions(user, req)
+	ipPoolChanged := req.IpPool != "" || req.ResetIpPool
+	validFrom, validUntil, validityChanged, err := userValidity(user, req)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	certLifetimeChanged := req.CertLifetime != 0 || req.ResetCertLifetime
+	emailChanged := req.Email != "" || req.ResetEmail
+
 	// User has admin perms?
 	if perms.Contains(ovpm.UpdateAnyUserPerm) {
+		// Push options are validated before anything is changed.
+		if pushChanged {
+			if err := user.SetPushOptions(pushOpts); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if ipPoolChanged {
+			if err := user.SetIPPool(req.IpPool); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if validityChanged {
+			if err := user.SetValidity(validFrom, validUntil); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if certLifetimeChanged {
+			if err := user.SetCertLifetime(time.Duration(req.CertLifetime) * time.Second); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if emailChanged {
+			if err := user.SetEmail(req.Email); err != nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		}
+		if req.ResetTotp {
+			if err := user.ResetTOTP(); err != nil {
+				return nil, err
+			}
+		}
 		err = user.Update(req.Password, noGW, req.HostId, admin, req.Description)
 		if err != nil {
 			return nil, err
@@ -194,6 +400,16 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 			HostId:             user.GetHostID(),
 			IsAdmin:            user.IsAdmin(),
 			Description:        user.GetDescription(),
+			Dns:                user.GetPushOptions().DNS,
+			Routes:             user.GetPushOptions().Routes,
+			RedirectGateway:    user.GetPushOptions().RedirectGW,
+			Pushes:             user.GetPushOptions().Pushes,
+			Inactive:           user.GetPushOptions().Inactive,
+			IpPool:             user.GetIPPool(),
+			ValidFrom:          formatTime(user.GetValidFrom()),
+			ValidUntil:         formatTime(user.GetValidUntil()),
+			CertLifetime:       uint64(user.GetCertLifetime() / time.Second),
+			Email:              user.GetEmail(),
 		})
 		return &pb.UserResponse{Users: ut}, nil
 	}
@@ -203,6 +419,24 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 		if user.GetUsername() != username {
 			return nil, grpc.Errorf(codes.PermissionDenied, "Caller can only update their user with ovpm.UpdateSelfPerm")
 		}
+		if pushChanged {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to update the push options")
+		}
+		if ipPoolChanged {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to update the ip pool")
+		}
+		if validityChanged {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to update the validity")
+		}
+		if certLifetimeChanged {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to update the cert lifetime")
+		}
+		if emailChanged {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to update the email address")
+		}
+		if req.ResetTotp {
+			return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required to reset the totp enrolment")
+		}
 
 		err = user.Update(req.Password, noGW, req.HostId, admin, req.Description)
 		if err != nil {
@@ -221,6 +455,68 @@ func (s *UserService) Update(ctx context.Context, req *pb.UserUpdateRequest) (*p
 	return nil, grpc.Errorf(codes.PermissionDenied, "Permissions are required for this operation.")
 }
 
+// userPushOptions returns the push options of the user with the changes requested by req, and
+// whether there are any.
+func userPushOptions(user *ovpm.User, req *pb.UserUpdateRequest) (ovpm.UserPushOptions, bool) {
+	opts := user.GetPushOptions()
+	changed := false
+	if len(req.Dns) > 0 || req.ResetDns {
+		opts.DNS, changed = req.Dns, true
+	}
+	if len(req.Routes) > 0 || req.ResetRoutes {
+		opts.Routes, changed = req.Routes, true
+	}
+	if req.RedirectGateway != "" || req.ResetRedirectGateway {
+		opts.RedirectGW, changed = req.RedirectGateway, true
+	}
+	if len(req.Pushes) > 0 || req.ResetPushes {
+		opts.Pushes, changed = req.Pushes, true
+	}
+	if req.Inactive != 0 || req.ResetInactive {
+		opts.Inactive, changed = req.Inactive, true
+	}
+	return opts, changed
+}
+
+// userValidity returns the validity window of the user with the changes requested by req, and
+// whether there are any.
+func userValidity(user *ovpm.User, req *pb.UserUpdateRequest) (from, until *time.Time, changed bool, err error) {
+	from, until = user.ValidFrom, user.ValidUntil
+	if req.ValidFrom != "" || req.ResetValidFrom {
+		if from, err = parseTime(req.ValidFrom); err != nil {
+			return nil, nil, false, err
+		}
+		changed = true
+	}
+	if req.ValidUntil != "" || req.ResetValidUntil {
+		if until, err = parseTime(req.ValidUntil); err != nil {
+			return nil, nil, false, err
+		}
+		changed = true
+	}
+	return from, until, changed, nil
+}
+
+// parseTime parses the RFC3339 time. Empty string is parsed as nil.
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: `%s` must be an RFC3339 time", s)
+	}
+	return &t, nil
+}
+
+// formatTime formats the time in RFC3339. Zero time is formatted as empty string.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func (s *UserService) Delete(ctx context.Context, req *pb.UserDeleteRequest) (*pb.UserResponse, error) {
 	logrus.Debugf("rpc call: user delete: %s", req.Username)
 	var ut []*pb.UserResponse_User
@@ -326,6 +622,146 @@ func (s *UserService) GenConfig(ctx context.Context, req *pb.UserGenConfigReques
 	return nil, grpc.Errorf(codes.PermissionDenied, "Permissions are required for this operation.")
 }
 
+func (s *UserService) Disable(ctx context.Context, req *pb.UserDisableRequest) (*pb.UserResponse, error) {
+	logrus.Debugf("rpc call: user disable: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required for this operation.")
+	}
+
+	user, err := ovpm.GetUser(req.Username)
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	if err := user.Disable(); err != nil {
+		return nil, err
+	}
+	return &pb.UserResponse{Users: []*pb.UserResponse_User{userStateResponse(user)}}, nil
+}
+
+func (s *UserService) Enable(ctx context.Context, req *pb.UserEnableRequest) (*pb.UserResponse, error) {
+	logrus.Debugf("rpc call: user enable: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateAnyUserPerm is required for this operation.")
+	}
+
+	user, err := ovpm.GetUser(req.Username)
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	if err := user.Enable(); err != nil {
+		return nil, err
+	}
+	return &pb.UserResponse{Users: []*pb.UserResponse_User{userStateResponse(user)}}, nil
+}
+
+func (s *UserService) CreateProfileLink(ctx context.Context, req *pb.UserProfileLinkRequest) (*pb.UserProfileLinkResponse, error) {
+	logrus.Debugf("rpc call: user create profile link: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GenConfigAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GenConfigAnyUserPerm is required for this operation.")
+	}
+
+	issuer, err := GetUsernameFromContext(ctx)
+	if err != nil {
+		logrus.Debugln(err)
+		return nil, grpc.Errorf(codes.Unauthenticated, "username not found with the provided credentials")
+	}
+	link, url, passcode, err := ovpm.CreateProfileLink(req.Username, issuer, time.Duration(req.Ttl)*time.Second, req.Passcode)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UserProfileLinkResponse{
+		Url:       url,
+		Passcode:  passcode,
+		ExpiresAt: link.GetExpiresAt().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *UserService) ListInvitations(ctx context.Context, req *pb.UserListInvitationsRequest) (*pb.UserInvitationsResponse, error) {
+	logrus.Debug("rpc call: user list invitations")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.GetAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetAnyUserPerm is required for this operation.")
+	}
+
+	invitations, err := ovpm.GetInvitations()
+	if err != nil {
+		return nil, err
+	}
+	return invitationsResponse(invitations), nil
+}
+
+func (s *UserService) RevokeInvitation(ctx context.Context, req *pb.UserRevokeInvitationRequest) (*pb.UserInvitationsResponse, error) {
+	logrus.Debugf("rpc call: user revoke invitation: %s", req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.DeleteAnyUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.DeleteAnyUserPerm is required for this operation.")
+	}
+
+	revoker, err := GetUsernameFromContext(ctx)
+	if err != nil {
+		logrus.Debugln(err)
+		return nil, grpc.Errorf(codes.Unauthenticated, "username not found with the provided credentials")
+	}
+	if err := ovpm.RevokeInvitation(req.Username, revoker); err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	invitations, err := ovpm.GetInvitations()
+	if err != nil {
+		return nil, err
+	}
+	return invitationsResponse(invitations), nil
+}
+
+// invitationsResponse returns the api representation of the invitations.
+func invitationsResponse(invitations []*ovpm.Invitation) *pb.UserInvitationsResponse {
+	var resp pb.UserInvitationsResponse
+	for _, i := range invitations {
+		resp.Invitations = append(resp.Invitations, &pb.UserInvitationsResponse_Invitation{
+			Username:  i.GetUsername(),
+			IssuedBy:  i.GetIssuedBy(),
+			CreatedAt: i.GetCreatedAt().UTC().Format(time.RFC3339),
+			ExpiresAt: i.GetExpiresAt().UTC().Format(time.RFC3339),
+			IsExpired: i.IsExpired(),
+		})
+	}
+	return &resp
+}
+
+// userStateResponse returns the api representation of the user along with whether it's disabled.
+func userStateResponse(user *ovpm.User) *pb.UserResponse_User {
+	return &pb.UserResponse_User{
+		Username:           user.GetUsername(),
+		ServerSerialNumber: user.GetServerSerialNumber(),
+		HostId:             user.GetHostID(),
+		IsAdmin:            user.IsAdmin(),
+		IsDisabled:         user.IsDisabled(),
+		IsSuspended:        user.IsSuspended(),
+	}
+}
+
 type VPNService struct{}
 
 func (s *VPNService) Status(ctx context.Context, req *pb.VPNStatusRequest) (*pb.VPNStatusResponse, error) {
@@ -341,21 +777,33 @@ func (s *VPNService) Status(ctx context.Context, req *pb.VPNStatusRequest) (*pb.
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
 	}
 
+	nat := ovpm.GetNATStatus()
+	dhcp := server.GetDHCPOptions()
 	response := pb.VPNStatusResponse{
-		Name:         server.GetServerName(),
-		SerialNumber: server.GetSerialNumber(),
-		Hostname:     server.GetHostname(),
-		Port:         server.GetPort(),
-		Proto:        server.GetProto(),
-		Cert:         server.Cert,
-		CaCert:       server.GetCACert(),
-		Net:          server.GetNet(),
-		Mask:         server.GetMask(),
-		CreatedAt:    server.GetCreatedAt(),
-		Dns:          server.GetDNS(),
-		ExpiresAt:    server.ExpiresAt().UTC().Format(time.RFC3339),
-		CaExpiresAt:  server.CAExpiresAt().UTC().Format(time.RFC3339),
-		UseLzo:       server.IsUseLZO(),
+		Name:          server.GetServerName(),
+		SerialNumber:  server.GetSerialNumber(),
+		Hostname:      server.GetHostname(),
+		Port:          server.GetPort(),
+		Proto:         server.GetProto(),
+		Cert:          server.Cert,
+		CaCert:        server.GetCACert(),
+		Net:           server.GetNet(),
+		Mask:          server.GetMask(),
+		CreatedAt:     server.GetCreatedAt(),
+		Dns:           server.GetDNS(),
+		ExpiresAt:     server.ExpiresAt().UTC().Format(time.RFC3339),
+		CaExpiresAt:   server.CAExpiresAt().UTC().Format(time.RFC3339),
+		UseLzo:        server.IsUseLZO(),
+		NatEnabled:    nat.Enabled,
+		NatError:      nat.LastError,
+		NatAttempts:   int32(nat.Attempts),
+		NatMode:       server.GetNATMode(),
+		SnatTo:        server.GetSNATTo(),
+		EgressIface:   server.GetEgressIface(),
+		Domain:        dhcp.Domain,
+		SearchDomains: dhcp.SearchDomains,
+		Ntp:           dhcp.NTP,
+		Wins:          dhcp.WINS,
 	}
 	return &response, nil
 }
@@ -406,7 +854,56 @@ func (s *VPNService) Update(ctx context.Context, req *pb.VPNUpdateRequest) (*pb.
 		useLzo = ptr.Bool(false)
 	}
 	if err := ovpm.TheServer().Update(req.IpBlock, req.Dns, useLzo); err != nil {
-		logrus.Errorf("server can not be updated: %v", err)
+		if _, ok := err.(*ovpm.CIDRConflict); ok {
+			return nil, grpc.Errorf(codes.FailedPrecondition, "server can not be updated: %v", err)
+		}
+		return nil, grpc.Errorf(codes.InvalidArgument, "server can not be updated: %v", err)
+	}
+
+	var egressIface *string
+	switch req.EgressIface {
+	case "":
+	case "auto":
+		egressIface = ptr.String("")
+	default:
+		egressIface = ptr.String(req.EgressIface)
+	}
+	if req.NatMode != "" || req.SnatTo != "" || egressIface != nil {
+		if err := ovpm.TheServer().UpdateNAT(req.NatMode, req.SnatTo, egressIface); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "server nat can not be updated: %v", err)
+		}
+	}
+	dhcp := ovpm.TheServer().GetDHCPOptions()
+	var dhcpChanged bool
+	if req.Domain != "" || req.ResetDomain {
+		dhcp.Domain, dhcpChanged = req.Domain, true
+	}
+	if len(req.SearchDomains) > 0 || req.ResetSearchDomains {
+		dhcp.SearchDomains, dhcpChanged = req.SearchDomains, true
+	}
+	if len(req.Ntp) > 0 || req.ResetNtp {
+		dhcp.NTP, dhcpChanged = req.Ntp, true
+	}
+	if len(req.Wins) > 0 || req.ResetWins {
+		dhcp.WINS, dhcpChanged = req.Wins, true
+	}
+	if dhcpChanged {
+		if err := ovpm.TheServer().SetDHCPOptions(dhcp); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "server dhcp options can not be updated: %v", err)
+		}
+	}
+	for _, nn := range req.NetworkNats {
+		network, err := ovpm.GetNetwork(nn.NetworkName)
+		if err != nil {
+			return nil, grpc.Errorf(codes.NotFound, "network not found: %s", nn.NetworkName)
+		}
+		mode := nn.NatMode
+		if mode == "inherit" {
+			mode = ""
+		}
+		if err := network.SetNAT(mode, nn.SnatTo); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "nat of the network %s can not be set: %v", nn.NetworkName, err)
+		}
 	}
 	return &pb.VPNUpdateResponse{}, nil
 }
@@ -426,74 +923,220 @@ func (s *VPNService) Restart(ctx context.Context, req *pb.VPNRestartRequest) (*p
 	return &pb.VPNRestartResponse{}, nil
 }
 
-type NetworkService struct{}
-
-func (s *NetworkService) List(ctx context.Context, req *pb.NetworkListRequest) (*pb.NetworkListResponse, error) {
-	logrus.Debug("rpc call: network list")
-	var nt []*pb.Network
-
+func (s *VPNService) FirewallShow(ctx context.Context, req *pb.VPNFirewallShowRequest) (*pb.VPNFirewallShowResponse, error) {
+	logrus.Debugf("rpc call: vpn firewall show")
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
 	}
 
-	if !perms.Contains(ovpm.ListNetworksPerm) {
-		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListNetworksPerm is required for this operation.")
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
 	}
 
-	networks := ovpm.GetAllNetworks()
-	for _, network := range networks {
-		nt = append(nt, &pb.Network{
-			Name:                network.GetName(),
-			Cidr:                network.GetCIDR(),
-			Type:                network.GetType().String(),
-			CreatedAt:           network.GetCreatedAt(),
-			AssociatedUsernames: network.GetAssociatedUsernames(),
-			Via:                 network.GetVia(),
-		})
+	backend, dump, err := ovpm.TheServer().DumpFirewall()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "can not dump the firewall rules: %v", err)
 	}
+	return &pb.VPNFirewallShowResponse{Backend: backend, Dump: dump}, nil
+}
 
-	return &pb.NetworkListResponse{Networks: nt}, nil
+// ipPools returns the api representation of the ip pools and their usage.
+func ipPools() ([]*pb.VPNIPPool, error) {
+	pools, err := ovpm.GetAllIPPools()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "can not get the ip pools: %v", err)
+	}
+	var pbPools []*pb.VPNIPPool
+	for _, pool := range pools {
+		groups, users := pool.GetAssignees()
+		pbPools = append(pbPools, &pb.VPNIPPool{
+			Name:   pool.GetName(),
+			Start:  pool.GetStart(),
+			End:    pool.GetEnd(),
+			Size:   int32(pool.Size()),
+			Used:   int32(pool.Used()),
+			Subnet: pool.GetSubnet(),
+			Groups: groups,
+			Users:  users,
+		})
+	}
+	return pbPools, nil
 }
 
-func (s *NetworkService) Create(ctx context.Context, req *pb.NetworkCreateRequest) (*pb.NetworkCreateResponse, error) {
-	logrus.Debugf("rpc call: network create: %s", req.Name)
+func (s *VPNService) IPPoolList(ctx context.Context, req *pb.VPNIPPoolListRequest) (*pb.VPNIPPoolListResponse, error) {
+	logrus.Debugf("rpc call: vpn ip pool list")
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
 	}
 
-	if !perms.Contains(ovpm.CreateNetworkPerm) {
-		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.CreateNetworkPerm is required for this operation.")
+	if !perms.Contains(ovpm.GetVPNStatusPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.GetVPNStatusPerm is required for this operation.")
 	}
 
-	network, err := ovpm.CreateNewNetwork(req.Name, req.Cidr, ovpm.NetworkTypeFromString(req.Type), req.Via)
+	pools, err := ipPools()
 	if err != nil {
 		return nil, err
 	}
-
-	n := pb.Network{
-		Name:                network.GetName(),
-		Cidr:                network.GetCIDR(),
-		Type:                network.GetType().String(),
-		CreatedAt:           network.GetCreatedAt(),
-		AssociatedUsernames: network.GetAssociatedUsernames(),
-		Via:                 network.GetVia(),
-	}
-
-	return &pb.NetworkCreateResponse{Network: &n}, nil
+	return &pb.VPNIPPoolListResponse{Pools: pools}, nil
 }
 
-func (s *NetworkService) Delete(ctx context.Context, req *pb.NetworkDeleteRequest) (*pb.NetworkDeleteResponse, error) {
-	logrus.Debugf("rpc call: network delete: %s", req.Name)
+func (s *VPNService) IPPoolCreate(ctx context.Context, req *pb.VPNIPPoolCreateRequest) (*pb.VPNIPPoolListResponse, error) {
+	logrus.Debugf("rpc call: vpn ip pool create: %s", req.Name)
 	perms, err := permset.FromContext(ctx)
 	if err != nil {
 		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
 	}
 
-	if !perms.Contains(ovpm.DeleteNetworkPerm) {
-		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.DeleteNetworkPerm is required for this operation.")
-	}
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	if req.Subnet != "" {
+		if req.Start != "" || req.End != "" {
+			return nil, grpc.Errorf(codes.InvalidArgument, "ip pool can either have a subnet or a range")
+		}
+		if _, err := ovpm.CreateSubnetIPPool(req.Name, req.Subnet); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	} else if _, err := ovpm.CreateIPPool(req.Name, req.Start, req.End); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	pools, err := ipPools()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VPNIPPoolListResponse{Pools: pools}, nil
+}
+
+func (s *VPNService) IPPoolDelete(ctx context.Context, req *pb.VPNIPPoolDeleteRequest) (*pb.VPNIPPoolListResponse, error) {
+	logrus.Debugf("rpc call: vpn ip pool delete: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateVPNPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateVPNPerm is required for this operation.")
+	}
+
+	pool, err := ovpm.GetIPPool(req.Name)
+	if err != nil {
+		return nil, grpc.Errorf(codes.NotFound, "%v", err)
+	}
+	if err := pool.Delete(); err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	pools, err := ipPools()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VPNIPPoolListResponse{Pools: pools}, nil
+}
+
+type NetworkService struct{}
+
+// networkACLs returns the api representation of the access rules of the network's associations.
+func networkACLs(network *ovpm.Network) []*pb.NetworkACL {
+	var acls []*pb.NetworkACL
+	for _, acl := range network.GetACLs() {
+		acls = append(acls, &pb.NetworkACL{
+			Username:  acl.GetUsername(),
+			GroupName: acl.GetGroupName(),
+			Rules:     acl.GetRules(),
+		})
+	}
+	return acls
+}
+
+func (s *NetworkService) List(ctx context.Context, req *pb.NetworkListRequest) (*pb.NetworkListResponse, error) {
+	logrus.Debug("rpc call: network list")
+	var nt []*pb.Network
+
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.ListNetworksPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListNetworksPerm is required for this operation.")
+	}
+
+	networks := ovpm.GetAllNetworks()
+	for _, network := range networks {
+		nt = append(nt, &pb.Network{
+			Name:                network.GetName(),
+			Cidr:                network.GetCIDR(),
+			Type:                network.GetType().String(),
+			CreatedAt:           network.GetCreatedAt(),
+			AssociatedUsernames: network.GetAssociatedUsernames(),
+			Via:                 network.GetVia(),
+			AssociatedGroups:    network.GetAssociatedGroupNames(),
+			Acls:                networkACLs(network),
+			NatMode:             network.GetNATMode(),
+			SnatTo:              network.GetSNATTo(),
+			Owner:               network.GetOwnerUsername(),
+			MappedCidr:          network.GetMappedCIDR(),
+			DnsDomain:           network.GetDNSDomain(),
+			DnsServers:          network.GetDNSServers(),
+		})
+	}
+
+	return &pb.NetworkListResponse{Networks: nt}, nil
+}
+
+func (s *NetworkService) Create(ctx context.Context, req *pb.NetworkCreateRequest) (*pb.NetworkCreateResponse, error) {
+	logrus.Debugf("rpc call: network create: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.CreateNetworkPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.CreateNetworkPerm is required for this operation.")
+	}
+
+	var network *ovpm.Network
+	if nettype := ovpm.NetworkTypeFromString(req.Type); nettype == ovpm.CLIENTNET {
+		network, err = ovpm.CreateNewClientNetwork(req.Name, req.Cidr, req.Owner)
+	} else {
+		network, err = ovpm.CreateNewNetwork(req.Name, req.Cidr, nettype, req.Via)
+	}
+	if err != nil {
+		return nil, cidrError(err)
+	}
+
+	n := pb.Network{
+		Name:                network.GetName(),
+		Cidr:                network.GetCIDR(),
+		Type:                network.GetType().String(),
+		CreatedAt:           network.GetCreatedAt(),
+		AssociatedUsernames: network.GetAssociatedUsernames(),
+		Via:                 network.GetVia(),
+		AssociatedGroups:    network.GetAssociatedGroupNames(),
+		Acls:                networkACLs(network),
+		NatMode:             network.GetNATMode(),
+		SnatTo:              network.GetSNATTo(),
+		Owner:               network.GetOwnerUsername(),
+		MappedCidr:          network.GetMappedCIDR(),
+		DnsDomain:           network.GetDNSDomain(),
+		DnsServers:          network.GetDNSServers(),
+	}
+
+	return &pb.NetworkCreateResponse{Network: &n}, nil
+}
+
+func (s *NetworkService) Delete(ctx context.Context, req *pb.NetworkDeleteRequest) (*pb.NetworkDeleteResponse, error) {
+	logrus.Debugf("rpc call: network delete: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.DeleteNetworkPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.DeleteNetworkPerm is required for this operation.")
+	}
 
 	network, err := ovpm.GetNetwork(req.Name)
 	if err != nil {
@@ -512,6 +1155,14 @@ func (s *NetworkService) Delete(ctx context.Context, req *pb.NetworkDeleteReques
 		CreatedAt:           network.GetCreatedAt(),
 		AssociatedUsernames: network.GetAssociatedUsernames(),
 		Via:                 network.GetVia(),
+		AssociatedGroups:    network.GetAssociatedGroupNames(),
+		Acls:                networkACLs(network),
+		NatMode:             network.GetNATMode(),
+		SnatTo:              network.GetSNATTo(),
+		Owner:               network.GetOwnerUsername(),
+		MappedCidr:          network.GetMappedCIDR(),
+		DnsDomain:           network.GetDNSDomain(),
+		DnsServers:          network.GetDNSServers(),
 	}
 
 	return &pb.NetworkDeleteResponse{Network: &n}, nil
@@ -570,6 +1221,10 @@ func (s *NetworkService) Associate(ctx context.Context, req *pb.NetworkAssociate
 		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.AssociateNetworkUserPerm is required for this operation.")
 	}
 
+	if _, err := ovpm.ParseACLRules(req.Rules); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	network, err := ovpm.GetNetwork(req.Name)
 	if err != nil {
 		return nil, err
@@ -578,6 +1233,11 @@ func (s *NetworkService) Associate(ctx context.Context, req *pb.NetworkAssociate
 	if err != nil {
 		return nil, err
 	}
+	if len(req.Rules) > 0 {
+		if err := network.SetRules(req.Username, req.Rules); err != nil {
+			return nil, err
+		}
+	}
 
 	return &pb.NetworkAssociateResponse{}, nil
 }
@@ -606,15 +1266,856 @@ func (s *NetworkService) Dissociate(ctx context.Context, req *pb.NetworkDissocia
 	return &pb.NetworkDissociateResponse{}, nil
 }
 
-// NewRPCServer returns a new gRPC server.
-func NewRPCServer() *grpc.Server {
-	var opts []grpc.ServerOption
-	opts = append(opts, grpc.UnaryInterceptor(AuthUnaryInterceptor))
+func (s *NetworkService) SetRules(ctx context.Context, req *pb.NetworkSetRulesRequest) (*pb.NetworkSetRulesResponse, error) {
+	logrus.Debugf("rpc call: network set-rules")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.AssociateNetworkUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.AssociateNetworkUserPerm is required for this operation.")
+	}
+	if (req.Username == "") == (req.GroupName == "") {
+		return nil, grpc.Errorf(codes.InvalidArgument, "either username or group name is required")
+	}
+
+	network, err := ovpm.GetNetwork(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Username != "" {
+		err = network.SetRules(req.Username, req.Rules)
+	} else {
+		err = network.SetGroupRules(req.GroupName, req.Rules)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.NetworkSetRulesResponse{}, nil
+}
+
+func (s *NetworkService) Update(ctx context.Context, req *pb.NetworkUpdateRequest) (*pb.NetworkUpdateResponse, error) {
+	logrus.Debugf("rpc call: network update: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateNetworkPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateNetworkPerm is required for this operation.")
+	}
+
+	network, err := ovpm.GetNetwork(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var via *string
+	switch req.Via {
+	case "":
+	case "vpn-server":
+		via = ptr.String("")
+	default:
+		via = ptr.String(req.Via)
+	}
+	if err := network.Update(req.NewName, req.Cidr, via); err != nil {
+		return nil, cidrError(err)
+	}
+
+	n := pb.Network{
+		Name:                network.GetName(),
+		Cidr:                network.GetCIDR(),
+		Type:                network.GetType().String(),
+		CreatedAt:           network.GetCreatedAt(),
+		AssociatedUsernames: network.GetAssociatedUsernames(),
+		Via:                 network.GetVia(),
+		AssociatedGroups:    network.GetAssociatedGroupNames(),
+		Acls:                networkACLs(network),
+		NatMode:             network.GetNATMode(),
+		SnatTo:              network.GetSNATTo(),
+		Owner:               network.GetOwnerUsername(),
+		MappedCidr:          network.GetMappedCIDR(),
+		DnsDomain:           network.GetDNSDomain(),
+		DnsServers:          network.GetDNSServers(),
+	}
+
+	return &pb.NetworkUpdateResponse{Network: &n}, nil
+}
+
+func (s *NetworkService) SetDNS(ctx context.Context, req *pb.NetworkSetDNSRequest) (*pb.NetworkSetDNSResponse, error) {
+	logrus.Debugf("rpc call: network set-dns: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateNetworkPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateNetworkPerm is required for this operation.")
+	}
+
+	network, err := ovpm.GetNetwork(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := network.SetDNS(req.Domain, req.Servers); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	n := pb.Network{
+		Name:                network.GetName(),
+		Cidr:                network.GetCIDR(),
+		Type:                network.GetType().String(),
+		CreatedAt:           network.GetCreatedAt(),
+		AssociatedUsernames: network.GetAssociatedUsernames(),
+		Via:                 network.GetVia(),
+		AssociatedGroups:    network.GetAssociatedGroupNames(),
+		Acls:                networkACLs(network),
+		NatMode:             network.GetNATMode(),
+		SnatTo:              network.GetSNATTo(),
+		Owner:               network.GetOwnerUsername(),
+		MappedCidr:          network.GetMappedCIDR(),
+		DnsDomain:           network.GetDNSDomain(),
+		DnsServers:          network.GetDNSServers(),
+	}
+
+	return &pb.NetworkSetDNSResponse{Network: &n}, nil
+}
+
+func (s *NetworkService) Check(ctx context.Context, req *pb.NetworkCheckRequest) (*pb.NetworkCheckResponse, error) {
+	logrus.Debugf("rpc call: network check")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.ListNetworksPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListNetworksPerm is required for this operation.")
+	}
+
+	var conflicts []*pb.NetworkCIDRConflict
+	for _, conflict := range ovpm.CheckCIDRs() {
+		conflicts = append(conflicts, &pb.NetworkCIDRConflict{
+			Block:    cidrBlock(conflict.Block),
+			Conflict: cidrBlock(conflict.Conflict),
+			Message:  conflict.Error(),
+		})
+	}
+
+	return &pb.NetworkCheckResponse{Conflicts: conflicts}, nil
+}
+
+// cidrBlock converts the address block to its protobuf message.
+func cidrBlock(b ovpm.CIDRBlock) *pb.NetworkCIDRBlock {
+	return &pb.NetworkCIDRBlock{Kind: string(b.Kind), Name: b.Name, Cidr: b.CIDR}
+}
+
+// cidrError returns the conflicts of the address blocks as failed preconditions.
+func cidrError(err error) error {
+	if _, ok := err.(*ovpm.CIDRConflict); ok {
+		return grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return err
+}
+
+func (s *NetworkService) Map(ctx context.Context, req *pb.NetworkMapRequest) (*pb.NetworkMapResponse, error) {
+	logrus.Debugf("rpc call: network map: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Can't get permset from context")
+	}
+
+	if !perms.Contains(ovpm.UpdateNetworkPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.UpdateNetworkPerm is required for this operation.")
+	}
+
+	network, err := ovpm.GetNetwork(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := network.SetMapping(req.MappedCidr); err != nil {
+		return nil, cidrError(err)
+	}
+
+	n := pb.Network{
+		Name:                network.GetName(),
+		Cidr:                network.GetCIDR(),
+		Type:                network.GetType().String(),
+		CreatedAt:           network.GetCreatedAt(),
+		AssociatedUsernames: network.GetAssociatedUsernames(),
+		Via:                 network.GetVia(),
+		AssociatedGroups:    network.GetAssociatedGroupNames(),
+		Acls:                networkACLs(network),
+		NatMode:             network.GetNATMode(),
+		SnatTo:              network.GetSNATTo(),
+		Owner:               network.GetOwnerUsername(),
+		MappedCidr:          network.GetMappedCIDR(),
+		DnsDomain:           network.GetDNSDomain(),
+		DnsServers:          network.GetDNSServers(),
+	}
+
+	return &pb.NetworkMapResponse{Network: &n}, nil
+}
+
+type TokenService struct{}
+
+// tokenOwner returns the owner of the tokens that the request is about, checking whether the caller
+// is allowed to manage them.
+func tokenOwner(ctx context.Context, username string) (string, error) {
+	caller, err := GetUsernameFromContext(ctx)
+	if err != nil {
+		logrus.Debugln(err)
+		return "", grpc.Errorf(codes.Unauthenticated, "username not found with the provided credentials")
+	}
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return "", grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	if username == "" {
+		username = caller
+	}
+	if perms.Contains(ovpm.ManageAnyTokensPerm) {
+		return username, nil
+	}
+	if perms.Contains(ovpm.ManageSelfTokensPerm) {
+		if username != caller {
+			return "", grpc.Errorf(codes.PermissionDenied, "Caller can only manage their own tokens.")
+		}
+		return username, nil
+	}
+	return "", grpc.Errorf(codes.PermissionDenied, "ovpm.ManageSelfTokensPerm is required for this operation.")
+}
+
+func tokenResponse(username string, t *ovpm.Token) *pb.TokenResponse_Token {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return &pb.TokenResponse_Token{
+		Name:       t.GetName(),
+		Username:   username,
+		Scopes:     ovpm.PermNames(t.GetScopes()),
+		CreatedAt:  formatTime(t.GetCreatedAt()),
+		ExpiresAt:  formatTime(t.GetExpiresAt()),
+		LastUsedAt: formatTime(t.GetLastUsedAt()),
+		IsSession:  t.IsSession(),
+		IsExpired:  t.IsExpired(),
+	}
+}
+
+func (s *TokenService) Create(ctx context.Context, req *pb.TokenCreateRequest) (*pb.TokenResponse, error) {
+	logrus.Debugf("rpc call: token create: %s of %s", req.Name, req.Username)
+	username, err := tokenOwner(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+	if req.Ttl != "" {
+		if ttl, err = time.ParseDuration(req.Ttl); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "ttl is invalid: %v", err)
+		}
+	}
+	scopes, err := ovpm.PermsFromNames(req.Scopes)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// Tokens can't be given more permissions than the caller has. Tokens of the other users are
+	// always scoped, since their owners might have permissions that the caller doesn't.
+	perms, _ := permset.FromContext(ctx)
+	caller, _ := GetUsernameFromContext(ctx)
+	if len(scopes) == 0 && (username != caller || GetTokenFromContext(ctx) != nil && GetTokenFromContext(ctx).IsScoped()) {
+		scopes = perms.Perms()
+	}
+	if err := checkGrantable(perms, scopes); err != nil {
+		return nil, err
+	}
+
+	token, plaintext, err := ovpm.CreateToken(username, req.Name, ttl, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TokenResponse{Tokens: []*pb.TokenResponse_Token{tokenResponse(username, token)}, Token: plaintext}, nil
+}
+
+func (s *TokenService) List(ctx context.Context, req *pb.TokenListRequest) (*pb.TokenResponse, error) {
+	logrus.Debugf("rpc call: token list: %s", req.Username)
+	username, err := tokenOwner(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := ovpm.GetTokens(username)
+	if err != nil {
+		return nil, err
+	}
+	var tt []*pb.TokenResponse_Token
+	for _, token := range tokens {
+		tt = append(tt, tokenResponse(username, token))
+	}
+	return &pb.TokenResponse{Tokens: tt}, nil
+}
+
+func (s *TokenService) Revoke(ctx context.Context, req *pb.TokenRevokeRequest) (*pb.TokenResponse, error) {
+	logrus.Debugf("rpc call: token revoke: %s of %s", req.Name, req.Username)
+	username, err := tokenOwner(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ovpm.RevokeToken(username, req.Name); err != nil {
+		return nil, err
+	}
+	return &pb.TokenResponse{}, nil
+}
+
+type ServiceAccountService struct{}
+
+func serviceAccountResponse(sa *ovpm.User) *pb.ServiceAccountResponse_ServiceAccount {
+	return &pb.ServiceAccountResponse_ServiceAccount{
+		Name:        sa.GetUsername(),
+		Description: sa.GetDescription(),
+		IsAdmin:     sa.IsAdmin(),
+		CreatedAt:   sa.GetCreatedAt(),
+	}
+}
+
+func (s *ServiceAccountService) Create(ctx context.Context, req *pb.ServiceAccountCreateRequest) (*pb.ServiceAccountResponse, error) {
+	logrus.Debugf("rpc call: service account create: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+	if !perms.Contains(ovpm.ManageServiceAccountsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageServiceAccountsPerm is required for this operation.")
+	}
+
+	if req.IsAdmin {
+		if err := checkGrantable(perms, ovpm.AdminPerms()); err != nil {
+			return nil, err
+		}
+	}
+
+	sa, err := ovpm.CreateServiceAccount(req.Name, req.Description, req.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ServiceAccountResponse{ServiceAccounts: []*pb.ServiceAccountResponse_ServiceAccount{serviceAccountResponse(sa)}}, nil
+}
+
+func (s *ServiceAccountService) List(ctx context.Context, req *pb.ServiceAccountListRequest) (*pb.ServiceAccountResponse, error) {
+	logrus.Debug("rpc call: service account list")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+	if !perms.Contains(ovpm.ManageServiceAccountsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageServiceAccountsPerm is required for this operation.")
+	}
+
+	sas, err := ovpm.GetAllServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	var st []*pb.ServiceAccountResponse_ServiceAccount
+	for _, sa := range sas {
+		st = append(st, serviceAccountResponse(sa))
+	}
+	return &pb.ServiceAccountResponse{ServiceAccounts: st}, nil
+}
+
+func (s *ServiceAccountService) Delete(ctx context.Context, req *pb.ServiceAccountDeleteRequest) (*pb.ServiceAccountResponse, error) {
+	logrus.Debugf("rpc call: service account delete: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+	if !perms.Contains(ovpm.ManageServiceAccountsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageServiceAccountsPerm is required for this operation.")
+	}
+
+	if err := ovpm.DeleteServiceAccount(req.Name); err != nil {
+		return nil, err
+	}
+	return &pb.ServiceAccountResponse{}, nil
+}
+
+type RoleService struct{}
+
+// roleResponse returns the api representation of the role.
+func roleResponse(role *ovpm.Role) *pb.RoleResponse_Role {
+	return &pb.RoleResponse_Role{
+		Name:        role.GetName(),
+		Description: role.GetDescription(),
+		Perms:       ovpm.PermNames(role.GetPerms()),
+		IsBuiltIn:   role.IsBuiltIn(),
+		Usernames:   role.GetUsernames(),
+	}
+}
+
+// roleNames returns the names of the user's roles.
+func roleNames(user *ovpm.User) []string {
+	roles, err := user.GetRoles()
+	if err != nil {
+		logrus.Errorf("roles of %s can not be fetched: %v", user.GetUsername(), err)
+		return nil
+	}
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.GetName())
+	}
+	return names
+}
+
+// checkGrantable returns an error unless the caller has all of the given permissions.
+//
+// Otherwise the callers could escalate their privileges by granting roles.
+func checkGrantable(perms permset.Permset, grant []permset.Perm) error {
+	for _, perm := range grant {
+		if !perms.Contains(perm) {
+			return grpc.Errorf(codes.PermissionDenied, "permission %s can not be granted by the caller", ovpm.PermName(perm))
+		}
+	}
+	return nil
+}
+
+func (s *RoleService) List(ctx context.Context, req *pb.RoleListRequest) (*pb.RoleResponse, error) {
+	logrus.Debug("rpc call: role list")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ListRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListRolesPerm is required for this operation")
+	}
+
+	roles, err := ovpm.GetAllRoles()
+	if err != nil {
+		logrus.Errorf("roles can not be fetched: %v", err)
+		return nil, grpc.Errorf(codes.Internal, "roles can not be fetched")
+	}
+	var rr []*pb.RoleResponse_Role
+	for _, role := range roles {
+		rr = append(rr, roleResponse(role))
+	}
+	return &pb.RoleResponse{Roles: rr}, nil
+}
+
+func (s *RoleService) Create(ctx context.Context, req *pb.RoleCreateRequest) (*pb.RoleResponse, error) {
+	logrus.Debugf("rpc call: role create: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageRolesPerm is required for this operation")
+	}
+
+	rolePerms, err := ovpm.PermsFromNames(req.Perms)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := checkGrantable(perms, rolePerms); err != nil {
+		return nil, err
+	}
+
+	role, err := ovpm.CreateRole(req.Name, req.Description, rolePerms)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RoleResponse{Roles: []*pb.RoleResponse_Role{roleResponse(role)}}, nil
+}
+
+func (s *RoleService) Update(ctx context.Context, req *pb.RoleUpdateRequest) (*pb.RoleResponse, error) {
+	logrus.Debugf("rpc call: role update: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageRolesPerm is required for this operation")
+	}
+
+	role, err := ovpm.GetRole(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	var rolePerms []permset.Perm
+	if len(req.Perms) > 0 {
+		if rolePerms, err = ovpm.PermsFromNames(req.Perms); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err := checkGrantable(perms, rolePerms); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := role.Update(req.Description, rolePerms); err != nil {
+		return nil, err
+	}
+	return &pb.RoleResponse{Roles: []*pb.RoleResponse_Role{roleResponse(role)}}, nil
+}
+
+func (s *RoleService) Delete(ctx context.Context, req *pb.RoleDeleteRequest) (*pb.RoleResponse, error) {
+	logrus.Debugf("rpc call: role delete: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageRolesPerm is required for this operation")
+	}
+
+	role, err := ovpm.GetRole(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := role.Delete(); err != nil {
+		return nil, err
+	}
+	return &pb.RoleResponse{}, nil
+}
+
+func (s *RoleService) Assign(ctx context.Context, req *pb.RoleAssignRequest) (*pb.RoleResponse, error) {
+	logrus.Debugf("rpc call: role assign: %s to %s", req.Name, req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageRolesPerm is required for this operation")
+	}
+
+	role, err := ovpm.GetRole(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(perms, role.GetPerms()); err != nil {
+		return nil, err
+	}
+	if err := role.Assign(req.Username); err != nil {
+		return nil, err
+	}
+	return &pb.RoleResponse{Roles: []*pb.RoleResponse_Role{roleResponse(role)}}, nil
+}
+
+func (s *RoleService) Unassign(ctx context.Context, req *pb.RoleAssignRequest) (*pb.RoleResponse, error) {
+	logrus.Debugf("rpc call: role unassign: %s from %s", req.Name, req.Username)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageRolesPerm is required for this operation")
+	}
+
+	role, err := ovpm.GetRole(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(perms, role.GetPerms()); err != nil {
+		return nil, err
+	}
+	if err := role.Unassign(req.Username); err != nil {
+		return nil, err
+	}
+	return &pb.RoleResponse{Roles: []*pb.RoleResponse_Role{roleResponse(role)}}, nil
+}
+
+func (s *RoleService) ListPerms(ctx context.Context, req *pb.RoleListPermsRequest) (*pb.RolePermsResponse, error) {
+	logrus.Debug("rpc call: role list perms")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ListRolesPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListRolesPerm is required for this operation")
+	}
+
+	return &pb.RolePermsResponse{Perms: ovpm.PermNames(ovpm.AdminPerms())}, nil
+}
+
+type GroupService struct{}
+
+// groupResponse returns the api representation of the group.
+func groupResponse(group *ovpm.Group) *pb.GroupResponse_Group {
+	return &pb.GroupResponse_Group{
+		Name:         group.GetName(),
+		Description:  group.GetDescription(),
+		NoGw:         group.IsNoGW(),
+		Dns:          group.GetDNS(),
+		Pushes:       group.GetPushes(),
+		Usernames:    group.GetMemberUsernames(),
+		NetworkNames: group.GetAssociatedNetworkNames(),
+		IpPool:       group.GetIPPool(),
+	}
+}
+
+func (s *GroupService) List(ctx context.Context, req *pb.GroupListRequest) (*pb.GroupResponse, error) {
+	logrus.Debug("rpc call: group list")
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ListGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ListGroupsPerm is required for this operation")
+	}
+
+	groups, err := ovpm.GetAllGroups()
+	if err != nil {
+		logrus.Errorf("groups can not be fetched: %v", err)
+		return nil, grpc.Errorf(codes.Internal, "groups can not be fetched")
+	}
+	var gr []*pb.GroupResponse_Group
+	for _, group := range groups {
+		gr = append(gr, groupResponse(group))
+	}
+	return &pb.GroupResponse{Groups: gr}, nil
+}
+
+func (s *GroupService) Create(ctx context.Context, req *pb.GroupCreateRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group create: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+
+	group, err := ovpm.CreateGroup(req.Name, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+func (s *GroupService) Update(ctx context.Context, req *pb.GroupUpdateRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group update: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	noGW := group.IsNoGW()
+	switch req.Gwpref {
+	case pb.GroupUpdateRequest_NOGW:
+		noGW = true
+	case pb.GroupUpdateRequest_GW:
+		noGW = false
+	}
+	dns := group.GetDNS()
+	if len(req.Dns) > 0 || req.ResetDns {
+		dns = req.Dns
+	}
+	pushes := group.GetPushes()
+	if len(req.Pushes) > 0 || req.ResetPushes {
+		pushes = req.Pushes
+	}
+
+	if req.IpPool != "" || req.ResetIpPool {
+		if err := group.SetIPPool(req.IpPool); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if err := group.Update(req.Description, noGW, dns, pushes); err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+func (s *GroupService) Delete(ctx context.Context, req *pb.GroupDeleteRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group delete: %s", req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := group.Delete(); err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{}, nil
+}
+
+func (s *GroupService) AddMember(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group add member: %s to %s", req.Username, req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := group.AddMember(req.Username); err != nil {
+		return nil, err
+	}
+	if group, err = ovpm.GetGroup(req.Name); err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+func (s *GroupService) RemoveMember(ctx context.Context, req *pb.GroupMemberRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group remove member: %s from %s", req.Username, req.Name)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := group.RemoveMember(req.Username); err != nil {
+		return nil, err
+	}
+	if group, err = ovpm.GetGroup(req.Name); err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+func (s *GroupService) Associate(ctx context.Context, req *pb.GroupAssociateRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group associate: %s with %s", req.Name, req.NetworkName)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+	if !perms.Contains(ovpm.AssociateNetworkUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.AssociateNetworkUserPerm is required for this operation")
+	}
+	if _, err := ovpm.ParseACLRules(req.Rules); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	network, err := ovpm.GetNetwork(req.NetworkName)
+	if err != nil {
+		return nil, err
+	}
+	if err := network.AssociateGroup(req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.Rules) > 0 {
+		if err := network.SetGroupRules(req.Name, req.Rules); err != nil {
+			return nil, err
+		}
+	}
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+func (s *GroupService) Dissociate(ctx context.Context, req *pb.GroupAssociateRequest) (*pb.GroupResponse, error) {
+	logrus.Debugf("rpc call: group dissociate: %s from %s", req.Name, req.NetworkName)
+	perms, err := permset.FromContext(ctx)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "permset not found within the context")
+	}
+
+	// Check perms.
+	if !perms.Contains(ovpm.ManageGroupsPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.ManageGroupsPerm is required for this operation")
+	}
+	if !perms.Contains(ovpm.DissociateNetworkUserPerm) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "ovpm.DissociateNetworkUserPerm is required for this operation")
+	}
+
+	network, err := ovpm.GetNetwork(req.NetworkName)
+	if err != nil {
+		return nil, err
+	}
+	if err := network.DissociateGroup(req.Name); err != nil {
+		return nil, err
+	}
+	group, err := ovpm.GetGroup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GroupResponse{Groups: []*pb.GroupResponse_Group{groupResponse(group)}}, nil
+}
+
+// NewRPCServer returns a new gRPC server.
+//
+// If limiter isn't nil, the requests of each remote client are rate limited to its rate and burst.
+func NewRPCServer(limiter *rate.Limiter) *grpc.Server {
+	var opts []grpc.ServerOption
+	if limiter != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(RateLimitUnaryInterceptor(limiter), AuthUnaryInterceptor))
+	} else {
+		opts = append(opts, grpc.UnaryInterceptor(AuthUnaryInterceptor))
+	}
 	s := grpc.NewServer(opts...)
 	//s := grpc.NewServer()
 	pb.RegisterUserServiceServer(s, &UserService{})
 	pb.RegisterVPNServiceServer(s, &VPNService{})
 	pb.RegisterNetworkServiceServer(s, &NetworkService{})
 	pb.RegisterAuthServiceServer(s, &AuthService{})
+	pb.RegisterTokenServiceServer(s, &TokenService{})
+	pb.RegisterServiceAccountServiceServer(s, &ServiceAccountService{})
+	pb.RegisterRoleServiceServer(s, &RoleService{})
+	pb.RegisterGroupServiceServer(s, &GroupService{})
 	return s
 }
@@ -0,0 +1,113 @@
+package ovpm
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Audited actions.
+const (
+	AuditAuthLockout        = "auth:lockout"         // an account or a source ip is locked out
+	AuditAuthLockoutCleared = "auth:lockout-cleared" // a lockout is cleared by an admin
+
+	AuditPasswordResetRequested = "auth:password-reset-requested" // a password reset link is emailed to a user
+	AuditPasswordReset          = "auth:password-reset"           // a password is reset with an emailed link
+	AuditProcessFailure         = "process:failure"               // the OpenVPN process failed
+	AuditAdminDigest            = "notify:admin-digest"           // a digest is emailed to the admins
+	AuditProfileLinkIssued      = "profile-link:issued"           // a profile download link is created by an admin
+	AuditProfileLinkRedeemed    = "profile-link:redeemed"         // a profile is downloaded through a link
+	AuditProfileLinkRevoked     = "profile-link:revoked"          // a link is revoked after too many failed attempts
+	AuditInvitationIssued       = "invitation:issued"             // a pending user is invited by an admin
+	AuditInvitationAccepted     = "invitation:accepted"           // an invited user sets its password and downloads its profile
+	AuditInvitationRevoked      = "invitation:revoked"            // an invitation and its pending user are deleted by an admin
+)
+
+// dbAuditModel is database model for audit log entries.
+//
+// Audit entries are append only; they are never updated.
+type dbAuditModel struct {
+	ID         uint      `gorm:"primary_key"`
+	CreatedAt  time.Time `gorm:"index"`
+	Action     string    `gorm:"index"`
+	Actor      string    // username of whoever caused the action; empty if it's ovpm itself
+	Target     string    // what the action is about, e.g. a username or an ip
+	RemoteAddr string    // source ip of the request that caused the action, if any
+	Details    string
+}
+
+// AuditEntry represents an entry of the audit log.
+type AuditEntry struct {
+	dbAuditModel
+}
+
+// audit appends an entry to the audit log.
+func audit(action, actor, target, remoteAddr, details string) {
+	entry := dbAuditModel{
+		Action:     action,
+		Actor:      actor,
+		Target:     target,
+		RemoteAddr: remoteAddr,
+		Details:    details,
+	}
+	db.Create(&entry)
+	if db.NewRecord(&entry) {
+		logrus.Errorf("audit entry can not be created: %s %s", action, target)
+		return
+	}
+	logrus.Debugf("audit: %s %s by '%s' from '%s': %s", action, target, actor, remoteAddr, details)
+}
+
+// GetAuditEntries returns the most recent entries of the audit log, newest first.
+//
+// If action isn't empty, only the entries of that action are returned. If limit is zero,
+// all of the matching entries are returned.
+func GetAuditEntries(action string, limit int) ([]*AuditEntry, error) {
+	q := db.Order("id desc")
+	if action != "" {
+		q = q.Where(&dbAuditModel{Action: action})
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	var dbEntries []*dbAuditModel
+	if err := q.Find(&dbEntries).Error; err != nil {
+		return nil, err
+	}
+
+	var entries []*AuditEntry
+	for _, e := range dbEntries {
+		entries = append(entries, &AuditEntry{dbAuditModel: *e})
+	}
+	return entries, nil
+}
+
+// GetAction returns the audited action.
+func (e *AuditEntry) GetAction() string {
+	return e.Action
+}
+
+// GetActor returns the username of whoever caused the action. It's empty if it's ovpm itself.
+func (e *AuditEntry) GetActor() string {
+	return e.Actor
+}
+
+// GetTarget returns what the action is about.
+func (e *AuditEntry) GetTarget() string {
+	return e.Target
+}
+
+// GetRemoteAddr returns the source ip of the request that caused the action.
+func (e *AuditEntry) GetRemoteAddr() string {
+	return e.RemoteAddr
+}
+
+// GetDetails returns the details of the action.
+func (e *AuditEntry) GetDetails() string {
+	return e.Details
+}
+
+// GetCreatedAt returns the time of the action.
+func (e *AuditEntry) GetCreatedAt() time.Time {
+	return e.CreatedAt
+}
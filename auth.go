@@ -0,0 +1,63 @@
+package ovpm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Authenticate checks the given credentials and returns the user they belong to.
+//
+// When the LDAP backend is enabled; LDAP managed users, as well as the users that don't exist
+// in ovpm yet, are authenticated against the directory. The latter are created on their first
+// successful login. All other users are checked against their local passwords.
+func Authenticate(username, password string) (*User, error) {
+	user, err := GetUser(username)
+	if cfg := GetLDAPConfig(); cfg != nil && (err != nil || user.IsLDAPUser()) {
+		user, err = authenticateLDAP(cfg, username, password)
+		if err != nil {
+			logrus.Debugf("ldap authentication failed for %s: %v", username, err)
+			return nil, fmt.Errorf("authentication failed: %s", username)
+		}
+	} else if err != nil || !user.CheckPassword(password) {
+		return nil, fmt.Errorf("authentication failed: %s", username)
+	}
+
+	if user.IsDisabled() {
+		return nil, fmt.Errorf("user is disabled: %s", username)
+	}
+	if err := user.checkValidity(time.Now()); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// authenticateLDAP authenticates the user against the directory and brings the
+// corresponding ovpm user up to date with the directory entry.
+func authenticateLDAP(cfg *LDAPConfig, username, password string) (*User, error) {
+	entry, err := cfg.authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+	if !isValidUsername(entry.Username) {
+		return nil, fmt.Errorf("`%s` is not a valid username", entry.Username)
+	}
+
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	user, changed, err := cfg.applyLDAPEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+	// The vpn server isn't restarted, since logging in doesn't change anything that its
+	// connected clients need.
+	if changed {
+		if err := svr.Emit(); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
@@ -0,0 +1,228 @@
+package ovpm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/master312/ovpm/pki"
+	"github.com/sirupsen/logrus"
+)
+
+// CertConfig represents the lifetimes of the certificates that ovpm issues and the renewal policy
+// of the client certificates.
+//
+// Zero lifetimes fall back to pki.DefaultLifetime.
+type CertConfig struct {
+	CALifetime     time.Duration // lifetime of the CA cert, applied when the server is initialized
+	ServerLifetime time.Duration // lifetime of the server cert, applied when the server is initialized
+	ClientLifetime time.Duration // lifetime of the client certs, unless the user has its own
+	RenewBefore    time.Duration // client certs are renewed when they expire within this period
+}
+
+var certConfig = CertConfig{RenewBefore: DefaultCertRenewBefore}
+var certMu sync.Mutex
+
+// SetCertConfig sets the lifetimes of the certificates and the renewal policy of the client
+// certificates.
+func SetCertConfig(cfg CertConfig) error {
+	if cfg.CALifetime < 0 || cfg.ServerLifetime < 0 || cfg.ClientLifetime < 0 || cfg.RenewBefore < 0 {
+		return fmt.Errorf("validation error: cert lifetimes and renewal period can not be negative")
+	}
+	if err := validateCertLifetime(cfg.ClientLifetime, cfg.RenewBefore); err != nil {
+		return err
+	}
+	certMu.Lock()
+	defer certMu.Unlock()
+	certConfig = cfg
+	return nil
+}
+
+// GetCertConfig returns the lifetimes of the certificates and the renewal policy of the client
+// certificates.
+func GetCertConfig() CertConfig {
+	certMu.Lock()
+	defer certMu.Unlock()
+	return certConfig
+}
+
+// validateCertLifetime returns an error if the client certs that are issued with the lifetime
+// would be renewed as soon as they are issued.
+func validateCertLifetime(lifetime, renewBefore time.Duration) error {
+	if lifetime < 0 {
+		return fmt.Errorf("validation error: cert lifetime can not be negative")
+	}
+	if lifetime == 0 {
+		lifetime = pki.DefaultLifetime
+	}
+	if lifetime <= renewBefore {
+		return fmt.Errorf("validation error: cert lifetime (%s) must be longer than the renewal period (%s)", lifetime, renewBefore)
+	}
+	return nil
+}
+
+// GetCertLifetime returns the lifetime of the client certs issued to the user, or zero if the
+// server's default is used.
+func (u *User) GetCertLifetime() time.Duration {
+	return u.CertLifetime
+}
+
+// SetCertLifetime sets the lifetime of the client certs issued to the user. Zero resets it to the
+// server's default.
+//
+// It's applied to the next cert that is issued to the user, either by renewing it manually or by
+// the renewal job.
+func (u *User) SetCertLifetime(lifetime time.Duration) error {
+	if lifetime != 0 {
+		if err := validateCertLifetime(lifetime, GetCertConfig().RenewBefore); err != nil {
+			return err
+		}
+	}
+	db.Model(&u.dbUserModel).Update("CertLifetime", lifetime)
+	u.CertLifetime = lifetime
+	logrus.Infof("user cert lifetime updated: %s", u.Username)
+	return nil
+}
+
+// certLifetime returns the effective lifetime of the client certs issued to the user.
+func (u *User) certLifetime() time.Duration {
+	if u.CertLifetime != 0 {
+		return u.CertLifetime
+	}
+	return GetCertConfig().ClientLifetime
+}
+
+// IsCertExpiring returns whether the user's cert expires within the renewal period at the time.
+func (u *User) IsCertExpiring(t time.Time) bool {
+	if u.ServiceAccount || u.Cert == "" {
+		return false
+	}
+	return !u.ExpiresAt().After(t.Add(GetCertConfig().RenewBefore))
+}
+
+// renewCert issues a new client cert to the user and saves it. The previous cert is not revoked,
+// so it stays valid until the user connects with the new one (see revokePreviousCerts), the user
+// is deleted or the previous cert expires.
+func (u *User) renewCert() error {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	ca, err := svr.GetSystemCA()
+	if err != nil {
+		return err
+	}
+
+	clientCert, err := pki.NewClientCertHolder(ca, u.Username, u.certLifetime())
+	if err != nil {
+		return fmt.Errorf("can not create client cert %s: %v", u.Username, err)
+	}
+
+	if serial := u.certSerial(); serial != "" {
+		u.PreviousSerials = strings.Join(append(u.previousSerials(), serial), ",")
+	}
+	u.Cert = clientCert.Cert
+	u.Key = clientCert.Key
+	u.ServerSerialNumber = svr.SerialNumber
+	db.Save(u.dbUserModel)
+	return nil
+}
+
+// certSerial returns the serial number of the user's current cert in hex, or empty string if it
+// can't be read.
+func (u *User) certSerial() string {
+	crt, err := pki.ReadCertFromPEM(u.Cert)
+	if err != nil {
+		return ""
+	}
+	return crt.SerialNumber.Text(16)
+}
+
+// previousSerials returns the serial numbers of the renewed certs of the user that are not revoked
+// yet.
+func (u *User) previousSerials() []string {
+	if u.PreviousSerials == "" {
+		return nil
+	}
+	return strings.Split(u.PreviousSerials, ",")
+}
+
+// revokePreviousCerts revokes the renewed certs of the user, once the user connects with its
+// current cert, so that its previous profiles can no longer be used.
+func (u *User) revokePreviousCerts() error {
+	serials := u.previousSerials()
+	if len(serials) == 0 {
+		return nil
+	}
+	for _, serial := range serials {
+		db.Create(&dbRevokedModel{SerialNumber: serial})
+	}
+	db.Model(&u.dbUserModel).Update("PreviousSerials", "")
+	u.PreviousSerials = ""
+	logrus.Infof("previous certs of the user are revoked: %s", u.Username)
+	return TheServer().emitCRL()
+}
+
+// renewExpiringCerts renews the client certs that expire within the renewal period at the time.
+//
+// Renewed users are notified by email to download their new profiles, while they can still
+// connect with the previous ones until they expire. The users whose certs can't be renewed are
+// warned once, when their certs enter the renewal period since the previous check.
+func renewExpiringCerts(since, now time.Time) error {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil
+	}
+	users, err := GetAllUsers()
+	if err != nil {
+		return err
+	}
+	caExpiresAt := svr.CAExpiresAt()
+	for _, user := range users {
+		if !user.IsCertExpiring(now) {
+			continue
+		}
+		expiresAt := user.ExpiresAt()
+		if !caExpiresAt.After(expiresAt) {
+			logrus.Warnf("user cert can not be renewed beyond the ca expiration (%s), the server needs to be renewed: %s", caExpiresAt.Format(time.RFC3339), user.Username)
+			if !user.IsCertExpiring(since) {
+				if err := user.notifyCertExpiring(expiresAt, false); err != nil {
+					logrus.Errorf("user can not be notified about its cert expiration: %v", err)
+				}
+			}
+			continue
+		}
+		if err := user.renewCert(); err != nil {
+			return err
+		}
+		logrus.Infof("user cert renewed, expires at %s: %s", user.ExpiresAt().Format(time.RFC3339), user.Username)
+		if err := user.notifyCertExpiring(expiresAt, true); err != nil {
+			logrus.Errorf("user can not be notified about its cert renewal: %v", err)
+		}
+	}
+	return nil
+}
+
+// StartCertRenewalJob runs renewExpiringCerts periodically in the background until the returned
+// function is called.
+func StartCertRenewalJob(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		since := time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := renewExpiringCerts(since, now); err != nil {
+					logrus.Errorf("client cert renewal failed: %v", err)
+				}
+				since = now
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,130 @@
+package ovpm
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/master312/ovpm/pki"
+)
+
+func TestCertConfig(t *testing.T) {
+	defer SetCertConfig(CertConfig{RenewBefore: DefaultCertRenewBefore})
+
+	for _, cfg := range []CertConfig{
+		{CALifetime: -time.Hour},
+		{ClientLifetime: 24 * time.Hour, RenewBefore: 48 * time.Hour},
+	} {
+		if err := SetCertConfig(cfg); err == nil {
+			t.Fatalf("cert config is expected to be invalid: %+v", cfg)
+		}
+	}
+	cfg := CertConfig{ClientLifetime: 90 * 24 * time.Hour, RenewBefore: DefaultCertRenewBefore}
+	if err := SetCertConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if GetCertConfig() != cfg {
+		t.Fatalf("cert config is expected to be %+v but it's %+v", cfg, GetCertConfig())
+	}
+}
+
+func TestRenewExpiringCerts(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	defer SetCertConfig(CertConfig{RenewBefore: DefaultCertRenewBefore})
+	if err := SetCertConfig(CertConfig{CALifetime: 365 * 24 * time.Hour, ClientLifetime: 60 * 24 * time.Hour, RenewBefore: DefaultCertRenewBefore}); err != nil {
+		t.Fatal(err)
+	}
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	if _, err := CreateNewUser("alice", "1234", false, 0, true, ""); err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	bob, err := CreateNewUser("bob", "1234", false, 0, true, "")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+
+	// Test:
+	if exp := svr.CAExpiresAt(); exp.After(time.Now().Add(366 * 24 * time.Hour)) {
+		t.Fatalf("ca is expected to expire in a year, but it expires at %s", exp)
+	}
+	if err := bob.SetCertLifetime(24 * time.Hour); err == nil {
+		t.Fatalf("cert lifetime shorter than the renewal period is expected to be rejected")
+	}
+	if err := bob.SetCertLifetime(180 * 24 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	// Bob's new cert is issued with its own lifetime, but not beyond the ca.
+	if err := bob.Renew(); err != nil {
+		t.Fatal(err)
+	}
+	if exp := bob.ExpiresAt(); exp.Before(time.Now().Add(179*24*time.Hour)) || exp.After(svr.CAExpiresAt()) {
+		t.Fatalf("cert of bob is expected to expire in 180 days, but it expires at %s", exp)
+	}
+
+	// Nothing expires within the renewal period yet.
+	if err := renewExpiringCerts(time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := GetUser("alice")
+	if alice.IsCertExpiring(time.Now()) {
+		t.Fatalf("cert of alice is not expected to be expiring")
+	}
+	aliceCert, bobCert := alice.Cert, bob.Cert
+
+	// Alice's cert expires within the renewal period in 45 days, bob's doesn't.
+	later := time.Now().Add(45 * 24 * time.Hour)
+	if !alice.IsCertExpiring(later) {
+		t.Fatalf("cert of alice is expected to be expiring at %s", later)
+	}
+	if err := renewExpiringCerts(time.Now(), later); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ = GetUser("alice")
+	bob, _ = GetUser("bob")
+	if alice.Cert == aliceCert {
+		t.Fatalf("cert of alice is expected to be renewed")
+	}
+	if bob.Cert != bobCert {
+		t.Fatalf("cert of bob is not expected to be renewed")
+	}
+	var revoked []dbRevokedModel
+	db.Find(&revoked)
+	if len(revoked) != 0 {
+		t.Fatalf("previous cert of alice is not expected to be revoked: %v", revoked)
+	}
+
+	// Previous cert of alice is revoked once alice connects with the new one.
+	serialOf := func(cert string) *big.Int {
+		crt, err := pki.ReadCertFromPEM(cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return crt.SerialNumber
+	}
+	connect := func(cert string) {
+		t.Helper()
+		if err := authorizeClient(map[string]string{"common_name": "alice", "tls_serial_0": serialOf(cert).Text(10)}, false); err != nil {
+			t.Fatalf("alice is expected to connect: %v", err)
+		}
+	}
+	connect(aliceCert)
+	if db.Find(&revoked); len(revoked) != 0 {
+		t.Fatalf("previous cert of alice is not expected to be revoked while it's in use: %v", revoked)
+	}
+	connect(alice.Cert)
+	if db.Find(&revoked); len(revoked) != 1 || revoked[0].SerialNumber != serialOf(aliceCert).Text(16) {
+		t.Fatalf("previous cert of alice is expected to be revoked: %v", revoked)
+	}
+
+	// Deleting bob revokes the cert that bob renewed, along with the current one.
+	if err := bob.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if db.Find(&revoked); len(revoked) != 3 {
+		t.Fatalf("both certs of bob are expected to be revoked: %v", revoked)
+	}
+}
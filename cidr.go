@@ -0,0 +1,192 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CIDRKind is the kind of user of an address block.
+type CIDRKind string
+
+// Address block users.
+const (
+	CIDRVPN       CIDRKind = "vpn"       // vpn network of the server
+	CIDRNetwork   CIDRKind = "network"   // addresses of a network
+	CIDRMapped    CIDRKind = "mapped"    // virtual addresses of a mapped network
+	CIDRInterface CIDRKind = "interface" // network of an interface of the server
+	CIDRPool      CIDRKind = "pool"      // network of an ip pool beside the vpn network
+)
+
+// CIDRBlock is an address block in use.
+type CIDRBlock struct {
+	Kind CIDRKind
+	Name string // name of the network or the interface; empty for the vpn network
+	CIDR string
+
+	network   uint // id of the network, if any
+	mapped    bool // network is reached at virtual addresses
+	clientNet bool // network is behind a vpn client
+}
+
+func (b CIDRBlock) String() string {
+	switch b.Kind {
+	case CIDRVPN:
+		return fmt.Sprintf("the vpn network %s", b.CIDR)
+	case CIDRMapped:
+		return fmt.Sprintf("the virtual network of %s (%s)", b.Name, b.CIDR)
+	default:
+		return fmt.Sprintf("the %s %s (%s)", b.Kind, b.Name, b.CIDR)
+	}
+}
+
+// overlaps returns whether the blocks have addresses in common.
+func (b CIDRBlock) overlaps(o CIDRBlock) bool {
+	_, a, err := net.ParseCIDR(b.CIDR)
+	if err != nil {
+		return false
+	}
+	_, c, err := net.ParseCIDR(o.CIDR)
+	if err != nil {
+		return false
+	}
+	return a.Contains(c.IP) || c.Contains(a.IP)
+}
+
+// conflicts returns whether the blocks can't be used together because they overlap.
+//
+// Networks can overlap with each other as long as one of them is mapped, so that the clients can
+// tell them apart, and SERVERNET and ROUTE networks are expected to overlap with the interfaces of
+// the server. Everything else must be unique.
+func (b CIDRBlock) conflicts(o CIDRBlock) bool {
+	if !b.overlaps(o) {
+		return false
+	}
+	if b.Kind == CIDRInterface && o.Kind == CIDRInterface {
+		return false
+	}
+	if o.Kind == CIDRInterface {
+		b, o = o, b
+	}
+	switch {
+	case b.Kind == CIDRInterface:
+		return o.Kind != CIDRNetwork || o.clientNet
+	case b.Kind == CIDRNetwork && o.Kind == CIDRNetwork:
+		return b.clientNet || o.clientNet || (!b.mapped && !o.mapped)
+	}
+	return true
+}
+
+// CIDRConflict is an overlap of two address blocks that can't be used together.
+//
+// It's returned as an error when an address block can't be used.
+type CIDRConflict struct {
+	Block    CIDRBlock // block that is checked
+	Conflict CIDRBlock // block that is in the way
+}
+
+func (c *CIDRConflict) Error() string {
+	return fmt.Sprintf("validation error: `%s` overlaps with %s", c.Block.CIDR, c.Conflict)
+}
+
+// interfaceCIDRs returns the networks of the interfaces of the server, except the vpn interface.
+var interfaceCIDRs = func() []CIDRBlock {
+	// When testing, there are no interfaces.
+	if Testing {
+		return nil
+	}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		logrus.Debugf("can not get the interfaces: %v", err)
+		return nil
+	}
+	var vpnIfaceName string
+	if vpnIfc := vpnInterface(); vpnIfc != nil {
+		vpnIfaceName = vpnIfc.Name
+	}
+	var blocks []CIDRBlock
+	for _, iface := range ifaces {
+		if iface.Name == vpnIfaceName {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.To4() == nil {
+				continue
+			}
+			network := &net.IPNet{IP: ipnet.IP.Mask(ipnet.Mask), Mask: ipnet.Mask}
+			blocks = append(blocks, CIDRBlock{Kind: CIDRInterface, Name: iface.Name, CIDR: network.String()})
+		}
+	}
+	return blocks
+}
+
+// usedCIDRs returns the address blocks in use by the vpn network, the ip pools beside it, the
+// networks and the interfaces of the server.
+func usedCIDRs() []CIDRBlock {
+	var blocks []CIDRBlock
+	if svr := TheServer(); svr.IsInitialized() {
+		blocks = append(blocks, CIDRBlock{Kind: CIDRVPN, CIDR: svr.vpnNet().String()})
+		pools, _ := getIPPools()
+		for _, p := range pools {
+			if p.Subnet != "" {
+				blocks = append(blocks, CIDRBlock{Kind: CIDRPool, Name: p.Name, CIDR: p.Subnet})
+			}
+		}
+	}
+	networks := GetAllNetworks()
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	for _, n := range networks {
+		blocks = append(blocks, n.cidrBlock())
+		if n.MappedCIDR != "" {
+			blocks = append(blocks, CIDRBlock{Kind: CIDRMapped, Name: n.Name, CIDR: n.MappedCIDR, network: n.ID})
+		}
+	}
+	return append(blocks, interfaceCIDRs()...)
+}
+
+// cidrBlock returns the address block of the network.
+func (n *Network) cidrBlock() CIDRBlock {
+	return CIDRBlock{
+		Kind:      CIDRNetwork,
+		Name:      n.Name,
+		CIDR:      n.CIDR,
+		network:   n.ID,
+		mapped:    n.MappedCIDR != "",
+		clientNet: n.Type == CLIENTNET,
+	}
+}
+
+// checkCIDRConflict returns a *CIDRConflict error if the block conflicts with an address block in
+// use, except the ones that are ignored.
+func checkCIDRConflict(block CIDRBlock, ignore func(CIDRBlock) bool) error {
+	for _, used := range usedCIDRs() {
+		if ignore != nil && ignore(used) {
+			continue
+		}
+		if block.conflicts(used) {
+			return &CIDRConflict{Block: block, Conflict: used}
+		}
+	}
+	return nil
+}
+
+// CheckCIDRs audits the address blocks in use, and returns the conflicts between them.
+func CheckCIDRs() []CIDRConflict {
+	var conflicts []CIDRConflict
+	blocks := usedCIDRs()
+	for i := range blocks {
+		for _, other := range blocks[i+1:] {
+			if blocks[i].conflicts(other) {
+				conflicts = append(conflicts, CIDRConflict{Block: blocks[i], Conflict: other})
+			}
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,78 @@
+package ovpm
+
+import (
+	"testing"
+)
+
+func TestCIDRConflicts(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("branch1", "1234", false, 0, true, "")
+	defer func(f func() []CIDRBlock) { interfaceCIDRs = f }(interfaceCIDRs)
+	interfaceCIDRs = func() []CIDRBlock {
+		return []CIDRBlock{{Kind: CIDRInterface, Name: "eth0", CIDR: "192.168.1.0/24"}}
+	}
+
+	// Test:
+	if _, err := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, ""); err != nil {
+		t.Fatalf("SERVERNET is expected to overlap with the interfaces: %v", err)
+	}
+	if _, err := CreateNewNetwork("office", "10.10.0.0/16", ROUTE, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name, cidr string
+		conflict   CIDRBlock
+	}{
+		{"vpn", "10.9.0.0/16", CIDRBlock{Kind: CIDRVPN, CIDR: "10.9.0.0/24"}},
+		{"office2", "10.10.5.0/24", CIDRBlock{Kind: CIDRNetwork, Name: "office", CIDR: "10.10.0.0/16"}},
+		{"lan2", "192.168.0.0/16", CIDRBlock{Kind: CIDRNetwork, Name: "lan", CIDR: "192.168.1.0/24"}},
+	} {
+		_, err := CreateNewNetwork(tt.name, tt.cidr, SERVERNET, "")
+		conflict, ok := err.(*CIDRConflict)
+		if !ok {
+			t.Errorf("%s is expected to fail with a conflict: %v", tt.name, err)
+			continue
+		}
+		if conflict.Conflict.Kind != tt.conflict.Kind || conflict.Conflict.Name != tt.conflict.Name || conflict.Conflict.CIDR != tt.conflict.CIDR {
+			t.Errorf("%s is expected to conflict with %s, got %s", tt.name, tt.conflict, conflict.Conflict)
+		}
+	}
+	_, err := CreateNewClientNetwork("branch", "192.168.1.128/25", "branch1")
+	if conflict, ok := err.(*CIDRConflict); !ok || conflict.Conflict.Name != "lan" {
+		t.Fatalf("CLIENTNET is expected to conflict with lan: %v", err)
+	}
+
+	// The vpn network can't be moved onto the networks or the interfaces.
+	if err := svr.Update("10.10.0.0/24", "", nil); err == nil {
+		t.Fatalf("vpn network is not expected to overlap with office")
+	}
+	if err := svr.Update("192.168.0.0/16", "", nil); err == nil {
+		t.Fatalf("vpn network is not expected to overlap with eth0")
+	}
+	if err := svr.Update("10.8.0.0/24", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Audit finds the conflicts caused by the changes of the interfaces.
+	if conflicts := CheckCIDRs(); len(conflicts) != 0 {
+		t.Fatalf("no conflicts are expected: %v", conflicts)
+	}
+	interfaceCIDRs = func() []CIDRBlock {
+		return []CIDRBlock{
+			{Kind: CIDRInterface, Name: "eth0", CIDR: "192.168.1.0/24"},
+			{Kind: CIDRInterface, Name: "eth1", CIDR: "10.8.0.0/16"},
+		}
+	}
+	conflicts := CheckCIDRs()
+	if len(conflicts) != 1 || conflicts[0].Block.Kind != CIDRVPN || conflicts[0].Conflict.Name != "eth1" {
+		t.Fatalf("vpn network is expected to conflict with eth1: %v", conflicts)
+	}
+	if msg := conflicts[0].Error(); msg != "validation error: `10.8.0.0/24` overlaps with the interface eth1 (10.8.0.0/16)" {
+		t.Fatalf("unexpected error message: %s", msg)
+	}
+}
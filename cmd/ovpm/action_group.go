@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+// groupListAction lists the user groups on the terminal.
+func groupListAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	groupListResp, err := groupSvc.List(context.Background(), &pb.GroupListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the group table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "members", "networks", "no gw", "dns", "pushes", "description"})
+	table.SetAutoWrapText(false)
+	for i, group := range groupListResp.Groups {
+		noGW := "✘"
+		if group.NoGw {
+			noGW = "✔"
+		}
+		data := []string{
+			fmt.Sprintf("%v", i+1),
+			group.Name,
+			strings.Join(group.Usernames, "\n"),
+			strings.Join(group.NetworkNames, "\n"),
+			noGW,
+			strings.Join(group.Dns, "\n"),
+			strings.Join(group.Pushes, "\n"),
+			group.Description,
+		}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// groupCreateAction creates a user group.
+func groupCreateAction(rpcSrvURLStr string, name string, description string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	_, err = groupSvc.Create(context.Background(), &pb.GroupCreateRequest{Name: name, Description: description})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("group created: %s", name)
+	return nil
+}
+
+// groupUpdateAction updates a user group.
+func groupUpdateAction(rpcSrvURLStr string, req *pb.GroupUpdateRequest) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	_, err = groupSvc.Update(context.Background(), req)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("group updated: %s", req.Name)
+	return nil
+}
+
+// groupDeleteAction deletes a user group.
+func groupDeleteAction(rpcSrvURLStr string, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	_, err = groupSvc.Delete(context.Background(), &pb.GroupDeleteRequest{Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("group deleted: %s", name)
+	return nil
+}
+
+// groupMemberAction adds the user to the group, or removes it if remove is true.
+func groupMemberAction(rpcSrvURLStr string, name string, username string, remove bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	req := pb.GroupMemberRequest{Name: name, Username: username}
+	if remove {
+		_, err = groupSvc.RemoveMember(context.Background(), &req)
+	} else {
+		_, err = groupSvc.AddMember(context.Background(), &req)
+	}
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	if remove {
+		logrus.Infof("user '%s' is removed from the group '%s'", username, name)
+		return nil
+	}
+	logrus.Infof("user '%s' is added to the group '%s'", username, name)
+	return nil
+}
+
+// groupAssociateAction associates the group with the network, or dissociates it if dissociate is true.
+func groupAssociateAction(rpcSrvURLStr string, name string, netName string, rules []string, dissociate bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var groupSvc = pb.NewGroupServiceClient(rpcConn)
+
+	req := pb.GroupAssociateRequest{Name: name, NetworkName: netName, Rules: rules}
+	if dissociate {
+		_, err = groupSvc.Dissociate(context.Background(), &req)
+	} else {
+		_, err = groupSvc.Associate(context.Background(), &req)
+	}
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	if dissociate {
+		logrus.Infof("group '%s' is dissociated with the network '%s'", name, netName)
+		return nil
+	}
+	logrus.Infof("group '%s' is associated with the network '%s'", name, netName)
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+// invitationListAction lists the invitations of the pending users on the terminal.
+func invitationListAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	invitationsResp, err := userSvc.ListInvitations(context.Background(), &pb.UserListInvitationsRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the invitation table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "username", "issued by", "issued", "expires"})
+	for i, inv := range invitationsResp.Invitations {
+		username := inv.Username
+		if inv.IsExpired {
+			username = fmt.Sprintf("%s (expired)", username)
+		}
+		data := []string{
+			fmt.Sprintf("%v", i+1),
+			username,
+			inv.IssuedBy,
+			formatTokenTime(inv.CreatedAt, ""),
+			formatTokenTime(inv.ExpiresAt, ""),
+		}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// invitationRevokeAction revokes the invitation of a pending user, which deletes the user.
+func invitationRevokeAction(rpcSrvURLStr string, username string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	_, err = userSvc.RevokeInvitation(context.Background(), &pb.UserRevokeInvitationRequest{Username: username})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("invitation revoked: %s", username)
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+// lockoutListAction lists the lockouts on the terminal.
+func lockoutListAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var authSvc = pb.NewAuthServiceClient(rpcConn)
+
+	lockoutsResp, err := authSvc.ListLockouts(context.Background(), &pb.AuthListLockoutsRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the lockout table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "kind", "username/ip", "failures", "last failure", "locked until"})
+	for i, l := range lockoutsResp.Lockouts {
+		data := []string{
+			fmt.Sprintf("%v", i+1),
+			l.Kind,
+			l.Key,
+			fmt.Sprintf("%d", l.Failures),
+			formatLockoutTime(l.LastFailureAt),
+			formatLockoutTime(l.LockedUntil),
+		}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// lockoutClearAction lifts a lockout, or all of them.
+func lockoutClearAction(rpcSrvURLStr string, kind string, key string, all bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var authSvc = pb.NewAuthServiceClient(rpcConn)
+
+	_, err = authSvc.ClearLockout(context.Background(), &pb.AuthClearLockoutRequest{Kind: kind, Key: key, All: all})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	if all {
+		logrus.Info("all lockouts cleared")
+		return nil
+	}
+	logrus.Infof("lockout cleared: %s %s", kind, key)
+	return nil
+}
+
+// formatLockoutTime formats the lockout timestamps for the lockout list.
+func formatLockoutTime(t string) string {
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return t
+	}
+	return parsed.Local().Format("2006-01-02 15:04:05")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/asaskevich/govalidator"
@@ -42,7 +43,7 @@ func netListAction(rpcServURLStr string) error {
 
 	// Render the network table.
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"#", "name", "cidr", "type", "assoc", "created at"})
+	table.SetHeader([]string{"#", "name", "cidr", "type", "assoc", "groups", "created at"})
 	for i, network := range netListResp.Networks {
 		// Create associated user list for this network.
 		var usernameList string
@@ -54,6 +55,26 @@ func netListAction(rpcServURLStr string) error {
 		}
 
 		usernames := assocUsers.Usernames
+		userRules, groupRules := map[string]string{}, map[string]string{}
+		for _, acl := range network.Acls {
+			if acl.Username != "" {
+				userRules[acl.Username] = strings.Join(acl.Rules, " ")
+			} else {
+				groupRules[acl.GroupName] = strings.Join(acl.Rules, " ")
+			}
+		}
+		for i, uname := range usernames {
+			if r, ok := userRules[uname]; ok {
+				usernames[i] = fmt.Sprintf("%s (%s)", uname, r)
+			}
+		}
+		var groupNames []string
+		for _, gname := range network.AssociatedGroups {
+			if r, ok := groupRules[gname]; ok {
+				gname = fmt.Sprintf("%s (%s)", gname, r)
+			}
+			groupNames = append(groupNames, gname)
+		}
 		count := len(usernames)
 		for i, uname := range usernames {
 			if i+1 == count {
@@ -67,10 +88,27 @@ func netListAction(rpcServURLStr string) error {
 		if via == "" {
 			via = "vpn-server"
 		}
-		if ovpm.NetworkTypeFromString(network.Type) == ovpm.ROUTE {
+		switch ovpm.NetworkTypeFromString(network.Type) {
+		case ovpm.ROUTE:
 			cidr = fmt.Sprintf("%s via %s", network.Cidr, via)
+		case ovpm.CLIENTNET:
+			cidr = fmt.Sprintf("%s behind %s", network.Cidr, network.Owner)
+		}
+		if network.MappedCidr != "" {
+			cidr = fmt.Sprintf("%s as %s", network.MappedCidr, cidr)
+		}
+		var typ = network.Type
+		switch network.NatMode {
+		case "":
+		case "snat":
+			typ = fmt.Sprintf("%s (nat: snat to %s)", network.Type, network.SnatTo)
+		default:
+			typ = fmt.Sprintf("%s (nat: %s)", network.Type, network.NatMode)
 		}
-		data := []string{fmt.Sprintf("%v", i+1), network.Name, cidr, network.Type, usernameList, network.CreatedAt}
+		if network.DnsDomain != "" {
+			typ = fmt.Sprintf("%s (dns: %s at %s)", typ, network.DnsDomain, strings.Join(network.DnsServers, ", "))
+		}
+		data := []string{fmt.Sprintf("%v", i+1), network.Name, cidr, typ, usernameList, strings.Join(groupNames, ", "), network.CreatedAt}
 		table.Append(data)
 	}
 	table.Render()
@@ -146,7 +184,7 @@ func netUndefAction(rpcServURLStr string, networkName string) error {
 	return nil
 }
 
-func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType string, via *string) error {
+func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType string, via *string, owner string) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -178,6 +216,12 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 			exit(1)
 			return err
 		}
+	case ovpm.CLIENTNET:
+		if govalidator.IsNull(owner) {
+			err := errors.EmptyValue("owner", owner)
+			exit(1)
+			return err
+		}
 	default: // Means UNDEFINEDNET
 		fmt.Printf("undefined network type %s", netType)
 		fmt.Println()
@@ -192,7 +236,7 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 	var netSvc = pb.NewNetworkServiceClient(rpcConn)
 
 	// Call the service.
-	netCreateResp, err := netSvc.Create(context.Background(), &pb.NetworkCreateRequest{Name: netName, Cidr: netCIDR, Type: netType, Via: targetVia})
+	netCreateResp, err := netSvc.Create(context.Background(), &pb.NetworkCreateRequest{Name: netName, Cidr: netCIDR, Type: netType, Via: targetVia, Owner: owner})
 	if err != nil {
 		logrus.Errorf("network can not be created '%s': %v", netName, err)
 		exit(1)
@@ -202,7 +246,7 @@ func netDefAction(rpcServURLStr string, netName string, netCIDR string, netType
 	return nil
 }
 
-func netAssocAction(rpcServURLStr string, netName string, username string, inBulk bool) error {
+func netAssocAction(rpcServURLStr string, netName string, username string, rules []string, inBulk bool) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -237,7 +281,7 @@ func netAssocAction(rpcServURLStr string, netName string, username string, inBul
 
 	// Call the service.
 	for _, userName := range userNames {
-		_, err = netSvc.Associate(context.Background(), &pb.NetworkAssociateRequest{Name: netName, Username: userName})
+		_, err = netSvc.Associate(context.Background(), &pb.NetworkAssociateRequest{Name: netName, Username: userName, Rules: rules})
 		if err != nil {
 			errors.UnknownGRPCError(err)
 			//exit(1)
@@ -294,3 +338,176 @@ func netDissocAction(rpcServURLStr string, netName string, username string, inBu
 	}
 	return nil
 }
+
+func netRulesAction(rpcServURLStr string, netName string, username string, groupName string, rules []string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service callable.
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	// Call the service.
+	_, err = netSvc.SetRules(context.Background(), &pb.NetworkSetRulesRequest{Name: netName, Username: username, GroupName: groupName, Rules: rules})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if len(rules) == 0 {
+		logrus.Infof("network rules lifted: %s%s <-> network:%s", username, groupName, netName)
+		return nil
+	}
+	logrus.Infof("network rules set: %s%s <-> network:%s: %s", username, groupName, netName, strings.Join(rules, " "))
+	return nil
+}
+
+func netMapAction(rpcServURLStr string, netName string, mappedCIDR string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service callable.
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	// Call the service.
+	res, err := netSvc.Map(context.Background(), &pb.NetworkMapRequest{Name: netName, MappedCidr: mappedCIDR})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if mappedCIDR == "" {
+		logrus.Infof("network unmapped: %s", netName)
+		return nil
+	}
+	logrus.Infof("network mapped: %s (%s -> %s)", netName, res.Network.MappedCidr, res.Network.Cidr)
+	return nil
+}
+
+func netCheckAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service callable.
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	// Call the service.
+	res, err := netSvc.Check(context.Background(), &pb.NetworkCheckRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if len(res.Conflicts) == 0 {
+		logrus.Info("no conflicts found")
+		return nil
+	}
+
+	// Prepare table data and draw it on the terminal.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "kind", "name", "cidr", "conflicts with"})
+	for i, conflict := range res.Conflicts {
+		with := fmt.Sprintf("%s %s (%s)", conflict.Conflict.Kind, conflict.Conflict.Name, conflict.Conflict.Cidr)
+		if conflict.Conflict.Name == "" {
+			with = fmt.Sprintf("%s (%s)", conflict.Conflict.Kind, conflict.Conflict.Cidr)
+		}
+		table.Append([]string{fmt.Sprintf("%v", i+1), conflict.Block.Kind, conflict.Block.Name, conflict.Block.Cidr, with})
+	}
+	table.Render()
+
+	return nil
+}
+
+func netUpdateAction(rpcServURLStr string, netName string, newName string, cidr string, via string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service callable.
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	// Call the service.
+	res, err := netSvc.Update(context.Background(), &pb.NetworkUpdateRequest{Name: netName, NewName: newName, Cidr: cidr, Via: via})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("network updated: %s (%s)", res.Network.Name, res.Network.Cidr)
+	return nil
+}
+
+func netDNSAction(rpcServURLStr string, netName string, domain string, servers []string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service callable.
+	var netSvc = pb.NewNetworkServiceClient(rpcConn)
+
+	// Call the service.
+	_, err = netSvc.SetDNS(context.Background(), &pb.NetworkSetDNSRequest{Name: netName, Domain: domain, Servers: servers})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	if domain == "" {
+		logrus.Infof("network dns removed: %s", netName)
+		return nil
+	}
+	logrus.Infof("network dns set: %s (%s -> %s)", netName, domain, strings.Join(servers, ", "))
+	return nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+// roleListAction lists the roles on the terminal.
+func roleListAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	roleListResp, err := roleSvc.List(context.Background(), &pb.RoleListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the role table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "built-in", "perms", "users", "description"})
+	table.SetAutoWrapText(false)
+	for i, role := range roleListResp.Roles {
+		builtIn := "✘"
+		if role.IsBuiltIn {
+			builtIn = "✔"
+		}
+		users := strings.Join(role.Usernames, "\n")
+		if role.IsBuiltIn && role.Name == "user" {
+			users = "*"
+		}
+		data := []string{
+			fmt.Sprintf("%v", i+1),
+			role.Name,
+			builtIn,
+			strings.Join(role.Perms, "\n"),
+			users,
+			role.Description,
+		}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// rolePermsAction lists the permissions that can be given to roles on the terminal.
+func rolePermsAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	permsResp, err := roleSvc.ListPerms(context.Background(), &pb.RoleListPermsRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	for _, perm := range permsResp.Perms {
+		fmt.Println(perm)
+	}
+	return nil
+}
+
+// roleCreateAction creates a custom role.
+func roleCreateAction(rpcSrvURLStr string, name string, description string, perms []string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	_, err = roleSvc.Create(context.Background(), &pb.RoleCreateRequest{Name: name, Description: description, Perms: perms})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("role created: %s", name)
+	return nil
+}
+
+// roleUpdateAction updates a custom role.
+func roleUpdateAction(rpcSrvURLStr string, name string, description string, perms []string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	_, err = roleSvc.Update(context.Background(), &pb.RoleUpdateRequest{Name: name, Description: description, Perms: perms})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("role updated: %s", name)
+	return nil
+}
+
+// roleDeleteAction deletes a custom role.
+func roleDeleteAction(rpcSrvURLStr string, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	_, err = roleSvc.Delete(context.Background(), &pb.RoleDeleteRequest{Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("role deleted: %s", name)
+	return nil
+}
+
+// roleAssignAction assigns the role to the user, or unassigns it if unassign is true.
+func roleAssignAction(rpcSrvURLStr string, name string, username string, unassign bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var roleSvc = pb.NewRoleServiceClient(rpcConn)
+
+	req := pb.RoleAssignRequest{Name: name, Username: username}
+	if unassign {
+		_, err = roleSvc.Unassign(context.Background(), &req)
+	} else {
+		_, err = roleSvc.Assign(context.Background(), &req)
+	}
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	if unassign {
+		logrus.Infof("role '%s' is unassigned from '%s'", name, username)
+		return nil
+	}
+	logrus.Infof("role '%s' is assigned to '%s'", name, username)
+	return nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+)
+
+// tokenCreateAction creates an API token and prints it.
+//
+// The token is only printed once; it can not be retrieved later.
+func tokenCreateAction(rpcSrvURLStr string, username string, name string, ttl time.Duration, scopes []string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var tokenSvc = pb.NewTokenServiceClient(rpcConn)
+
+	req := pb.TokenCreateRequest{Username: username, Name: name, Scopes: scopes}
+	if ttl > 0 {
+		req.Ttl = ttl.String()
+	}
+	tokenCreateResp, err := tokenSvc.Create(context.Background(), &req)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("token created: %s of %s", name, username)
+	fmt.Println("Store the token safely, it won't be shown again:")
+	fmt.Println(tokenCreateResp.Token)
+	return nil
+}
+
+// tokenListAction lists the API tokens of a user on the terminal.
+func tokenListAction(rpcSrvURLStr string, username string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var tokenSvc = pb.NewTokenServiceClient(rpcConn)
+
+	tokenListResp, err := tokenSvc.List(context.Background(), &pb.TokenListRequest{Username: username})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the token table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "scopes", "created", "expires", "last used"})
+	for i, token := range tokenListResp.Tokens {
+		name := token.Name
+		if token.IsExpired {
+			name = fmt.Sprintf("%s (expired)", name)
+		}
+		scopes := strings.Join(token.Scopes, ", ")
+		if scopes == "" {
+			scopes = "*"
+		}
+		data := []string{
+			fmt.Sprintf("%v", i+1),
+			name,
+			scopes,
+			formatTokenTime(token.CreatedAt, ""),
+			formatTokenTime(token.ExpiresAt, "never"),
+			formatTokenTime(token.LastUsedAt, "never"),
+		}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// tokenRevokeAction revokes an API token.
+func tokenRevokeAction(rpcSrvURLStr string, username string, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var tokenSvc = pb.NewTokenServiceClient(rpcConn)
+
+	_, err = tokenSvc.Revoke(context.Background(), &pb.TokenRevokeRequest{Username: username, Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("token revoked: %s of %s", name, username)
+	return nil
+}
+
+// serviceAccountCreateAction creates a service account.
+func serviceAccountCreateAction(rpcSrvURLStr string, name string, description string, isAdmin bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var saSvc = pb.NewServiceAccountServiceClient(rpcConn)
+
+	_, err = saSvc.Create(context.Background(), &pb.ServiceAccountCreateRequest{Name: name, Description: description, IsAdmin: isAdmin})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("service account created: %s", name)
+	return nil
+}
+
+// serviceAccountListAction lists the service accounts on the terminal.
+func serviceAccountListAction(rpcSrvURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var saSvc = pb.NewServiceAccountServiceClient(rpcConn)
+
+	saListResp, err := saSvc.List(context.Background(), &pb.ServiceAccountListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	// Render the service account table.
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "admin", "description", "created"})
+	for i, sa := range saListResp.ServiceAccounts {
+		admin := "NO"
+		if sa.IsAdmin {
+			admin = "YES"
+		}
+		data := []string{fmt.Sprintf("%v", i+1), sa.Name, admin, sa.Description, sa.CreatedAt}
+		table.Append(data)
+	}
+	table.Render()
+
+	return nil
+}
+
+// serviceAccountDeleteAction deletes a service account.
+func serviceAccountDeleteAction(rpcSrvURLStr string, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var saSvc = pb.NewServiceAccountServiceClient(rpcConn)
+
+	_, err = saSvc.Delete(context.Background(), &pb.ServiceAccountDeleteRequest{Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("service account deleted: %s", name)
+	return nil
+}
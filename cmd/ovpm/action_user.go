@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/asaskevich/govalidator"
 	"github.com/sirupsen/logrus"
 	"github.com/master312/ovpm"
 	"github.com/master312/ovpm/api/pb"
@@ -54,7 +55,7 @@ func userListAction(rpcServURLStr string) error {
 	}
 
 	// Prepare table data.
-	header := []string{"#", "username", "ip", "created", "crt exp", "push gw", "admin"}
+	header := []string{"#", "username", "ip", "created", "crt exp", "status", "push gw", "admin"}
 	rows := [][]string{}
 	for i, user := range userListResp.Users {
 		isConnected := " "
@@ -81,6 +82,9 @@ func userListAction(rpcServURLStr string) error {
 			// Check if the cert is expired.
 			if expiresAt.After(time.Now()) {
 				isValidCRT = fmt.Sprintf("in %s", humanize.Time(expiresAt))
+				if user.CertExpiring {
+					isValidCRT += " (!)" // will be renewed soon
+				}
 			}
 		}
 
@@ -100,6 +104,7 @@ func userListAction(rpcServURLStr string) error {
 			fmt.Sprintf("%s %s", user.IpNet, static),
 			createdAt,
 			isValidCRT,
+			userStatus(user),
 			isPushGW,
 			isAdmin,
 		}
@@ -116,7 +121,7 @@ func userListAction(rpcServURLStr string) error {
 }
 
 // userCreateAction creates a new VPN user from the terminal.
-func userCreateAction(rpcSrvURLStr string, username string, password string, ipAddr *net.IP, noGW bool, isAdmin bool) error {
+func userCreateAction(rpcSrvURLStr string, username string, password string, ipAddr *net.IP, noGW bool, isAdmin bool, validity userValidityParams, email string, invite userInviteParams) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
 	if err != nil {
@@ -159,6 +164,13 @@ func userCreateAction(rpcSrvURLStr string, username string, password string, ipA
 		NoGw:     noGW,
 		HostId:   hostid,
 		IsAdmin:  isAdmin,
+
+		ValidFrom:  validity.validFrom,
+		ValidUntil: validity.validUntil,
+		Email:      email,
+
+		Invite:        invite.invite,
+		InvitationTtl: uint64(invite.ttl / time.Second),
 	})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
@@ -167,11 +179,180 @@ func userCreateAction(rpcSrvURLStr string, username string, password string, ipA
 	}
 
 	logrus.Infof("user created: %s", userCreateResp.Users[0].Username)
+	if invite.invite {
+		fmt.Println("Send the invitation link to the user, it won't be shown again:")
+		fmt.Println(userCreateResp.Users[0].InvitationUrl)
+	}
+	return nil
+}
+
+// userInviteParams are the options of inviting a new user instead of setting its password.
+type userInviteParams struct {
+	invite bool
+	ttl    time.Duration
+}
+
+// validate returns an error if the options are invalid or conflicting with the password.
+func (p userInviteParams) validate(password string) error {
+	if p.invite && password != "" {
+		return errors.ConflictingDemands("--invite and --password options are mutually exclusive (can not be used together)")
+	}
+	if !p.invite && password == "" {
+		return errors.EmptyValue("password", password)
+	}
+	if p.ttl != 0 && !p.invite {
+		return errors.ConflictingDemands("--invite-ttl option can only be used with --invite")
+	}
+	if p.ttl < 0 || p.ttl%time.Second != 0 {
+		return fmt.Errorf("--invite-ttl must be positive and in whole seconds: %s", p.ttl)
+	}
+	return nil
+}
+
+// userValidityParams are the changes of the window that the user can connect and log in within.
+type userValidityParams struct {
+	validFrom       string
+	resetValidFrom  bool
+	validUntil      string
+	resetValidUntil bool
+
+	certLifetime      time.Duration // lifetime of the next certs issued to the user
+	resetCertLifetime bool
+}
+
+// validate returns an error if the times are invalid or conflicting. Times are accepted in RFC3339
+// or as dates, which are midnight in the local time, and they are converted to RFC3339.
+func (p *userValidityParams) validate() error {
+	for _, flags := range []struct {
+		name, resetName string
+		set, reset      bool
+	}{
+		{"valid-from", "reset-valid-from", p.validFrom != "", p.resetValidFrom},
+		{"valid-until", "reset-valid-until", p.validUntil != "", p.resetValidUntil},
+		{"cert-lifetime", "reset-cert-lifetime", p.certLifetime != 0, p.resetCertLifetime},
+	} {
+		if flags.set && flags.reset {
+			return errors.ConflictingDemands(fmt.Sprintf("--%s and --%s options are mutually exclusive (can not be used together)", flags.name, flags.resetName))
+		}
+	}
+	var times []time.Time
+	for _, v := range []*string{&p.validFrom, &p.validUntil} {
+		if *v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, *v)
+		if err != nil {
+			if t, err = time.ParseInLocation("2006-01-02", *v, time.Local); err != nil {
+				return fmt.Errorf("`%s` must be an RFC3339 time or a date, e.g. 2006-01-02", *v)
+			}
+		}
+		*v = t.Format(time.RFC3339)
+		times = append(times, t)
+	}
+	if p.validFrom != "" && p.validUntil != "" && !times[1].After(times[0]) {
+		return fmt.Errorf("--valid-until must be after --valid-from")
+	}
+	if p.certLifetime < 0 {
+		return fmt.Errorf("--cert-lifetime can not be negative: %s", p.certLifetime)
+	}
+	if p.certLifetime%time.Second != 0 {
+		return fmt.Errorf("--cert-lifetime must be in whole seconds: %s", p.certLifetime)
+	}
+	return nil
+}
+
+// userStatus returns whether the user is pending, suspended or disabled, or its validity window in
+// a human readable form.
+func userStatus(user *pb.UserResponse_User) string {
+	switch {
+	case user.IsPending:
+		return "pending"
+	case user.IsSuspended:
+		return "suspended"
+	case user.IsDisabled:
+		return "disabled"
+	}
+	now := time.Now()
+	if t, err := time.Parse(time.RFC3339, user.ValidFrom); err == nil && now.Before(t) {
+		return fmt.Sprintf("from %s", humanize.Time(t))
+	}
+	if t, err := time.Parse(time.RFC3339, user.ValidUntil); err == nil {
+		if !now.Before(t) {
+			return "expired"
+		}
+		return fmt.Sprintf("until %s", humanize.Time(t))
+	}
+	return ""
+}
+
+// userPushParams are the changes of the options that are pushed to the user.
+type userPushParams struct {
+	dns                  []string
+	resetDNS             bool
+	routes               []string
+	resetRoutes          bool
+	redirectGateway      string
+	resetRedirectGateway bool
+	pushes               []string
+	resetPushes          bool
+	inactive             int
+	resetInactive        bool
+	ipPool               string // pool to lease the address that is pushed to the user from
+	resetIPPool          bool
+}
+
+// validate returns an error if the push options are invalid or conflicting.
+func (p userPushParams) validate() error {
+	for _, flags := range []struct {
+		name, resetName string
+		set, reset      bool
+	}{
+		{"dns", "reset-dns", len(p.dns) > 0, p.resetDNS},
+		{"route", "reset-routes", len(p.routes) > 0, p.resetRoutes},
+		{"redirect-gateway", "reset-redirect-gateway", p.redirectGateway != "", p.resetRedirectGateway},
+		{"push", "reset-push", len(p.pushes) > 0, p.resetPushes},
+		{"inactive", "reset-inactive", p.inactive != 0, p.resetInactive},
+		{"ip-pool", "reset-ip-pool", p.ipPool != "", p.resetIPPool},
+	} {
+		if flags.set && flags.reset {
+			return errors.ConflictingDemands(fmt.Sprintf("--%s and --%s options are mutually exclusive (can not be used together)", flags.name, flags.resetName))
+		}
+	}
+	for _, ip := range p.dns {
+		if !govalidator.IsIPv4(ip) {
+			return errors.NotIPv4(ip)
+		}
+	}
+	for _, cidr := range p.routes {
+		if !govalidator.IsCIDR(cidr) {
+			return errors.NotCIDR(cidr)
+		}
+	}
+	if p.inactive < 0 {
+		return fmt.Errorf("--inactive can not be negative: %d", p.inactive)
+	}
+	return nil
+}
+
+// userEmailParams are the changes of the address that the notifications of the user are sent to.
+type userEmailParams struct {
+	email      string
+	resetEmail bool
+}
+
+// validate returns an error if the address is invalid or conflicting.
+func (p userEmailParams) validate() error {
+	if p.email != "" && p.resetEmail {
+		return errors.ConflictingDemands("--email and --reset-email options are mutually exclusive (can not be used together)")
+	}
+	if p.email != "" && !govalidator.IsEmail(p.email) {
+		return fmt.Errorf("`%s` must be an email address", p.email)
+	}
 	return nil
 }
 
 // userUpdateAction creates a new VPN user from the terminal.
-func userUpdateAction(rpcSrvURLStr string, username string, password *string, ipAddr *net.IP, isStatic *bool, noGW *bool, isAdmin *bool, inBulk bool) error {
+func userUpdateAction(rpcSrvURLStr string, username string, password *string, ipAddr *net.IP, isStatic *bool, noGW *bool, isAdmin *bool, inBulk bool, push userPushParams, validity userValidityParams, email userEmailParams, resetTOTP bool) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
 	if err != nil {
@@ -262,6 +443,32 @@ func userUpdateAction(rpcSrvURLStr string, username string, password *string, ip
 			StaticPref: targetStaticPref,
 			HostId:     targetHostid,
 			AdminPref:  targetAdminPref,
+
+			Dns:                  push.dns,
+			ResetDns:             push.resetDNS,
+			Routes:               push.routes,
+			ResetRoutes:          push.resetRoutes,
+			RedirectGateway:      push.redirectGateway,
+			ResetRedirectGateway: push.resetRedirectGateway,
+			Pushes:               push.pushes,
+			ResetPushes:          push.resetPushes,
+			Inactive:             uint32(push.inactive),
+			ResetInactive:        push.resetInactive,
+			IpPool:               push.ipPool,
+			ResetIpPool:          push.resetIPPool,
+
+			ValidFrom:       validity.validFrom,
+			ResetValidFrom:  validity.resetValidFrom,
+			ValidUntil:      validity.validUntil,
+			ResetValidUntil: validity.resetValidUntil,
+
+			CertLifetime:      uint64(validity.certLifetime / time.Second),
+			ResetCertLifetime: validity.resetCertLifetime,
+
+			Email:      email.email,
+			ResetEmail: email.resetEmail,
+
+			ResetTotp: resetTOTP,
 		})
 		if err != nil {
 			err := errors.UnknownGRPCError(err)
@@ -336,6 +543,59 @@ func userRenewAction(rpcSrvURLStr string, username string) error {
 	return nil
 }
 
+// userSetDisabledAction suspends or resumes a VPN user, or all of them in bulk.
+func userSetDisabledAction(rpcSrvURLStr string, username string, inBulk bool, disable bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	userNames := []string{username}
+	if inBulk {
+		userListResp, err := userSvc.List(context.Background(), &pb.UserListRequest{})
+		if err != nil {
+			err := errors.UnknownGRPCError(err)
+			exit(1)
+			return err
+		}
+		userNames = nil
+		for _, u := range userListResp.Users {
+			userNames = append(userNames, u.Username)
+		}
+	}
+
+	for _, userName := range userNames {
+		if disable {
+			_, err = userSvc.Disable(context.Background(), &pb.UserDisableRequest{Username: userName})
+		} else {
+			_, err = userSvc.Enable(context.Background(), &pb.UserEnableRequest{Username: userName})
+		}
+		if err != nil {
+			err := errors.UnknownGRPCError(err)
+			exit(1)
+			return err
+		}
+		if disable {
+			logrus.Infof("user disabled: %s", userName)
+		} else {
+			logrus.Infof("user enabled: %s", userName)
+		}
+	}
+	return nil
+}
+
 // userGenconfigAction generates ovpn configs for a VPN user.
 func userGenconfigAction(rpcSrvURLStr string, username string, outPath *string) error {
 	// Parse RPC Server's URL.
@@ -381,3 +641,43 @@ func userGenconfigAction(rpcSrvURLStr string, username string, outPath *string)
 	logrus.Infof("exported to %s", *outPath)
 	return nil
 }
+
+func userProfileLinkAction(rpcSrvURLStr string, username string, ttl time.Duration, passcode bool) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcSrvURLStr)
+	if err != nil {
+		return errors.BadURL(rpcSrvURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare a service caller.
+	var userSvc = pb.NewUserServiceClient(rpcConn)
+
+	// Send a user create profile link request to the server.
+	linkResp, err := userSvc.CreateProfileLink(context.Background(), &pb.UserProfileLinkRequest{
+		Username: username,
+		Ttl:      uint64(ttl / time.Second),
+		Passcode: passcode,
+	})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	logrus.Infof("profile link created: %s (expires at %s)", username, linkResp.ExpiresAt)
+	fmt.Println("The link can be used once, it won't be shown again:")
+	fmt.Println(linkResp.Url)
+	if linkResp.Passcode != "" {
+		fmt.Println("Share the passcode over another channel:")
+		fmt.Println(linkResp.Passcode)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/master312/ovpm/api/pb"
@@ -62,9 +63,31 @@ func vpnStatusAction(rpcServURLStr string) error {
 	table.Append([]string{"Netmask", vpnStatusResp.Mask})
 	table.Append([]string{"Created At", vpnStatusResp.CreatedAt})
 	table.Append([]string{"DNS", vpnStatusResp.Dns})
+	table.Append([]string{"Domain", vpnStatusResp.Domain})
+	table.Append([]string{"Search Domains", strings.Join(vpnStatusResp.SearchDomains, ", ")})
+	table.Append([]string{"NTP", strings.Join(vpnStatusResp.Ntp, ", ")})
+	table.Append([]string{"WINS", strings.Join(vpnStatusResp.Wins, ", ")})
 	table.Append([]string{"Cert Exp", vpnStatusResp.ExpiresAt})
 	table.Append([]string{"CA Cert Exp", vpnStatusResp.CaExpiresAt})
 	table.Append([]string{"Use LZO", fmt.Sprintf("%t", vpnStatusResp.UseLzo)})
+	natMode := vpnStatusResp.NatMode
+	if natMode == "snat" {
+		natMode = fmt.Sprintf("snat to %s", vpnStatusResp.SnatTo)
+	}
+	egress := vpnStatusResp.EgressIface
+	if egress == "" {
+		egress = "auto"
+	}
+	table.Append([]string{"NAT Mode", natMode})
+	table.Append([]string{"Egress", egress})
+	switch {
+	case vpnStatusResp.NatEnabled:
+		table.Append([]string{"NAT", "enabled"})
+	case vpnStatusResp.NatError != "":
+		table.Append([]string{"NAT", fmt.Sprintf("failing after %d attempts: %s", vpnStatusResp.NatAttempts, vpnStatusResp.NatError)})
+	default:
+		table.Append([]string{"NAT", "disabled"})
+	}
 
 	table.Render()
 
@@ -119,7 +142,54 @@ func vpnInitAction(params vpnInitParams) error {
 	return nil
 }
 
-func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, useLzo *bool) error {
+// vpnNATParams are the nat settings to update.
+type vpnNATParams struct {
+	mode        string
+	snatTo      string
+	egressIface string
+	networkNATs []*pb.VPNNetworkNAT
+}
+
+// vpnDHCPParams are the dhcp options to update.
+type vpnDHCPParams struct {
+	domain             string
+	resetDomain        bool
+	searchDomains      []string
+	resetSearchDomains bool
+	ntp                []string
+	resetNTP           bool
+	wins               []string
+	resetWINS          bool
+}
+
+// validate returns an error if the dhcp options are invalid or conflicting.
+func (p vpnDHCPParams) validate() error {
+	if p.domain != "" && p.resetDomain {
+		return errors.ConflictingDemands("--domain and --reset-domain options are mutually exclusive (can not be used together)")
+	}
+	if len(p.searchDomains) > 0 && p.resetSearchDomains {
+		return errors.ConflictingDemands("--search-domain and --reset-search-domain options are mutually exclusive (can not be used together)")
+	}
+	if len(p.ntp) > 0 && p.resetNTP {
+		return errors.ConflictingDemands("--ntp and --reset-ntp options are mutually exclusive (can not be used together)")
+	}
+	if len(p.wins) > 0 && p.resetWINS {
+		return errors.ConflictingDemands("--wins and --reset-wins options are mutually exclusive (can not be used together)")
+	}
+	for _, domain := range append([]string{p.domain}, p.searchDomains...) {
+		if domain != "" && !govalidator.IsDNSName(domain) {
+			return fmt.Errorf("%s is not a domain name", domain)
+		}
+	}
+	for _, ip := range append(append([]string{}, p.ntp...), p.wins...) {
+		if !govalidator.IsIPv4(ip) {
+			return errors.NotIPv4(ip)
+		}
+	}
+	return nil
+}
+
+func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, useLzo *bool, nat vpnNATParams, dhcp vpnDHCPParams) error {
 	// Parse RPC Server's URL.
 	rpcSrvURL, err := url.Parse(rpcServURLStr)
 	if err != nil {
@@ -167,8 +237,10 @@ func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, use
 	// Set DNS address if provided.
 	var targetDNSAddr string
 	if dnsAddr != nil {
-		if !govalidator.IsIPv4(*dnsAddr) {
-			return errors.NotIPv4(*dnsAddr)
+		for _, ip := range strings.Split(*dnsAddr, ",") {
+			if !govalidator.IsIPv4(strings.TrimSpace(ip)) {
+				return errors.NotIPv4(ip)
+			}
 		}
 		targetDNSAddr = *dnsAddr
 	}
@@ -191,9 +263,22 @@ func vpnUpdateAction(rpcServURLStr string, netCIDR *string, dnsAddr *string, use
 
 	// Request update request from vpn service.
 	_, err = vpnSvc.Update(context.Background(), &pb.VPNUpdateRequest{
-		IpBlock: targetNetCIDR,
-		Dns:     targetDNSAddr,
-		LzoPref: targetLZOPref,
+		IpBlock:     targetNetCIDR,
+		Dns:         targetDNSAddr,
+		LzoPref:     targetLZOPref,
+		NatMode:     nat.mode,
+		SnatTo:      nat.snatTo,
+		EgressIface: nat.egressIface,
+		NetworkNats: nat.networkNATs,
+
+		Domain:             dhcp.domain,
+		ResetDomain:        dhcp.resetDomain,
+		SearchDomains:      dhcp.searchDomains,
+		ResetSearchDomains: dhcp.resetSearchDomains,
+		Ntp:                dhcp.ntp,
+		ResetNtp:           dhcp.resetNTP,
+		Wins:               dhcp.wins,
+		ResetWins:          dhcp.resetWINS,
 	})
 	if err != nil {
 		err := errors.UnknownGRPCError(err)
@@ -240,3 +325,149 @@ func vpnRestartAction(rpcServURLStr string) error {
 	logrus.Info("ovpm server restarted")
 	return nil
 }
+
+func vpnFirewallShowAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.FirewallShow(context.Background(), &pb.VPNFirewallShowRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+
+	fmt.Printf("backend: %s\n\n%s", res.Backend, res.Dump)
+	return nil
+}
+
+// vpnIPPoolListAction lists the ip pools and their usage on the terminal.
+func vpnIPPoolListAction(rpcServURLStr string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.IPPoolList(context.Background(), &pb.VPNIPPoolListRequest{})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	printIPPools(res.Pools)
+	return nil
+}
+
+// vpnIPPoolCreateAction creates an ip pool.
+func vpnIPPoolCreateAction(rpcServURLStr string, req *pb.VPNIPPoolCreateRequest) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.IPPoolCreate(context.Background(), req)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("ip pool created: %s", req.Name)
+	printIPPools(res.Pools)
+	return nil
+}
+
+// vpnIPPoolDeleteAction deletes an ip pool.
+func vpnIPPoolDeleteAction(rpcServURLStr, name string) error {
+	// Parse RPC Server's URL.
+	rpcSrvURL, err := url.Parse(rpcServURLStr)
+	if err != nil {
+		return errors.BadURL(rpcServURLStr, err)
+	}
+
+	// Create a gRPC connection to the server.
+	rpcConn, err := grpcConnect(rpcSrvURL)
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	defer rpcConn.Close()
+
+	// Prepare service caller.
+	var vpnSvc = pb.NewVPNServiceClient(rpcConn)
+
+	res, err := vpnSvc.IPPoolDelete(context.Background(), &pb.VPNIPPoolDeleteRequest{Name: name})
+	if err != nil {
+		err := errors.UnknownGRPCError(err)
+		exit(1)
+		return err
+	}
+	logrus.Infof("ip pool deleted: %s", name)
+	printIPPools(res.Pools)
+	return nil
+}
+
+// printIPPools prints the ip pools and their usage as a table.
+func printIPPools(pools []*pb.VPNIPPool) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "name", "range", "subnet", "used", "free", "usage", "groups", "users"})
+	for i, pool := range pools {
+		usage := "0%"
+		if pool.Size > 0 {
+			usage = fmt.Sprintf("%d%%", pool.Used*100/pool.Size)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%v", i+1),
+			pool.Name,
+			fmt.Sprintf("%s-%s", pool.Start, pool.End),
+			pool.Subnet,
+			fmt.Sprintf("%d", pool.Used),
+			fmt.Sprintf("%d", pool.Size-pool.Used),
+			usage,
+			strings.Join(pool.Groups, ", "),
+			strings.Join(pool.Users, ", "),
+		})
+	}
+	table.Render()
+}
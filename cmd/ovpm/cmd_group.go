@@ -0,0 +1,402 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
+	"github.com/master312/ovpm/api/pb"
+	"github.com/master312/ovpm/errors"
+	"github.com/urfave/cli"
+)
+
+var groupListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List user groups.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		action = "group:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var groupCreateCmd = cli.Command{
+	Name:      "create",
+	Usage:     "Create a user group.",
+	UsageText: "ovpm group create --name engineering --description \"engineering team\"",
+	Aliases:   []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "description, d",
+			Usage: "description of the group",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:create"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("description"))
+	},
+}
+
+var groupUpdateCmd = cli.Command{
+	Name:      "update",
+	Usage:     "Update a user group and the defaults of its members.",
+	UsageText: "ovpm group update --name engineering --no-gw --dns 10.0.0.53 --push \"route 10.10.0.0 255.255.0.0\"",
+	Aliases:   []string{"u"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "description, d",
+			Usage: "description of the group",
+		},
+		cli.BoolFlag{
+			Name:  "no-gw",
+			Usage: "don't push vpn server as default gateway for the members",
+		},
+		cli.BoolFlag{
+			Name:  "gw",
+			Usage: "push vpn server as default gateway for the members, unless they have no-gw",
+		},
+		cli.StringSliceFlag{
+			Name:  "dns",
+			Usage: "dns server to push to the members, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-dns",
+			Usage: "push the server's dns servers to the members",
+		},
+		cli.StringSliceFlag{
+			Name:  "push",
+			Usage: "extra option to push to the members, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-push",
+			Usage: "don't push any extra options to the members",
+		},
+		cli.StringFlag{
+			Name:  "ip-pool",
+			Usage: "ip pool to lease the members' addresses from",
+		},
+		cli.BoolFlag{
+			Name:  "reset-ip-pool",
+			Usage: "lease the members' addresses from the general ip pools",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:update"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// Set gwPref if it's provided.
+		gwPref := pb.GroupUpdateRequest_NOPREF
+		gwVal, noGWVal := c.Bool("gw"), c.Bool("no-gw")
+		if gwVal && noGWVal {
+			err := errors.ConflictingDemands("--gw and --no-gw options are mutually exclusive (can not be used together)")
+			exit(1)
+			return err
+		}
+		if gwVal {
+			gwPref = pb.GroupUpdateRequest_GW
+		}
+		if noGWVal {
+			gwPref = pb.GroupUpdateRequest_NOGW
+		}
+
+		// Validate dns servers.
+		if len(c.StringSlice("dns")) > 0 && c.Bool("reset-dns") {
+			err := errors.ConflictingDemands("--dns and --reset-dns options are mutually exclusive (can not be used together)")
+			exit(1)
+			return err
+		}
+		for _, dns := range c.StringSlice("dns") {
+			if !govalidator.IsIPv4(dns) {
+				return errors.NotIPv4(dns)
+			}
+		}
+		if len(c.StringSlice("push")) > 0 && c.Bool("reset-push") {
+			err := errors.ConflictingDemands("--push and --reset-push options are mutually exclusive (can not be used together)")
+			exit(1)
+			return err
+		}
+		if c.String("ip-pool") != "" && c.Bool("reset-ip-pool") {
+			err := errors.ConflictingDemands("--ip-pool and --reset-ip-pool options are mutually exclusive (can not be used together)")
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		req := pb.GroupUpdateRequest{
+			Name:        c.String("name"),
+			Description: c.String("description"),
+			Gwpref:      gwPref,
+			Dns:         c.StringSlice("dns"),
+			ResetDns:    c.Bool("reset-dns"),
+			Pushes:      c.StringSlice("push"),
+			ResetPushes: c.Bool("reset-push"),
+			IpPool:      c.String("ip-pool"),
+			ResetIpPool: c.Bool("reset-ip-pool"),
+		}
+		return groupUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), &req)
+	},
+}
+
+var groupDeleteCmd = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete a user group.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:delete"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"))
+	},
+}
+
+var groupAddMemberCmd = cli.Command{
+	Name:    "add-member",
+	Usage:   "Add a user to a group.",
+	Aliases: []string{"am"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:add-member"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and username.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupMemberAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("user"), false)
+	},
+}
+
+var groupRemoveMemberCmd = cli.Command{
+	Name:    "remove-member",
+	Usage:   "Remove a user from a group.",
+	Aliases: []string{"rm"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:remove-member"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and username.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupMemberAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("user"), true)
+	},
+}
+
+var groupAssociateCmd = cli.Command{
+	Name:    "associate",
+	Usage:   "Allow the members of a group access to a network.",
+	Aliases: []string{"a"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "net",
+			Usage: "name of the network",
+		},
+		cli.StringSliceFlag{
+			Name:  "rule, r",
+			Usage: "restrict the access to a protocol and ports, e.g. tcp/22,443 (can be repeated)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:associate"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and network name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			return errors.EmptyValue("net", netName)
+		}
+
+		if _, err := ovpm.ParseACLRules(c.StringSlice("rule")); err != nil {
+			return errors.UnknownApplicationError(err)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupAssociateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("net"), c.StringSlice("rule"), false)
+	},
+}
+
+var groupDissociateCmd = cli.Command{
+	Name:    "dissociate",
+	Usage:   "Take the access of the members of a group to a network.",
+	Aliases: []string{"di"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the group",
+		},
+		cli.StringFlag{
+			Name:  "net",
+			Usage: "name of the network",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "group:dissociate"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and network name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			return errors.EmptyValue("net", netName)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return groupAssociateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("net"), nil, true)
+	},
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		cli.Command{
+			Name:    "group",
+			Usage:   "Group Operations",
+			Aliases: []string{"g"},
+			Subcommands: []cli.Command{
+				groupListCmd,
+				groupCreateCmd,
+				groupUpdateCmd,
+				groupDeleteCmd,
+				groupAddMemberCmd,
+				groupRemoveMemberCmd,
+				groupAssociateCmd,
+				groupDissociateCmd,
+			},
+		},
+	)
+}
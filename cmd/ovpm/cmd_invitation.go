@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
+	"github.com/master312/ovpm/errors"
+	"github.com/urfave/cli"
+)
+
+var invitationListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List the invitations of the pending users.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		action = "invitation:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return invitationListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var invitationRevokeCmd = cli.Command{
+	Name:    "revoke",
+	Usage:   "Revoke an invitation and delete its pending user.",
+	Aliases: []string{"r"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the pending user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "invitation:revoke"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		username := c.String("user")
+		if govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return invitationRevokeAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), username)
+	},
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		cli.Command{
+			Name:    "invitation",
+			Usage:   "User Invitation Operations",
+			Aliases: []string{"inv"},
+			Subcommands: []cli.Command{
+				invitationListCmd,
+				invitationRevokeCmd,
+			},
+		},
+	)
+}
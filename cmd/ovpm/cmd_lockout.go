@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
+	"github.com/master312/ovpm/errors"
+	"github.com/urfave/cli"
+)
+
+var lockoutListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List the usernames and the source ips that are locked out.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		action = "lockout:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return lockoutListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var lockoutClearCmd = cli.Command{
+	Name:    "clear",
+	Usage:   "Lift a lockout.",
+	Aliases: []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "locked out username",
+		},
+		cli.StringFlag{
+			Name:  "ip",
+			Usage: "locked out source ip",
+		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "lift all of the lockouts",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "lockout:clear"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Exactly one of the --user, --ip and --all flags is expected.
+		var kind, key string
+		var given int
+		if username := c.String("user"); username != "" {
+			kind, key = ovpm.LockoutKindUser, username
+			given++
+		}
+		if ip := c.String("ip"); ip != "" {
+			if !govalidator.IsIP(ip) {
+				return errors.UnknownApplicationError(fmt.Errorf("`%s` is not a valid ip", ip))
+			}
+			kind, key = ovpm.LockoutKindIP, ip
+			given++
+		}
+		if c.Bool("all") {
+			given++
+		}
+		if given == 0 {
+			return errors.EmptyValue("user, ip or all", "")
+		}
+		if given > 1 {
+			return errors.ConflictingDemands("--user, --ip and --all flags are mutually exclusive")
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return lockoutClearAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), kind, key, c.Bool("all"))
+	},
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		cli.Command{
+			Name:    "lockout",
+			Usage:   "Brute-force Lockout Operations",
+			Aliases: []string{"lo"},
+			Subcommands: []cli.Command{
+				lockoutListCmd,
+				lockoutClearCmd,
+			},
+		},
+	)
+}
@@ -30,6 +30,10 @@ var netDefineCommand = cli.Command{
 			Name:  "via, v",
 			Usage: "if network type is route, via represents route's gateway",
 		},
+		cli.StringFlag{
+			Name:  "owner, o",
+			Usage: "if network type is clientnet, owner is the user that the network is behind",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "net:create"
@@ -63,6 +67,13 @@ var netDefineCommand = cli.Command{
 			}
 		}
 
+		// Validate if owner can be set, and is set when needed.
+		if isClientNet := ovpm.NetworkTypeFromString(c.String("type")) == ovpm.CLIENTNET; isClientNet != !govalidator.IsNull(c.String("owner")) {
+			err := errors.ConflictingDemands("--owner flag is required for and can only be used with --type CLIENTNET")
+			exit(1)
+			return err
+		}
+
 		// Validate network CIDR.
 		if netCIDR := c.String("cidr"); !govalidator.IsCIDR(netCIDR) {
 			err := errors.NotCIDR(netCIDR)
@@ -81,7 +92,72 @@ var netDefineCommand = cli.Command{
 			return nil
 		}
 
-		return netDefAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("cidr"), c.String("type"), via)
+		return netDefAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("cidr"), c.String("type"), via, c.String("owner"))
+	},
+}
+
+var netUpdateCommand = cli.Command{
+	Name:      "update",
+	Aliases:   []string{"up"},
+	Usage:     "Update a network, keeping its associations.",
+	UsageText: "ovpm net update --net office --name hq --cidr 10.10.0.0/16 --via vpn-server",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "net, n",
+			Usage: "name of the network",
+		},
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "new name of the network",
+		},
+		cli.StringFlag{
+			Name:  "cidr, c",
+			Usage: "new CIDR of the network",
+		},
+		cli.StringFlag{
+			Name:  "via, v",
+			Usage: "if network type is route, new gateway of the route (vpn-server to route via the vpn server)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "net:update"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate network name.
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			err := errors.EmptyValue("net", netName)
+			exit(1)
+			return err
+		}
+
+		// Validate the changes.
+		if c.String("name") == "" && c.String("cidr") == "" && c.String("via") == "" {
+			err := errors.EmptyValue("name, cidr or via", "")
+			exit(1)
+			return err
+		}
+		if netCIDR := c.String("cidr"); netCIDR != "" && !govalidator.IsCIDR(netCIDR) {
+			err := errors.NotCIDR(netCIDR)
+			exit(1)
+			return err
+		}
+		if netVia := c.String("via"); netVia != "" && netVia != "vpn-server" && !govalidator.IsIPv4(netVia) {
+			err := errors.NotIPv4(netVia)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return netUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("name"), c.String("cidr"), c.String("via"))
 	},
 }
 
@@ -177,6 +253,11 @@ var netAssociateCommand = cli.Command{
 			Name:  "user, u",
 			Usage: "name of the user",
 		},
+
+		cli.StringSliceFlag{
+			Name:  "rule, r",
+			Usage: "restrict the access to a protocol and ports, e.g. tcp/22,443 (can be repeated)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "net:associate"
@@ -201,6 +282,12 @@ var netAssociateCommand = cli.Command{
 			return err
 		}
 
+		// Validate rules.
+		if _, err := ovpm.ParseACLRules(c.StringSlice("rule")); err != nil {
+			exit(1)
+			return errors.UnknownApplicationError(err)
+		}
+
 		// Mark inBulk if username is set to asterisk.
 		if c.String("user") == "*" {
 			inBulk = true
@@ -211,7 +298,7 @@ var netAssociateCommand = cli.Command{
 			return nil
 		}
 
-		return netAssocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), inBulk)
+		return netAssocAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), c.StringSlice("rule"), inBulk)
 	},
 }
 
@@ -267,6 +354,230 @@ var netDissociateCommand = cli.Command{
 	},
 }
 
+var netRulesCommand = cli.Command{
+	Name:      "rules",
+	Aliases:   []string{"r"},
+	Usage:     "Restrict the access of an associated user or group to a network.",
+	UsageText: "ovpm net rules --net office --user alice --rule tcp/22,443 --rule icmp",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "net, n",
+			Usage: "name of the network",
+		},
+
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "name of the user",
+		},
+
+		cli.StringFlag{
+			Name:  "group, g",
+			Usage: "name of the group",
+		},
+
+		cli.StringSliceFlag{
+			Name:  "rule, r",
+			Usage: "allowed protocol and ports, e.g. tcp/22,443 (can be repeated; lifts the restrictions if omitted)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "net:rules"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate network name, user or group and rules.
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			err := errors.EmptyValue("network", netName)
+			exit(1)
+			return err
+		}
+		if c.String("user") == "" && c.String("group") == "" {
+			err := errors.EmptyValue("user or group", "")
+			exit(1)
+			return err
+		}
+		if c.String("user") != "" && c.String("group") != "" {
+			err := errors.ConflictingDemands("--user and --group options are mutually exclusive (can not be used together)")
+			exit(1)
+			return err
+		}
+		if _, err := ovpm.ParseACLRules(c.StringSlice("rule")); err != nil {
+			exit(1)
+			return errors.UnknownApplicationError(err)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return netRulesAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), c.String("user"), c.String("group"), c.StringSlice("rule"))
+	},
+}
+
+var netMapCommand = cli.Command{
+	Name:      "map",
+	Aliases:   []string{"m"},
+	Usage:     "Map a network 1:1 to a virtual network, e.g. to tell apart the sites with the same addresses.",
+	UsageText: "ovpm net map --net site_a --to 10.200.1.0/24",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "net, n",
+			Usage: "name of the network",
+		},
+
+		cli.StringFlag{
+			Name:  "to, t",
+			Usage: "virtual network in CIDR form that the clients reach the network at",
+		},
+
+		cli.BoolFlag{
+			Name:  "remove, r",
+			Usage: "remove the mapping",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "net:map"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate network name and the virtual network.
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			err := errors.EmptyValue("network", netName)
+			exit(1)
+			return err
+		}
+		mappedCIDR := c.String("to")
+		if c.Bool("remove") {
+			if mappedCIDR != "" {
+				err := errors.ConflictingDemands("--to and --remove options are mutually exclusive (can not be used together)")
+				exit(1)
+				return err
+			}
+		} else {
+			if govalidator.IsNull(mappedCIDR) {
+				err := errors.EmptyValue("to", mappedCIDR)
+				exit(1)
+				return err
+			}
+			if !govalidator.IsCIDR(mappedCIDR) {
+				err := errors.NotCIDR(mappedCIDR)
+				exit(1)
+				return err
+			}
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return netMapAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), mappedCIDR)
+	},
+}
+
+var netDNSCommand = cli.Command{
+	Name:      "dns",
+	Aliases:   []string{"ns"},
+	Usage:     "Resolve the domain of a network with its own DNS servers for the associated users (split DNS).",
+	UsageText: "ovpm net dns --net corp --domain corp.example.com --server 10.10.0.53",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "net, n",
+			Usage: "name of the network",
+		},
+
+		cli.StringFlag{
+			Name:  "domain, d",
+			Usage: "domain of the network",
+		},
+
+		cli.StringSliceFlag{
+			Name:  "server, s",
+			Usage: "DNS server that resolves the domain (can be repeated)",
+		},
+
+		cli.BoolFlag{
+			Name:  "remove, r",
+			Usage: "remove the split DNS of the network",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "net:dns"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate network name, the domain and the servers.
+		if netName := c.String("net"); govalidator.IsNull(netName) {
+			err := errors.EmptyValue("network", netName)
+			exit(1)
+			return err
+		}
+		domain, servers := c.String("domain"), c.StringSlice("server")
+		if c.Bool("remove") {
+			if domain != "" || len(servers) > 0 {
+				err := errors.ConflictingDemands("--domain and --server options can not be used with --remove")
+				exit(1)
+				return err
+			}
+		} else {
+			if !govalidator.IsDNSName(domain) {
+				err := errors.EmptyValue("domain", domain)
+				exit(1)
+				return err
+			}
+			if len(servers) == 0 {
+				err := errors.EmptyValue("server", "")
+				exit(1)
+				return err
+			}
+			for _, server := range servers {
+				if !govalidator.IsIPv4(server) {
+					err := errors.NotIPv4(server)
+					exit(1)
+					return err
+				}
+			}
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return netDNSAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("net"), domain, servers)
+	},
+}
+
+var netCheckCommand = cli.Command{
+	Name:    "check",
+	Aliases: []string{"c"},
+	Usage:   "Audit the addresses of the vpn network, the networks and the interfaces of the server for conflicts.",
+	Action: func(c *cli.Context) error {
+		action = "net:check"
+
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		return netCheckAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
 func init() {
 	app.Commands = append(app.Commands,
 		cli.Command{
@@ -278,8 +589,13 @@ func init() {
 				netTypesCommand,
 				netDefineCommand,
 				netUndefineCommand,
+				netUpdateCommand,
 				netAssociateCommand,
 				netDissociateCommand,
+				netRulesCommand,
+				netMapCommand,
+				netDNSCommand,
+				netCheckCommand,
 			},
 		},
 	)
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
+	"github.com/master312/ovpm/errors"
+	"github.com/urfave/cli"
+)
+
+var roleListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List roles.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		action = "role:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var rolePermsCmd = cli.Command{
+	Name:    "perms",
+	Usage:   "List the permissions that can be given to roles.",
+	Aliases: []string{"p"},
+	Action: func(c *cli.Context) error {
+		action = "role:perms"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return rolePermsAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var roleCreateCmd = cli.Command{
+	Name:      "create",
+	Usage:     "Create a custom role.",
+	UsageText: "ovpm role create --name helpdesk --perm user:renew-any --perm user:genconfig-any",
+	Aliases:   []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the role",
+		},
+		cli.StringFlag{
+			Name:  "description, d",
+			Usage: "description of the role",
+		},
+		cli.StringSliceFlag{
+			Name:  "perm, p",
+			Usage: "permission of the role, e.g. user:renew-any (can be repeated)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "role:create"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and permissions.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if len(c.StringSlice("perm")) == 0 {
+			return errors.EmptyValue("perm", "")
+		}
+		if _, err := ovpm.PermsFromNames(c.StringSlice("perm")); err != nil {
+			return errors.UnknownApplicationError(err)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("description"), c.StringSlice("perm"))
+	},
+}
+
+var roleUpdateCmd = cli.Command{
+	Name:    "update",
+	Usage:   "Update a custom role.",
+	Aliases: []string{"u"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the role",
+		},
+		cli.StringFlag{
+			Name:  "description, d",
+			Usage: "description of the role",
+		},
+		cli.StringSliceFlag{
+			Name:  "perm, p",
+			Usage: "permission of the role, replacing the current ones (can be repeated)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "role:update"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and permissions.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if _, err := ovpm.PermsFromNames(c.StringSlice("perm")); err != nil {
+			return errors.UnknownApplicationError(err)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("description"), c.StringSlice("perm"))
+	},
+}
+
+var roleDeleteCmd = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete a custom role.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the role",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "role:delete"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"))
+	},
+}
+
+var roleAssignCmd = cli.Command{
+	Name:    "assign",
+	Usage:   "Assign a role to a user.",
+	Aliases: []string{"a"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the role",
+		},
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user or the service account",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "role:assign"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and username.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleAssignAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("user"), false)
+	},
+}
+
+var roleUnassignCmd = cli.Command{
+	Name:    "unassign",
+	Usage:   "Take a role from a user.",
+	Aliases: []string{"ua"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the role",
+		},
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user or the service account",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "role:unassign"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name and username.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return roleAssignAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("user"), true)
+	},
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		cli.Command{
+			Name:    "role",
+			Usage:   "Role Operations",
+			Aliases: []string{"r"},
+			Subcommands: []cli.Command{
+				roleListCmd,
+				rolePermsCmd,
+				roleCreateCmd,
+				roleUpdateCmd,
+				roleDeleteCmd,
+				roleAssignCmd,
+				roleUnassignCmd,
+			},
+		},
+	)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm"
+	"github.com/master312/ovpm/errors"
+	"github.com/urfave/cli"
+)
+
+var tokenCreateCmd = cli.Command{
+	Name:    "create",
+	Usage:   "Create an API token.",
+	Aliases: []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user or the service account",
+		},
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the token",
+		},
+		cli.DurationFlag{
+			Name:  "expires-in, e",
+			Usage: "lifetime of the token, e.g. 720h (never expires if not set)",
+		},
+		cli.StringSliceFlag{
+			Name:  "scope, s",
+			Usage: "restrict the token to a permission, e.g. vpn:status (can be repeated)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "token:create"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username and token name.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+		if ttl := c.Duration("expires-in"); ttl < 0 {
+			return errors.ConflictingDemands("--expires-in can not be negative")
+		}
+		for _, scope := range c.StringSlice("scope") {
+			if _, err := ovpm.PermFromName(scope); err != nil {
+				return errors.UnknownApplicationError(err)
+			}
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return tokenCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"), c.String("name"), c.Duration("expires-in"), c.StringSlice("scope"))
+	},
+}
+
+var tokenListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List API tokens.",
+	Aliases: []string{"l"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user or the service account",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "token:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return tokenListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"))
+	},
+}
+
+var tokenRevokeCmd = cli.Command{
+	Name:    "revoke",
+	Usage:   "Revoke an API token.",
+	Aliases: []string{"r"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the user or the service account",
+		},
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the token",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "token:revoke"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username and token name.
+		if username := c.String("user"); govalidator.IsNull(username) {
+			return errors.EmptyValue("username", username)
+		}
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return tokenRevokeAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("user"), c.String("name"))
+	},
+}
+
+var serviceAccountCreateCmd = cli.Command{
+	Name:    "create",
+	Usage:   "Create a service account.",
+	Aliases: []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the service account",
+		},
+		cli.StringFlag{
+			Name:  "description, d",
+			Usage: "description of the service account",
+		},
+		cli.BoolFlag{
+			Name:  "admin",
+			Usage: "give the service account admin rights",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "service-account:create"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return serviceAccountCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"), c.String("description"), c.Bool("admin"))
+	},
+}
+
+var serviceAccountListCmd = cli.Command{
+	Name:    "list",
+	Usage:   "List service accounts.",
+	Aliases: []string{"l"},
+	Action: func(c *cli.Context) error {
+		action = "service-account:list"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return serviceAccountListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var serviceAccountDeleteCmd = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete a service account along with its tokens.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the service account",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "service-account:delete"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate name.
+		if name := c.String("name"); govalidator.IsNull(name) {
+			return errors.EmptyValue("name", name)
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return serviceAccountDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), c.String("name"))
+	},
+}
+
+// formatTokenTime formats the token timestamps for the token list.
+func formatTokenTime(t string, zero string) string {
+	if t == "" {
+		return zero
+	}
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return t
+	}
+	return parsed.Local().Format("2006-01-02 15:04")
+}
+
+func init() {
+	app.Commands = append(app.Commands,
+		cli.Command{
+			Name:    "token",
+			Usage:   "API Token Operations",
+			Aliases: []string{"t"},
+			Subcommands: []cli.Command{
+				tokenListCmd,
+				tokenCreateCmd,
+				tokenRevokeCmd,
+			},
+		},
+		cli.Command{
+			Name:    "service-account",
+			Usage:   "Service Account Operations",
+			Aliases: []string{"sa"},
+			Subcommands: []cli.Command{
+				serviceAccountListCmd,
+				serviceAccountCreateCmd,
+				serviceAccountDeleteCmd,
+			},
+		},
+	)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/master312/ovpm"
@@ -45,7 +46,7 @@ var userCreateCmd = cli.Command{
 		},
 		cli.StringFlag{
 			Name:  "password, p",
-			Usage: "password for the vpn user (required)",
+			Usage: "password for the vpn user (required, unless the user is invited)",
 		},
 		cli.BoolFlag{
 			Name:  "no-gw",
@@ -59,6 +60,26 @@ var userCreateCmd = cli.Command{
 			Name:  "admin, a",
 			Usage: "this user has admin rights",
 		},
+		cli.StringFlag{
+			Name:  "valid-from",
+			Usage: "time that the user can connect and log in from, e.g. 2006-01-02 or 2006-01-02T15:04:05Z",
+		},
+		cli.StringFlag{
+			Name:  "valid-until",
+			Usage: "time that the user expires at, e.g. 2006-01-02 or 2006-01-02T15:04:05Z",
+		},
+		cli.StringFlag{
+			Name:  "email",
+			Usage: "email address of the vpn user, the welcome message is sent to it",
+		},
+		cli.BoolFlag{
+			Name:  "invite",
+			Usage: "create a pending user and print a link for the user to set its own password, it's also emailed to the user",
+		},
+		cli.DurationFlag{
+			Name:  "invite-ttl",
+			Usage: fmt.Sprintf("time that the invitation expires after (default: %s)", ovpm.DefaultInvitationTTL),
+		},
 	},
 	// userCreate action has two modes. Bulk mode
 	Action: func(c *cli.Context) error {
@@ -74,8 +95,12 @@ var userCreateCmd = cli.Command{
 		if username := c.String("username"); govalidator.IsNull(username) {
 			return errors.EmptyValue("username", username)
 		}
-		if password := c.String("password"); govalidator.IsNull(password) {
-			return errors.EmptyValue("password", password)
+		invite := userInviteParams{
+			invite: c.Bool("invite"),
+			ttl:    c.Duration("invite-ttl"),
+		}
+		if err := invite.validate(c.String("password")); err != nil {
+			return err
 		}
 
 		// Static IP addr holder.
@@ -94,6 +119,22 @@ var userCreateCmd = cli.Command{
 			ipAddr = &tmp
 		}
 
+		validity := userValidityParams{
+			validFrom:  c.String("valid-from"),
+			validUntil: c.String("valid-until"),
+		}
+		if err := validity.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+		email := userEmailParams{email: c.String("email")}
+		if err := email.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
@@ -107,6 +148,9 @@ var userCreateCmd = cli.Command{
 			ipAddr,
 			c.Bool("no-gw"),
 			c.Bool("admin"),
+			validity,
+			email.email,
+			invite,
 		)
 	},
 }
@@ -157,6 +201,90 @@ var userUpdateCmd = cli.Command{
 			Name:  "no-admin",
 			Usage: "this user has no admin rights",
 		},
+		cli.StringSliceFlag{
+			Name:  "dns",
+			Usage: "dns server to push to the user, replacing the ones of the groups and the server (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-dns",
+			Usage: "push the dns servers of the groups or the server to the user",
+		},
+		cli.StringSliceFlag{
+			Name:  "route",
+			Usage: "network to route through the vpn for the user, e.g. 10.20.0.0/16 (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-routes",
+			Usage: "don't push any extra routes to the user",
+		},
+		cli.StringFlag{
+			Name:  "redirect-gateway",
+			Usage: "flags of the redirect-gateway option to push to the user, e.g. \"def1 block-local\"",
+		},
+		cli.BoolFlag{
+			Name:  "reset-redirect-gateway",
+			Usage: "push the default redirect-gateway flags to the user",
+		},
+		cli.StringSliceFlag{
+			Name:  "push",
+			Usage: "extra option to push to the user, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-push",
+			Usage: "don't push any extra options to the user",
+		},
+		cli.IntFlag{
+			Name:  "inactive",
+			Usage: "seconds of inactivity after which the user is disconnected",
+		},
+		cli.BoolFlag{
+			Name:  "reset-inactive",
+			Usage: "don't disconnect the user when inactive",
+		},
+		cli.StringFlag{
+			Name:  "ip-pool",
+			Usage: "ip pool to lease the user's address from, instead of the one of the groups",
+		},
+		cli.BoolFlag{
+			Name:  "reset-ip-pool",
+			Usage: "lease the user's address from the ip pool of the groups or the general pools",
+		},
+		cli.StringFlag{
+			Name:  "valid-from",
+			Usage: "time that the user can connect and log in from, e.g. 2006-01-02 or 2006-01-02T15:04:05Z",
+		},
+		cli.BoolFlag{
+			Name:  "reset-valid-from",
+			Usage: "let the user connect and log in without waiting",
+		},
+		cli.StringFlag{
+			Name:  "valid-until",
+			Usage: "time that the user expires at, e.g. 2006-01-02 or 2006-01-02T15:04:05Z",
+		},
+		cli.BoolFlag{
+			Name:  "reset-valid-until",
+			Usage: "make the user never expire",
+		},
+		cli.DurationFlag{
+			Name:  "cert-lifetime",
+			Usage: "lifetime of the certs that are issued to the user from now on, e.g. 2160h",
+		},
+		cli.BoolFlag{
+			Name:  "reset-cert-lifetime",
+			Usage: "issue the user certs with the server's default lifetime",
+		},
+		cli.StringFlag{
+			Name:  "email",
+			Usage: "email address that the notifications of the user are sent to",
+		},
+		cli.BoolFlag{
+			Name:  "reset-email",
+			Usage: "remove the email address of the user",
+		},
+		cli.BoolFlag{
+			Name:  "reset-totp",
+			Usage: "unenrol the user from TOTP, e.g. if it lost its authenticator",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "user:update"
@@ -260,6 +388,49 @@ var userUpdateCmd = cli.Command{
 			isAdmin = &tmp
 		}
 
+		push := userPushParams{
+			dns:                  c.StringSlice("dns"),
+			resetDNS:             c.Bool("reset-dns"),
+			routes:               c.StringSlice("route"),
+			resetRoutes:          c.Bool("reset-routes"),
+			redirectGateway:      c.String("redirect-gateway"),
+			resetRedirectGateway: c.Bool("reset-redirect-gateway"),
+			pushes:               c.StringSlice("push"),
+			resetPushes:          c.Bool("reset-push"),
+			inactive:             c.Int("inactive"),
+			resetInactive:        c.Bool("reset-inactive"),
+			ipPool:               c.String("ip-pool"),
+			resetIPPool:          c.Bool("reset-ip-pool"),
+		}
+		if err := push.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+		validity := userValidityParams{
+			validFrom:       c.String("valid-from"),
+			resetValidFrom:  c.Bool("reset-valid-from"),
+			validUntil:      c.String("valid-until"),
+			resetValidUntil: c.Bool("reset-valid-until"),
+
+			certLifetime:      c.Duration("cert-lifetime"),
+			resetCertLifetime: c.Bool("reset-cert-lifetime"),
+		}
+		if err := validity.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+		email := userEmailParams{
+			email:      c.String("email"),
+			resetEmail: c.Bool("reset-email"),
+		}
+		if err := email.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
@@ -273,6 +444,10 @@ var userUpdateCmd = cli.Command{
 			noGW,
 			isAdmin,
 			inBulk,
+			push,
+			validity,
+			email,
+			c.Bool("reset-totp"),
 		)
 	},
 }
@@ -341,6 +516,64 @@ var userRenewCmd = cli.Command{
 	},
 }
 
+// userDisableCmd suspends a user, or all of the users if the username is asterisk (*), without
+// revoking its certificate.
+var userDisableCmd = cli.Command{
+	Name:      "disable",
+	Usage:     "Suspend a VPN user, keeping its certificate, ip and associations.",
+	UsageText: "ovpm user disable --username bob",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "username, u",
+			Usage: "username of the vpn user, or * for all users",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:disable"
+		return userSetDisabled(c, true)
+	},
+}
+
+// userEnableCmd resumes a user that is suspended, or all of the users if the username is asterisk
+// (*).
+var userEnableCmd = cli.Command{
+	Name:      "enable",
+	Usage:     "Resume a suspended VPN user.",
+	UsageText: "ovpm user enable --username bob",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "username, u",
+			Usage: "username of the vpn user, or * for all users",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:enable"
+		return userSetDisabled(c, false)
+	},
+}
+
+func userSetDisabled(c *cli.Context, disable bool) error {
+	// Use default port if no port is specified.
+	daemonPort := ovpm.DefaultDaemonPort
+	if port := c.GlobalInt("daemon-port"); port != 0 {
+		daemonPort = port
+	}
+
+	username := c.String("username")
+	if govalidator.IsNull(username) {
+		err := errors.EmptyValue("username", username)
+		exit(1)
+		return err
+	}
+
+	// If dry run, then don't call the action, just preprocess.
+	if c.GlobalBool("dry-run") {
+		return nil
+	}
+
+	return userSetDisabledAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), username, username == "*", disable)
+}
+
 var userGenconfigCmd = cli.Command{
 	Name:    "genconfig",
 	Usage:   "Generate client config for the user. (.ovpn file)",
@@ -383,6 +616,60 @@ var userGenconfigCmd = cli.Command{
 	},
 }
 
+// userProfileLinkCmd creates a one-time link to download the vpn profile of a user, that expires
+// after a while.
+var userProfileLinkCmd = cli.Command{
+	Name:      "profile-link",
+	Usage:     "Create a one-time, expiring link to download the client config of the user.",
+	UsageText: "ovpm user profile-link --user bob [--ttl 24h] [--passcode]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "username of the vpn user",
+		},
+		cli.DurationFlag{
+			Name:  "ttl",
+			Usage: fmt.Sprintf("time that the link expires after (default: %s)", ovpm.DefaultProfileLinkTTL),
+		},
+		cli.BoolFlag{
+			Name:  "passcode",
+			Usage: "unlock the link with a passcode instead of the user's password",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "user:profile-link"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// Validate username.
+		username := c.String("user")
+		if govalidator.IsNull(username) {
+			err := errors.EmptyValue("username", username)
+			exit(1)
+			return err
+		}
+
+		// Validate ttl.
+		ttl := c.Duration("ttl")
+		if ttl < 0 || ttl%time.Second != 0 {
+			err := fmt.Errorf("--ttl must be positive and in whole seconds: %s", ttl)
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return userProfileLinkAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), username, ttl, c.Bool("passcode"))
+	},
+}
+
 func init() {
 	app.Commands = append(app.Commands,
 		cli.Command{
@@ -395,7 +682,10 @@ func init() {
 				userUpdateCmd,
 				userDeleteCmd,
 				userRenewCmd,
+				userDisableCmd,
+				userEnableCmd,
 				userGenconfigCmd,
+				userProfileLinkCmd,
 			},
 		},
 	)
@@ -2,10 +2,13 @@ package main
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/asaskevich/govalidator"
 	"github.com/master312/ovpm"
 	"github.com/master312/ovpm/api/pb"
 	"github.com/master312/ovpm/errors"
+	"github.com/master312/ovpm/firewall"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 	"go.uber.org/thriftrw/ptr"
@@ -202,7 +205,39 @@ var vpnUpdateCommand = cli.Command{
 		},
 		cli.StringFlag{
 			Name:  "dns, d",
-			Usage: fmt.Sprintf("DNS server to push to clients (default: %s)", ovpm.DefaultVPNDNS),
+			Usage: fmt.Sprintf("comma separated DNS servers to push to clients (default: %s)", ovpm.DefaultVPNDNS),
+		},
+		cli.StringFlag{
+			Name:  "domain",
+			Usage: "DNS domain to push to clients",
+		},
+		cli.BoolFlag{
+			Name:  "reset-domain",
+			Usage: "don't push a DNS domain to clients",
+		},
+		cli.StringSliceFlag{
+			Name:  "search-domain",
+			Usage: "DNS search domain to push to clients, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-search-domain",
+			Usage: "don't push DNS search domains to clients",
+		},
+		cli.StringSliceFlag{
+			Name:  "ntp",
+			Usage: "NTP server to push to clients, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-ntp",
+			Usage: "don't push NTP servers to clients",
+		},
+		cli.StringSliceFlag{
+			Name:  "wins",
+			Usage: "WINS server to push to clients, replacing the current ones (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "reset-wins",
+			Usage: "don't push WINS servers to clients",
 		},
 		cli.BoolFlag{
 			Name:  "enable-use-lzo",
@@ -212,6 +247,22 @@ var vpnUpdateCommand = cli.Command{
 			Name:  "disable-use-lzo",
 			Usage: fmt.Sprintf("Disable use of the deprecated lzo compression algorithm to support older clients."),
 		},
+		cli.StringFlag{
+			Name:  "nat",
+			Usage: "translation of the clients' traffic that goes out of the egress interface: masquerade, snat or none (routed)",
+		},
+		cli.StringFlag{
+			Name:  "snat-to",
+			Usage: "source address to translate the clients' traffic to in the snat mode",
+		},
+		cli.StringFlag{
+			Name:  "egress",
+			Usage: "interface that the clients' traffic goes out of, or auto to detect it from the default route",
+		},
+		cli.StringSliceFlag{
+			Name:  "network-nat",
+			Usage: "override the translation of the clients' traffic to a SERVERNET network, e.g. lan=none, dmz=snat:192.168.5.1 or lan=inherit (can be repeated)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		action = "vpn:update"
@@ -245,15 +296,76 @@ var vpnUpdateCommand = cli.Command{
 			useLzo = ptr.Bool(false)
 		}
 
+		nat := vpnNATParams{mode: c.String("nat"), snatTo: c.String("snat-to"), egressIface: c.String("egress")}
+		if nat.mode != "" && !firewall.IsNATMode(nat.mode) {
+			e := fmt.Errorf("--nat should be one of masquerade, snat or none: %s", nat.mode)
+			fmt.Println(e.Error())
+			exit(1)
+			return e
+		}
+		if nat.snatTo != "" && !govalidator.IsIPv4(nat.snatTo) {
+			e := errors.NotIPv4(nat.snatTo)
+			fmt.Println(e.Error())
+			exit(1)
+			return e
+		}
+		for _, s := range c.StringSlice("network-nat") {
+			networkNAT, err := parseNetworkNAT(s)
+			if err != nil {
+				fmt.Println(err.Error())
+				exit(1)
+				return err
+			}
+			nat.networkNATs = append(nat.networkNATs, networkNAT)
+		}
+
+		dhcp := vpnDHCPParams{
+			domain:             c.String("domain"),
+			resetDomain:        c.Bool("reset-domain"),
+			searchDomains:      c.StringSlice("search-domain"),
+			resetSearchDomains: c.Bool("reset-search-domain"),
+			ntp:                c.StringSlice("ntp"),
+			resetNTP:           c.Bool("reset-ntp"),
+			wins:               c.StringSlice("wins"),
+			resetWINS:          c.Bool("reset-wins"),
+		}
+		if err := dhcp.validate(); err != nil {
+			fmt.Println(err.Error())
+			exit(1)
+			return err
+		}
+
 		// If dry run, then don't call the action, just preprocess.
 		if c.GlobalBool("dry-run") {
 			return nil
 		}
 
-		return vpnUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), netCIDR, dnsAddr, useLzo)
+		return vpnUpdateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), netCIDR, dnsAddr, useLzo, nat, dhcp)
 	},
 }
 
+// parseNetworkNAT parses a network nat override in the NAME=MODE or NAME=snat:ADDR form.
+func parseNetworkNAT(s string) (*pb.VPNNetworkNAT, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("--network-nat should be in the NAME=MODE form: %s", s)
+	}
+	networkNAT := &pb.VPNNetworkNAT{NetworkName: parts[0], NatMode: parts[1]}
+	if strings.HasPrefix(parts[1], "snat:") {
+		networkNAT.NatMode, networkNAT.SnatTo = "snat", strings.TrimPrefix(parts[1], "snat:")
+		if !govalidator.IsIPv4(networkNAT.SnatTo) {
+			return nil, errors.NotIPv4(networkNAT.SnatTo)
+		}
+	}
+	if networkNAT.NatMode != "inherit" && !firewall.IsNATMode(networkNAT.NatMode) {
+		return nil, fmt.Errorf("--network-nat mode should be one of masquerade, snat:ADDR, none or inherit: %s", s)
+	}
+	if networkNAT.NatMode == "snat" && networkNAT.SnatTo == "" {
+		return nil, fmt.Errorf("--network-nat snat mode requires an address, e.g. %s=snat:192.168.5.1", parts[0])
+	}
+	return networkNAT, nil
+}
+
 var vpnRestartCommand = cli.Command{
 	Name:    "restart",
 	Usage:   "Restart VPN server.",
@@ -274,6 +386,171 @@ var vpnRestartCommand = cli.Command{
 	},
 }
 
+var vpnFirewallShowCommand = cli.Command{
+	Name:    "show",
+	Usage:   "Show the firewall rules that are managed by ovpm.",
+	Aliases: []string{"s"},
+	Action: func(c *cli.Context) error {
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnFirewallShowAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+	},
+}
+
+var vpnFirewallCommand = cli.Command{
+	Name:    "firewall",
+	Usage:   "Firewall Operations",
+	Aliases: []string{"f"},
+	Subcommands: []cli.Command{
+		vpnFirewallShowCommand,
+	},
+}
+
+var vpnIPPoolListCommand = cli.Command{
+	Name:    "list",
+	Usage:   "List the ip pools and their usage.",
+	Aliases: []string{"l"},
+	Action:  vpnIPPoolList,
+}
+
+func vpnIPPoolList(c *cli.Context) error {
+	action = "vpn:ip-pool:list"
+	// Use default port if no port is specified.
+	daemonPort := ovpm.DefaultDaemonPort
+	if port := c.GlobalInt("daemon-port"); port != 0 {
+		daemonPort = port
+	}
+
+	// If dry run, then don't call the action, just preprocess.
+	if c.GlobalBool("dry-run") {
+		return nil
+	}
+
+	return vpnIPPoolListAction(fmt.Sprintf("grpc://localhost:%d", daemonPort))
+}
+
+var vpnIPPoolCreateCommand = cli.Command{
+	Name:    "create",
+	Usage:   "Create an ip pool to lease the dynamic addresses from.",
+	Aliases: []string{"c"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the ip pool",
+		},
+		cli.StringFlag{
+			Name:  "start, s",
+			Usage: "first address of the ip pool, e.g. 10.9.0.10",
+		},
+		cli.StringFlag{
+			Name:  "end, e",
+			Usage: "last address of the ip pool, e.g. 10.9.0.99",
+		},
+		cli.StringFlag{
+			Name:  "subnet",
+			Usage: "network beside the vpn network to use as the ip pool instead of a range, e.g. 10.9.8.0/24",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "vpn:ip-pool:create"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		name, start, end, subnet := c.String("name"), c.String("start"), c.String("end"), c.String("subnet")
+		if govalidator.IsNull(name) {
+			err := errors.EmptyValue("name", name)
+			exit(1)
+			return err
+		}
+		if subnet != "" {
+			if start != "" || end != "" {
+				err := errors.ConflictingDemands("--subnet and --start/--end options are mutually exclusive (can not be used together)")
+				exit(1)
+				return err
+			}
+			if !govalidator.IsCIDR(subnet) {
+				err := errors.NotCIDR(subnet)
+				exit(1)
+				return err
+			}
+		} else {
+			for _, ip := range []string{start, end} {
+				if !govalidator.IsIPv4(ip) {
+					err := errors.NotIPv4(ip)
+					exit(1)
+					return err
+				}
+			}
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		req := pb.VPNIPPoolCreateRequest{Name: name, Start: start, End: end, Subnet: subnet}
+		return vpnIPPoolCreateAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), &req)
+	},
+}
+
+var vpnIPPoolDeleteCommand = cli.Command{
+	Name:    "delete",
+	Usage:   "Delete an ip pool that has no leases.",
+	Aliases: []string{"d"},
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name, n",
+			Usage: "name of the ip pool",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		action = "vpn:ip-pool:delete"
+		// Use default port if no port is specified.
+		daemonPort := ovpm.DefaultDaemonPort
+		if port := c.GlobalInt("daemon-port"); port != 0 {
+			daemonPort = port
+		}
+
+		name := c.String("name")
+		if govalidator.IsNull(name) {
+			err := errors.EmptyValue("name", name)
+			exit(1)
+			return err
+		}
+
+		// If dry run, then don't call the action, just preprocess.
+		if c.GlobalBool("dry-run") {
+			return nil
+		}
+
+		return vpnIPPoolDeleteAction(fmt.Sprintf("grpc://localhost:%d", daemonPort), name)
+	},
+}
+
+var vpnIPPoolCommand = cli.Command{
+	Name:    "ip-pool",
+	Usage:   "IP Pool Operations, lists the ip pools and their usage when no subcommand is given.",
+	Aliases: []string{"ip"},
+	Action:  vpnIPPoolList,
+	Subcommands: []cli.Command{
+		vpnIPPoolListCommand,
+		vpnIPPoolCreateCommand,
+		vpnIPPoolDeleteCommand,
+	},
+}
+
 func init() {
 	app.Commands = append(app.Commands,
 		cli.Command{
@@ -285,6 +562,8 @@ func init() {
 				vpnInitCommand,
 				vpnUpdateCommand,
 				vpnRestartCommand,
+				vpnFirewallCommand,
+				vpnIPPoolCommand,
 			},
 		},
 	)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGroupCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "group"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subcmd := range []string{"list, l", "create, c", "update, u", "delete, d", "add-member, am", "remove-member, rm", "associate, a", "dissociate, di"} {
+		if !strings.Contains(output.String(), subcmd) {
+			t.Fatalf("subcommand missing '%s'", subcmd)
+		}
+	}
+}
+
+func TestGroupUpdateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "group", "update"})
+	if err == nil {
+		t.Fatal("error is expected about missing fields, but we didn't got error")
+	}
+
+	// Invalid dns server
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "update", "--name", "engineering", "--dns", "foo"})
+	if err == nil {
+		t.Fatal("error is expected about invalid dns server, but we didn't got error")
+	}
+
+	// Conflicting ip pool options
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "update", "--name", "contractors", "--ip-pool", "contractors", "--reset-ip-pool"})
+	if err == nil {
+		t.Fatal("error is expected about conflicting ip pool options, but we didn't got error")
+	}
+
+	// Ensure proper call
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "update", "--name", "engineering", "--no-gw", "--dns", "10.0.0.53", "--push", "route 10.10.0.0 255.255.0.0"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestGroupAssociateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing network
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "associate", "--name", "engineering"})
+	if err == nil {
+		t.Fatal("error is expected about missing network, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "associate", "--name", "engineering", "--net", "office"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "group", "add-member", "--name", "engineering", "--user", "alice"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
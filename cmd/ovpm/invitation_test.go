@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestInvitationCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "invitation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "list, l") {
+		t.Fatal("subcommand missing 'list, l'")
+	}
+
+	if !strings.Contains(output.String(), "revoke, r") {
+		t.Fatal("subcommand missing 'revoke, r'")
+	}
+}
+
+func TestInvitationRevokeCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "invitation", "revoke"})
+	if err == nil {
+		t.Fatal("error is expected about missing username, but we didn't got error")
+	}
+
+	// Ensure proper call
+	err = app.Run([]string{"ovpm", "--dry-run", "invitation", "revoke", "--user", "newcomer"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLockoutCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "lockout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "list, l") {
+		t.Fatal("subcommand missing 'list, l'")
+	}
+
+	if !strings.Contains(output.String(), "clear, c") {
+		t.Fatal("subcommand missing 'clear, c'")
+	}
+}
+
+func TestLockoutClearCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "lockout", "clear"})
+	if err == nil {
+		t.Fatal("error is expected about missing fields, but we didn't got error")
+	}
+
+	// Invalid ip
+	err = app.Run([]string{"ovpm", "--dry-run", "lockout", "clear", "--ip", "foo"})
+	if err == nil {
+		t.Fatal("error is expected about invalid ip, but we didn't got error")
+	}
+
+	// Conflicting flags
+	err = app.Run([]string{"ovpm", "--dry-run", "lockout", "clear", "--user", "alice", "--all"})
+	if err == nil {
+		t.Fatal("error is expected about conflicting flags, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	for _, args := range [][]string{{"--user", "alice"}, {"--ip", "192.0.2.1"}, {"--all"}} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "lockout", "clear"}, args...))
+		if err != nil {
+			t.Fatalf("error is not expected: %v", err)
+		}
+	}
+}
@@ -34,6 +34,10 @@ func TestNetCmd(t *testing.T) {
 	if !strings.Contains(output.String(), "dissoc, di") {
 		t.Fatal("subcommand missing 'dissoc, di'")
 	}
+
+	if !strings.Contains(output.String(), "check, c") {
+		t.Fatal("subcommand missing 'check, c'")
+	}
 }
 
 func TestNetDefineCmd(t *testing.T) {
@@ -95,6 +99,23 @@ func TestNetDefineCmd(t *testing.T) {
 		t.Fatalf("error is not expected: %v", err)
 	}
 
+	// CLIENTNET without --owner
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "def", "--name", "branch", "--type", "CLIENTNET", "--cidr", "192.168.50.0/24"})
+	if err == nil {
+		t.Fatal("error is expected about missing owner, but we didn't got error")
+	}
+
+	// Incorrect use of owner
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "def", "--name", "asd", "--type", "SERVERNET", "--cidr", "192.168.1.1/24", "--owner", "branch1"})
+	if err == nil {
+		t.Fatal("error is expected about incorrect use of owner, but we didn't got error")
+	}
+
+	// Ensure CLIENTNET type use with --owner
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "def", "--name", "branch", "--type", "CLIENTNET", "--cidr", "192.168.50.0/24", "--owner", "branch1"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
 }
 
 func TestNetUnDefineCmd(t *testing.T) {
@@ -135,3 +156,161 @@ func TestAssocCmd(t *testing.T) {
 		t.Fatal("error is expected about missing username, but we didn't got error")
 	}
 }
+
+func TestNetRulesCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing user or group
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "rules", "--net", "office"})
+	if err == nil {
+		t.Fatal("error is expected about missing user or group, but we didn't got error")
+	}
+
+	// Both user and group
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "rules", "--net", "office", "--user", "alice", "--group", "engineering"})
+	if err == nil {
+		t.Fatal("error is expected about conflicting user and group, but we didn't got error")
+	}
+
+	// Invalid rule
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "rules", "--net", "office", "--user", "alice", "--rule", "tcp/99999"})
+	if err == nil {
+		t.Fatal("error is expected about invalid rule, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "rules", "--net", "office", "--user", "alice", "--rule", "tcp/22,443", "--rule", "icmp"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "assoc", "--net", "office", "--user", "alice", "--rule", "udp/53"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestNetMapCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing network
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--to", "10.200.1.0/24"})
+	if err == nil {
+		t.Fatal("error is expected about missing network name, but we didn't got error")
+	}
+
+	// Missing virtual network
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--net", "site_a"})
+	if err == nil {
+		t.Fatal("error is expected about missing virtual network, but we didn't got error")
+	}
+
+	// Invalid virtual network
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--net", "site_a", "--to", "10.200.1.0"})
+	if err == nil {
+		t.Fatal("error is expected about invalid cidr, but we didn't got error")
+	}
+
+	// Both virtual network and remove
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--net", "site_a", "--to", "10.200.1.0/24", "--remove"})
+	if err == nil {
+		t.Fatal("error is expected about conflicting options, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--net", "site_a", "--to", "10.200.1.0/24"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "map", "--net", "site_a", "--remove"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestNetUpdateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing network
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--cidr", "10.10.0.0/16"})
+	if err == nil {
+		t.Fatal("error is expected about missing network name, but we didn't got error")
+	}
+
+	// Nothing to update
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--net", "office"})
+	if err == nil {
+		t.Fatal("error is expected about missing changes, but we didn't got error")
+	}
+
+	// Invalid CIDR
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--net", "office", "--cidr", "10.10.0.0"})
+	if err == nil {
+		t.Fatal("error is expected about invalid cidr, but we didn't got error")
+	}
+
+	// Invalid via
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--net", "office", "--via", "gw"})
+	if err == nil {
+		t.Fatal("error is expected about invalid via, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--net", "office", "--name", "hq", "--cidr", "10.10.0.0/16", "--via", "10.9.0.5"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "update", "--net", "office", "--via", "vpn-server"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestNetDNSCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing network
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--domain", "corp.example.com", "--server", "10.10.0.53"})
+	if err == nil {
+		t.Fatal("error is expected about missing network name, but we didn't got error")
+	}
+
+	// Missing server
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--net", "corp", "--domain", "corp.example.com"})
+	if err == nil {
+		t.Fatal("error is expected about missing dns server, but we didn't got error")
+	}
+
+	// Invalid server
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--net", "corp", "--domain", "corp.example.com", "--server", "ns1"})
+	if err == nil {
+		t.Fatal("error is expected about invalid dns server, but we didn't got error")
+	}
+
+	// Both domain and remove
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--net", "corp", "--domain", "corp.example.com", "--remove"})
+	if err == nil {
+		t.Fatal("error is expected about conflicting options, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--net", "corp", "--domain", "corp.example.com", "--server", "10.10.0.53", "--server", "10.10.0.54"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "net", "dns", "--net", "corp", "--remove"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRoleCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "role"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subcmd := range []string{"list, l", "perms, p", "create, c", "update, u", "delete, d", "assign, a", "unassign, ua"} {
+		if !strings.Contains(output.String(), subcmd) {
+			t.Fatalf("subcommand missing '%s'", subcmd)
+		}
+	}
+}
+
+func TestRoleCreateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "role", "create"})
+	if err == nil {
+		t.Fatal("error is expected about missing fields, but we didn't got error")
+	}
+
+	// Missing perms
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "create", "--name", "helpdesk"})
+	if err == nil {
+		t.Fatal("error is expected about missing perms, but we didn't got error")
+	}
+
+	// Unknown perm
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "create", "--name", "helpdesk", "--perm", "foo:bar"})
+	if err == nil {
+		t.Fatal("error is expected about unknown perm, but we didn't got error")
+	}
+
+	// Ensure proper call
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "create", "--name", "helpdesk", "--perm", "user:renew-any", "--perm", "user:genconfig-any"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestRoleAssignCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Missing username
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "assign", "--name", "helpdesk"})
+	if err == nil {
+		t.Fatal("error is expected about missing username, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "assign", "--name", "helpdesk", "--user", "alice"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "role", "unassign", "--name", "helpdesk", "--user", "alice"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTokenCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "list, l") {
+		t.Fatal("subcommand missing 'list, l'")
+	}
+
+	if !strings.Contains(output.String(), "create, c") {
+		t.Fatal("subcommand missing 'create, c'")
+	}
+
+	if !strings.Contains(output.String(), "revoke, r") {
+		t.Fatal("subcommand missing 'revoke, r'")
+	}
+}
+
+func TestTokenCreateCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	var err error
+
+	// Empty call
+	err = app.Run([]string{"ovpm", "token", "create"})
+	if err == nil {
+		t.Fatal("error is expected about missing fields, but we didn't got error")
+	}
+
+	// Missing name
+	err = app.Run([]string{"ovpm", "token", "create", "--user", "ci"})
+	if err == nil {
+		t.Fatal("error is expected about missing token name, but we didn't got error")
+	}
+
+	// Unknown scope
+	err = app.Run([]string{"ovpm", "--dry-run", "token", "create", "--user", "ci", "--name", "deploy", "--scope", "foo:bar"})
+	if err == nil {
+		t.Fatal("error is expected about unknown scope, but we didn't got error")
+	}
+
+	// Ensure proper call
+	err = app.Run([]string{"ovpm", "--dry-run", "token", "create", "--user", "ci", "--name", "deploy", "--expires-in", "720h", "--scope", "vpn:status"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestServiceAccountCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "service-account"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "list, l") {
+		t.Fatal("subcommand missing 'list, l'")
+	}
+
+	if !strings.Contains(output.String(), "create, c") {
+		t.Fatal("subcommand missing 'create, c'")
+	}
+
+	if !strings.Contains(output.String(), "delete, d") {
+		t.Fatal("subcommand missing 'delete, d'")
+	}
+}
@@ -82,6 +82,46 @@ func TestUserCreateCmd(t *testing.T) {
 		t.Fatalf("error is not expected: %v", err)
 	}
 
+	// Validity window
+	for _, args := range [][]string{
+		{"--valid-until", "tomorrow"},
+		{"--valid-from", "2030-01-02", "--valid-until", "2030-01-01"},
+	} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "user", "create", "--username", "contractor", "--password", "1234"}, args...))
+		if err == nil {
+			t.Fatalf("error is expected about %v", args)
+		}
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "user", "create", "--username", "contractor", "--password", "1234", "--valid-from", "2030-01-01", "--valid-until", "2030-06-30T18:00:00Z"})
+	if err != nil {
+		t.Fatalf("validity window is expected to be valid: %v", err)
+	}
+
+	// Email
+	err = app.Run([]string{"ovpm", "--dry-run", "user", "create", "--username", "contractor", "--password", "1234", "--email", "contractor"})
+	if err == nil {
+		t.Fatal("error is expected about the invalid email address")
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "user", "create", "--username", "contractor", "--password", "1234", "--email", "contractor@example.com"})
+	if err != nil {
+		t.Fatalf("email address is expected to be valid: %v", err)
+	}
+
+	// Invitation
+	for _, args := range [][]string{
+		{"--invite", "--password", "1234"},
+		{"--invite-ttl", "72h", "--password", "1234"},
+		{"--invite", "--invite-ttl", "-1h"},
+	} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "user", "create", "--username", "newcomer"}, args...))
+		if err == nil {
+			t.Fatalf("error is expected about %v", args)
+		}
+	}
+	err = app.Run([]string{"ovpm", "--dry-run", "user", "create", "--username", "newcomer", "--invite", "--invite-ttl", "72h", "--email", "newcomer@example.com"})
+	if err != nil {
+		t.Fatalf("invitation is expected to be valid: %v", err)
+	}
 }
 
 func TestUserUpdateCmd(t *testing.T) {
@@ -126,6 +166,39 @@ func TestUserUpdateCmd(t *testing.T) {
 	if err == nil {
 		t.Fatal("error is expected about bulk and --static conflict")
 	}
+
+	// Push options
+	for _, args := range [][]string{
+		{"--dns", "10.0.0.53", "--reset-dns"},
+		{"--dns", "ns1"},
+		{"--route", "10.20.0.0"},
+		{"--route", "10.20.0.0/16", "--reset-routes"},
+		{"--redirect-gateway", "def1", "--reset-redirect-gateway"},
+		{"--push", "ping 10", "--reset-push"},
+		{"--inactive", "-1"},
+		{"--inactive", "3600", "--reset-inactive"},
+		{"--ip-pool", "contractors", "--reset-ip-pool"},
+		{"--valid-from", "2030-01-01", "--reset-valid-from"},
+		{"--valid-until", "2030-01-01", "--reset-valid-until"},
+		{"--valid-until", "01/01/2030"},
+		{"--cert-lifetime", "2160h", "--reset-cert-lifetime"},
+		{"--cert-lifetime", "-1h"},
+		{"--email", "foo@example.com", "--reset-email"},
+		{"--email", "foo"},
+	} {
+		err = app.Run(append([]string{"ovpm", "user", "update", "--username", "foo"}, args...))
+		if err == nil {
+			t.Fatalf("error is expected about %v", args)
+		}
+	}
+	err = app.Run([]string{"ovpm", "user", "update", "--username", "foo", "--dns", "10.0.0.53", "--route", "10.20.0.0/16", "--redirect-gateway", "def1 block-local", "--push", "ping 10", "--inactive", "3600"})
+	if err != nil {
+		t.Fatalf("push options are expected to be valid: %v", err)
+	}
+	err = app.Run([]string{"ovpm", "user", "update", "--username", "foo", "--cert-lifetime", "2160h"})
+	if err != nil {
+		t.Fatalf("cert lifetime is expected to be valid: %v", err)
+	}
 }
 
 func TestUserDeleteCmd(t *testing.T) {
@@ -154,6 +227,25 @@ func TestUserRenewCmd(t *testing.T) {
 	}
 }
 
+func TestUserDisableCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	for _, cmd := range []string{"disable", "enable"} {
+		// Empty call
+		if err := app.Run([]string{"ovpm", "--dry-run", "user", cmd}); err == nil {
+			t.Fatalf("error is expected about missing username for %s, but we didn't got error", cmd)
+		}
+
+		// Ensure proper calls, including the bulk one
+		for _, username := range []string{"bob", "*"} {
+			if err := app.Run([]string{"ovpm", "--dry-run", "user", cmd, "--username", username}); err != nil {
+				t.Fatalf("error is not expected for %s %s: %v", cmd, username, err)
+			}
+		}
+	}
+}
+
 func TestUserGenconfigCmd(t *testing.T) {
 	output := new(bytes.Buffer)
 	app.Writer = output
@@ -166,3 +258,29 @@ func TestUserGenconfigCmd(t *testing.T) {
 		t.Fatal("error is expected about missing fields, but we didn't got error")
 	}
 }
+
+func TestUserProfileLinkCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	// Empty call
+	if err := app.Run([]string{"ovpm", "--dry-run", "user", "profile-link"}); err == nil {
+		t.Fatal("error is expected about missing username, but we didn't got error")
+	}
+
+	// Invalid ttl
+	if err := app.Run([]string{"ovpm", "--dry-run", "user", "profile-link", "--user", "bob", "--ttl", "1500ms"}); err == nil {
+		t.Fatal("error is expected about invalid ttl, but we didn't got error")
+	}
+
+	// Ensure proper calls
+	for _, args := range [][]string{
+		{"--user", "bob"},
+		{"--user", "bob", "--ttl", "2h"},
+		{"--user", "bob", "--passcode"},
+	} {
+		if err := app.Run(append([]string{"ovpm", "--dry-run", "user", "profile-link"}, args...)); err != nil {
+			t.Fatalf("error is not expected for %v: %v", args, err)
+		}
+	}
+}
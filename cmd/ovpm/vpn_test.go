@@ -31,3 +31,121 @@ func TestVPNCmd(t *testing.T) {
 		t.Fatal("subcommand missing 'restart, r'")
 	}
 }
+
+func TestVPNFirewallCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+
+	err := app.Run([]string{"ovpm", "vpn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "firewall, f") {
+		t.Fatal("subcommand missing 'firewall, f'")
+	}
+
+	output.Reset()
+	err = app.Run([]string{"ovpm", "vpn", "firewall"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "show, s") {
+		t.Fatal("subcommand missing 'show, s'")
+	}
+}
+
+func TestVPNUpdateNATCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+	var err error
+
+	// Unknown nat mode
+	err = app.Run([]string{"ovpm", "--dry-run", "vpn", "update", "--nat", "bridge"})
+	if err == nil {
+		t.Fatal("error is expected about unknown nat mode, but we didn't got error")
+	}
+
+	// Invalid snat address
+	err = app.Run([]string{"ovpm", "--dry-run", "vpn", "update", "--nat", "snat", "--snat-to", "foo"})
+	if err == nil {
+		t.Fatal("error is expected about invalid snat address, but we didn't got error")
+	}
+
+	// Invalid network nat overrides
+	for _, networkNAT := range []string{"lan", "=none", "lan=bridge", "lan=snat", "lan=snat:foo"} {
+		err = app.Run([]string{"ovpm", "--dry-run", "vpn", "update", "--network-nat", networkNAT})
+		if err == nil {
+			t.Fatalf("error is expected about invalid network nat %s, but we didn't got error", networkNAT)
+		}
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "vpn", "update", "--nat", "snat", "--snat-to", "203.0.113.5", "--egress", "eth1", "--network-nat", "lan=none", "--network-nat", "dmz=snat:192.168.5.1", "--network-nat", "lab=inherit"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestVPNUpdateDHCPCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+	var err error
+
+	// Invalid options
+	for _, args := range [][]string{
+		{"--domain", "corp example"},
+		{"--domain", "corp.example.com", "--reset-domain"},
+		{"--search-domain", "example.com", "--reset-search-domain"},
+		{"--ntp", "pool.ntp.org"},
+		{"--ntp", "10.0.0.123", "--reset-ntp"},
+		{"--wins", "foo"},
+	} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "vpn", "update"}, args...))
+		if err == nil {
+			t.Fatalf("error is expected about invalid dhcp options %v, but we didn't got error", args)
+		}
+	}
+
+	// Ensure proper calls
+	err = app.Run([]string{"ovpm", "--dry-run", "vpn", "update", "--dns", "10.0.0.53,10.0.0.54", "--domain", "vpn.example.com", "--search-domain", "example.com", "--search-domain", "corp.example.com", "--ntp", "10.0.0.123", "--reset-wins"})
+	if err != nil {
+		t.Fatalf("error is not expected: %v", err)
+	}
+}
+
+func TestVPNIPPoolCmd(t *testing.T) {
+	output := new(bytes.Buffer)
+	app.Writer = output
+	var err error
+
+	// Missing or invalid options
+	for _, args := range [][]string{
+		{"create"},
+		{"create", "--name", "office", "--start", "10.9.0.10"},
+		{"create", "--name", "office", "--start", "10.9.0.10", "--end", "foo"},
+		{"create", "--name", "contractors", "--subnet", "10.9.8.0"},
+		{"create", "--name", "contractors", "--subnet", "10.9.8.0/24", "--start", "10.9.8.10"},
+		{"delete"},
+	} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "vpn", "ip-pool"}, args...))
+		if err == nil {
+			t.Fatalf("error is expected about %v, but we didn't got error", args)
+		}
+	}
+
+	// Ensure proper calls
+	for _, args := range [][]string{
+		{},
+		{"list"},
+		{"create", "--name", "office", "--start", "10.9.0.10", "--end", "10.9.0.99"},
+		{"create", "--name", "contractors", "--subnet", "10.9.8.0/24"},
+		{"delete", "--name", "office"},
+	} {
+		err = app.Run(append([]string{"ovpm", "--dry-run", "vpn", "ip-pool"}, args...))
+		if err != nil {
+			t.Fatalf("error is not expected for %v: %v", args, err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,8 +17,10 @@ import (
 
 	"github.com/master312/ovpm"
 	"github.com/master312/ovpm/api"
+	"github.com/master312/ovpm/firewall"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
+	"golang.org/x/time/rate"
 )
 
 var action string
@@ -41,6 +44,239 @@ func main() {
 			Name:  "web-port",
 			Usage: "port number for the REST API daemon",
 		},
+		cli.BoolFlag{
+			Name:  "vpn-password-auth",
+			Usage: "vpn clients must log in with their passwords (or their LDAP passwords) besides their certs",
+		},
+		cli.BoolFlag{
+			Name:  "require-totp",
+			Usage: "invited users must enrol in TOTP when they accept their invitations",
+		},
+		cli.StringFlag{
+			Name:   "ldap-url",
+			Usage:  "url of the LDAP server to authenticate and sync users with, e.g. ldaps://dc.example.com (disabled if empty)",
+			EnvVar: "OVPM_LDAP_URL",
+		},
+		cli.BoolFlag{
+			Name:  "ldap-start-tls",
+			Usage: "upgrade ldap:// connections with StartTLS",
+		},
+		cli.BoolFlag{
+			Name:  "ldap-insecure-skip-verify",
+			Usage: "don't verify the LDAP server's certificate",
+		},
+		cli.StringFlag{
+			Name:   "ldap-bind-dn",
+			Usage:  "DN of the service account used for LDAP searches",
+			EnvVar: "OVPM_LDAP_BIND_DN",
+		},
+		cli.StringFlag{
+			Name:   "ldap-bind-password",
+			Usage:  "password of the LDAP service account",
+			EnvVar: "OVPM_LDAP_BIND_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:  "ldap-base-dn",
+			Usage: "search base for the LDAP users, e.g. ou=people,dc=example,dc=com",
+		},
+		cli.StringFlag{
+			Name:  "ldap-username-attr",
+			Usage: "LDAP attribute that holds the username",
+			Value: ovpm.DefaultLDAPUsernameAttr,
+		},
+		cli.StringFlag{
+			Name:  "ldap-member-of-attr",
+			Usage: "LDAP attribute that lists the groups of a user",
+			Value: ovpm.DefaultLDAPMemberOfAttr,
+		},
+		cli.StringFlag{
+			Name:  "ldap-user-filter",
+			Usage: "LDAP filter that users must match to have access, e.g. (memberOf=cn=vpn,ou=groups,dc=example,dc=com)",
+		},
+		cli.StringSliceFlag{
+			Name:  "ldap-admin-group",
+			Usage: "DN of an LDAP group whose members are given admin rights (can be repeated)",
+		},
+		cli.StringSliceFlag{
+			Name:  "ldap-group-network",
+			Usage: "associate members of an LDAP group with a network, in the form of <group dn>:<network name> (can be repeated)",
+		},
+		cli.DurationFlag{
+			Name:  "ldap-sync-interval",
+			Usage: "period of the LDAP user synchronization",
+			Value: ovpm.DefaultLDAPSyncInterval,
+		},
+		cli.DurationFlag{
+			Name:  "validity-check-interval",
+			Usage: "period of disabling the expired users and enabling the ones that become valid",
+			Value: ovpm.DefaultValidityCheckInterval,
+		},
+		cli.DurationFlag{
+			Name:  "ca-cert-lifetime",
+			Usage: "lifetime of the ca cert that is created when the server is initialized (default: 10 years)",
+		},
+		cli.DurationFlag{
+			Name:  "server-cert-lifetime",
+			Usage: "lifetime of the server cert that is created when the server is initialized (default: 10 years)",
+		},
+		cli.DurationFlag{
+			Name:  "client-cert-lifetime",
+			Usage: "lifetime of the client certs, unless the user has its own (default: 10 years)",
+		},
+		cli.DurationFlag{
+			Name:  "cert-renew-before",
+			Usage: "period before the expiration of a client cert that it's renewed at",
+			Value: ovpm.DefaultCertRenewBefore,
+		},
+		cli.DurationFlag{
+			Name:  "cert-renew-interval",
+			Usage: "period of renewing the client certs that are about to expire",
+			Value: ovpm.DefaultCertRenewInterval,
+		},
+		cli.StringFlag{
+			Name:   "smtp-host",
+			Usage:  "host of the SMTP server that the email notifications are sent through (disabled if empty)",
+			EnvVar: "OVPM_SMTP_HOST",
+		},
+		cli.StringFlag{
+			Name:   "smtp-port",
+			Usage:  "port of the SMTP server",
+			Value:  ovpm.DefaultSMTPPort,
+			EnvVar: "OVPM_SMTP_PORT",
+		},
+		cli.StringFlag{
+			Name:   "smtp-tls",
+			Usage:  fmt.Sprintf("tls mode of the SMTP connection (%s, %s or %s)", ovpm.SMTPTLSNone, ovpm.SMTPTLSStartTLS, ovpm.SMTPTLS),
+			Value:  ovpm.SMTPTLSStartTLS,
+			EnvVar: "OVPM_SMTP_TLS",
+		},
+		cli.StringFlag{
+			Name:   "smtp-username",
+			Usage:  "username to authenticate to the SMTP server with (no authentication if empty)",
+			EnvVar: "OVPM_SMTP_USERNAME",
+		},
+		cli.StringFlag{
+			Name:   "smtp-password",
+			Usage:  "password to authenticate to the SMTP server with",
+			EnvVar: "OVPM_SMTP_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:   "smtp-from",
+			Usage:  "address that the email notifications are sent from",
+			EnvVar: "OVPM_SMTP_FROM",
+		},
+		cli.StringSliceFlag{
+			Name:  "smtp-admin-email",
+			Usage: "address that receives the admin digests besides the admin users' own (can be repeated)",
+		},
+		cli.StringFlag{
+			Name:  "smtp-template-dir",
+			Usage: "directory of the <name>.tmpl files that override the default email templates",
+		},
+		cli.StringFlag{
+			Name:   "web-url",
+			Usage:  "public url of the web ui that is linked in the emails and the profile links, e.g. https://vpn.example.com",
+			EnvVar: "OVPM_WEB_URL",
+		},
+		cli.DurationFlag{
+			Name:  "admin-digest-interval",
+			Usage: "period of emailing the admins the expiring certs and the process failures",
+			Value: ovpm.DefaultAdminDigestInterval,
+		},
+		cli.StringFlag{
+			Name:   "oidc-issuer-url",
+			Usage:  "issuer url of the OpenID Connect provider for single sign-on, e.g. https://accounts.google.com (disabled if empty)",
+			EnvVar: "OVPM_OIDC_ISSUER_URL",
+		},
+		cli.StringFlag{
+			Name:   "oidc-client-id",
+			Usage:  "client id registered on the OpenID Connect provider",
+			EnvVar: "OVPM_OIDC_CLIENT_ID",
+		},
+		cli.StringFlag{
+			Name:   "oidc-client-secret",
+			Usage:  "client secret registered on the OpenID Connect provider",
+			EnvVar: "OVPM_OIDC_CLIENT_SECRET",
+		},
+		cli.StringFlag{
+			Name:  "oidc-redirect-url",
+			Usage: "externally reachable callback url of ovpm, e.g. https://vpn.example.com/api/v1/auth/oidc/callback",
+		},
+		cli.StringSliceFlag{
+			Name:  "oidc-scope",
+			Usage: "scope to request besides openid, e.g. profile, email, groups (can be repeated)",
+		},
+		cli.StringFlag{
+			Name:  "oidc-username-claim",
+			Usage: "ID token claim that is matched against the usernames",
+			Value: ovpm.DefaultOIDCUsernameClaim,
+		},
+		cli.StringFlag{
+			Name:  "oidc-groups-claim",
+			Usage: "ID token claim that lists the groups of the user",
+			Value: ovpm.DefaultOIDCGroupsClaim,
+		},
+		cli.StringSliceFlag{
+			Name:  "oidc-admin-group",
+			Usage: "group whose members are given admin rights (can be repeated)",
+		},
+		cli.BoolFlag{
+			Name:  "oidc-create-users",
+			Usage: "create the users that don't exist yet on their first single sign-on",
+		},
+		cli.IntFlag{
+			Name:  "lockout-threshold",
+			Usage: "failed login attempts against a username before locking it out (0 disables)",
+			Value: ovpm.DefaultLockoutUserThreshold,
+		},
+		cli.IntFlag{
+			Name:  "lockout-ip-threshold",
+			Usage: "failed login attempts from a source ip before locking it out (0 disables)",
+			Value: ovpm.DefaultLockoutIPThreshold,
+		},
+		cli.DurationFlag{
+			Name:  "lockout-duration",
+			Usage: "duration of the first lockout; it's doubled with every further failed attempt",
+			Value: ovpm.DefaultLockoutDuration,
+		},
+		cli.DurationFlag{
+			Name:  "lockout-max-duration",
+			Usage: "upper limit of the lockouts",
+			Value: ovpm.DefaultLockoutMaxDuration,
+		},
+		cli.DurationFlag{
+			Name:  "lockout-reset-after",
+			Usage: "period without failed attempts after which they are forgotten",
+			Value: ovpm.DefaultLockoutResetAfter,
+		},
+		cli.Float64Flag{
+			Name:  "rate-limit",
+			Usage: "requests per second that the api serves to each remote client (0 disables)",
+			Value: ovpm.DefaultRateLimit,
+		},
+		cli.IntFlag{
+			Name:  "rate-limit-burst",
+			Usage: "requests that the api serves to each remote client at once",
+			Value: ovpm.DefaultRateLimitBurst,
+		},
+		cli.StringFlag{
+			Name:  "firewall",
+			Usage: fmt.Sprintf("firewall backend that the rules are managed with (%s)", strings.Join(firewall.Backends(), ", ")),
+			Value: firewall.Auto,
+		},
+		cli.BoolFlag{
+			Name:  "dns-resolver",
+			Usage: "run a dns resolver for the vpn clients that resolves <username>.<domain> and forwards the rest",
+		},
+		cli.StringFlag{
+			Name:  "dns-resolver-domain",
+			Usage: "domain that the vpn clients are resolved under (default: the vpn domain, or " + ovpm.DefaultResolverDomain + ")",
+		},
+		cli.StringFlag{
+			Name:  "dns-resolver-port",
+			Usage: "port that the dns resolver listens on at the vpn server address; it must be 53 when the resolver is enabled, since the clients are pushed the dns server without a port",
+			Value: ovpm.DefaultResolverPort,
+		},
 	}
 	app.Before = func(c *cli.Context) error {
 		logrus.SetLevel(logrus.InfoLevel)
@@ -65,7 +301,57 @@ func main() {
 			webPort = "8080"
 		}
 
-		s := newServer(port, webPort)
+		ldapConfig, err := ldapConfigFromFlags(c)
+		if err != nil {
+			logrus.Fatalf("invalid ldap configuration: %v", err)
+		}
+		if err := ovpm.SetLDAPConfig(ldapConfig); err != nil {
+			logrus.Fatalf("invalid ldap configuration: %v", err)
+		}
+		ovpm.SetVPNPasswordAuth(c.Bool("vpn-password-auth"))
+		ovpm.SetTOTPRequired(c.Bool("require-totp"))
+		if err := ovpm.SetOIDCConfig(oidcConfigFromFlags(c)); err != nil {
+			logrus.Fatalf("invalid oidc configuration: %v", err)
+		}
+
+		if err := ovpm.SetLockoutPolicy(lockoutPolicyFromFlags(c)); err != nil {
+			logrus.Fatalf("invalid lockout configuration: %v", err)
+		}
+		if err := ovpm.SetFirewallBackend(c.String("firewall")); err != nil {
+			logrus.Fatalf("invalid firewall configuration: %v", err)
+		}
+		if err := ovpm.SetResolverConfig(ovpm.ResolverConfig{
+			Enabled: c.Bool("dns-resolver"),
+			Domain:  c.String("dns-resolver-domain"),
+			Port:    c.String("dns-resolver-port"),
+		}); err != nil {
+			logrus.Fatalf("invalid dns resolver configuration: %v", err)
+		}
+		if err := ovpm.SetCertConfig(ovpm.CertConfig{
+			CALifetime:     c.Duration("ca-cert-lifetime"),
+			ServerLifetime: c.Duration("server-cert-lifetime"),
+			ClientLifetime: c.Duration("client-cert-lifetime"),
+			RenewBefore:    c.Duration("cert-renew-before"),
+		}); err != nil {
+			logrus.Fatalf("invalid cert configuration: %v", err)
+		}
+		if err := ovpm.SetWebURL(c.String("web-url")); err != nil {
+			logrus.Fatalf("invalid web url: %v", err)
+		}
+		if err := ovpm.SetSMTPConfig(smtpConfigFromFlags(c)); err != nil {
+			logrus.Fatalf("invalid smtp configuration: %v", err)
+		}
+
+		var limiter *rate.Limiter
+		if limit := c.Float64("rate-limit"); limit > 0 {
+			limiter = rate.NewLimiter(rate.Limit(limit), c.Int("rate-limit-burst"))
+		}
+
+		s := newServer(port, webPort, limiter)
+		s.ldapSyncInterval = c.Duration("ldap-sync-interval")
+		s.validityCheckInterval = c.Duration("validity-check-interval")
+		s.certRenewInterval = c.Duration("cert-renew-interval")
+		s.adminDigestInterval = c.Duration("admin-digest-interval")
 		s.start()
 		s.waitForInterrupt()
 		s.stop()
@@ -83,9 +369,21 @@ type server struct {
 	restPort   string
 	signal     chan os.Signal
 	done       chan bool
+
+	ldapSyncInterval time.Duration
+	stopLDAPSync     func()
+
+	validityCheckInterval time.Duration
+	stopValidityJob       func()
+
+	certRenewInterval  time.Duration
+	stopCertRenewalJob func()
+
+	adminDigestInterval time.Duration
+	stopAdminDigestJob  func()
 }
 
-func newServer(port, webPort string) *server {
+func newServer(port, webPort string, limiter *rate.Limiter) *server {
 	sigs := make(chan os.Signal, 1)
 	done := make(chan bool, 1)
 
@@ -107,7 +405,7 @@ func newServer(port, webPort string) *server {
 			logrus.Fatalf("could not listen to port %s: %v", port, err)
 		}
 
-		rpcServer := api.NewRPCServer()
+		rpcServer := api.NewRPCServer(limiter)
 		restServer, restCancel, err := api.NewRESTServer(port)
 		if err != nil {
 			logrus.Fatalf("could not get new rest server :%v", err)
@@ -133,10 +431,30 @@ func (s *server) start() {
 	go s.grpcServer.Serve(s.lis)
 	go http.ListenAndServe(":"+s.restPort, s.restServer)
 	ovpm.TheServer().StartVPNProc()
+	if ovpm.IsLDAPEnabled() {
+		s.stopLDAPSync = ovpm.StartLDAPSync(s.ldapSyncInterval)
+	}
+	s.stopValidityJob = ovpm.StartValidityJob(s.validityCheckInterval)
+	s.stopCertRenewalJob = ovpm.StartCertRenewalJob(s.certRenewInterval)
+	if ovpm.IsSMTPEnabled() {
+		s.stopAdminDigestJob = ovpm.StartAdminDigestJob(s.adminDigestInterval)
+	}
 }
 
 func (s *server) stop() {
 	logrus.Info("OVPM is shutting down ...")
+	if s.stopLDAPSync != nil {
+		s.stopLDAPSync()
+	}
+	if s.stopValidityJob != nil {
+		s.stopValidityJob()
+	}
+	if s.stopCertRenewalJob != nil {
+		s.stopCertRenewalJob()
+	}
+	if s.stopAdminDigestJob != nil {
+		s.stopAdminDigestJob()
+	}
 	s.grpcServer.Stop()
 	s.restCancel()
 	ovpm.TheServer().StopVPNProc()
@@ -154,6 +472,90 @@ func timeout(interval time.Duration) {
 	os.Exit(-1)
 }
 
+// ldapConfigFromFlags returns the LDAP backend config from the command line flags.
+// It returns nil if the LDAP backend is not enabled.
+func ldapConfigFromFlags(c *cli.Context) (*ovpm.LDAPConfig, error) {
+	if c.String("ldap-url") == "" {
+		return nil, nil
+	}
+
+	groupNetworks := make(map[string][]string)
+	for _, m := range c.StringSlice("ldap-group-network") {
+		i := strings.LastIndex(m, ":")
+		if i <= 0 || i == len(m)-1 {
+			return nil, fmt.Errorf("--ldap-group-network should be in the form of <group dn>:<network name>: %s", m)
+		}
+		groupDN, network := m[:i], m[i+1:]
+		groupNetworks[groupDN] = append(groupNetworks[groupDN], network)
+	}
+
+	return &ovpm.LDAPConfig{
+		URL:                c.String("ldap-url"),
+		StartTLS:           c.Bool("ldap-start-tls"),
+		InsecureSkipVerify: c.Bool("ldap-insecure-skip-verify"),
+		BindDN:             c.String("ldap-bind-dn"),
+		BindPassword:       c.String("ldap-bind-password"),
+		BaseDN:             c.String("ldap-base-dn"),
+		UsernameAttr:       c.String("ldap-username-attr"),
+		MemberOfAttr:       c.String("ldap-member-of-attr"),
+		UserFilter:         c.String("ldap-user-filter"),
+		AdminGroups:        c.StringSlice("ldap-admin-group"),
+		GroupNetworks:      groupNetworks,
+	}, nil
+}
+
+// oidcConfigFromFlags returns the OIDC single sign-on config from the command line flags.
+// It returns nil if the single sign-on is not enabled.
+func oidcConfigFromFlags(c *cli.Context) *ovpm.OIDCConfig {
+	if c.String("oidc-issuer-url") == "" {
+		return nil
+	}
+	return &ovpm.OIDCConfig{
+		IssuerURL:     c.String("oidc-issuer-url"),
+		ClientID:      c.String("oidc-client-id"),
+		ClientSecret:  c.String("oidc-client-secret"),
+		RedirectURL:   c.String("oidc-redirect-url"),
+		Scopes:        c.StringSlice("oidc-scope"),
+		UsernameClaim: c.String("oidc-username-claim"),
+		GroupsClaim:   c.String("oidc-groups-claim"),
+		AdminGroups:   c.StringSlice("oidc-admin-group"),
+		CreateUsers:   c.Bool("oidc-create-users"),
+	}
+}
+
+// smtpConfigFromFlags returns the email notifications config from the command line flags.
+// It returns nil if the email notifications are not enabled.
+func smtpConfigFromFlags(c *cli.Context) *ovpm.SMTPConfig {
+	if c.String("smtp-host") == "" {
+		return nil
+	}
+	return &ovpm.SMTPConfig{
+		Host:        c.String("smtp-host"),
+		Port:        c.String("smtp-port"),
+		TLS:         c.String("smtp-tls"),
+		Username:    c.String("smtp-username"),
+		Password:    c.String("smtp-password"),
+		From:        c.String("smtp-from"),
+		AdminEmails: c.StringSlice("smtp-admin-email"),
+		TemplateDir: c.String("smtp-template-dir"),
+	}
+}
+
+// lockoutPolicyFromFlags returns the brute-force protection policy from the command line flags.
+// It returns nil if both of the thresholds are zero.
+func lockoutPolicyFromFlags(c *cli.Context) *ovpm.LockoutPolicy {
+	if c.Int("lockout-threshold") == 0 && c.Int("lockout-ip-threshold") == 0 {
+		return nil
+	}
+	return &ovpm.LockoutPolicy{
+		UserThreshold: c.Int("lockout-threshold"),
+		IPThreshold:   c.Int("lockout-ip-threshold"),
+		Duration:      c.Duration("lockout-duration"),
+		MaxDuration:   c.Duration("lockout-max-duration"),
+		ResetAfter:    c.Duration("lockout-reset-after"),
+	}
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
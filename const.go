@@ -1,5 +1,7 @@
 package ovpm
 
+import "time"
+
 // Version defines the version of ovpm.
 var Version = "development"
 
@@ -25,6 +27,114 @@ const (
 	// DefaultKeepaliveTimeout is the default ping timeout to assume that remote peer is down.
 	DefaultKeepaliveTimeout = "4"
 
+	// DefaultLDAPUsernameAttr is the default LDAP attribute that holds the username.
+	DefaultLDAPUsernameAttr = "sAMAccountName"
+
+	// DefaultLDAPMemberOfAttr is the default LDAP attribute that lists the groups of a user.
+	DefaultLDAPMemberOfAttr = "memberOf"
+
+	// DefaultLDAPSyncInterval is the default period of the LDAP user synchronization.
+	DefaultLDAPSyncInterval = 5 * time.Minute
+
+	// DefaultManagementRetryInterval is the period of reconnecting to the management interface of
+	// the OpenVPN process, e.g. while it's being restarted.
+	DefaultManagementRetryInterval = time.Second
+
+	// DefaultManagementTimeout is the timeout of the commands that are sent to the management
+	// interface of the OpenVPN process.
+	DefaultManagementTimeout = 10 * time.Second
+
+	// DefaultValidityCheckInterval is the default period of checking the users that become valid
+	// or expire.
+	DefaultValidityCheckInterval = time.Minute
+
+	// DefaultCertRenewBefore is the default period before the expiration of a client cert that
+	// it's renewed at.
+	DefaultCertRenewBefore = 30 * 24 * time.Hour
+
+	// DefaultCertRenewInterval is the default period of checking the client certs that are about
+	// to expire.
+	DefaultCertRenewInterval = time.Hour
+
+	// DefaultSMTPPort is the default port of the SMTP server that the notifications are sent through.
+	DefaultSMTPPort = "587"
+
+	// DefaultAdminDigestInterval is the default period of sending the admins digests.
+	DefaultAdminDigestInterval = 24 * time.Hour
+
+	// DefaultPasswordResetTTL is the lifetime of the password reset links that are sent by email.
+	DefaultPasswordResetTTL = time.Hour
+
+	// DefaultProfileLinkTTL is the default lifetime of the one-time profile download links.
+	DefaultProfileLinkTTL = 24 * time.Hour
+
+	// DefaultProfileLinkMaxFailures is the number of failed attempts to unlock a profile download
+	// link, after which it's revoked.
+	DefaultProfileLinkMaxFailures = 5
+
+	// DefaultInvitationTTL is the default lifetime of the invitations of the pending users.
+	DefaultInvitationTTL = 7 * 24 * time.Hour
+
+	// DefaultSessionTokenTTL is the lifetime of the tokens that are issued by logging in.
+	DefaultSessionTokenTTL = 24 * time.Hour
+
+	// DefaultLockoutUserThreshold is the default number of failed attempts against a username before locking it out.
+	DefaultLockoutUserThreshold = 5
+
+	// DefaultLockoutIPThreshold is the default number of failed attempts from a source ip before locking it out.
+	DefaultLockoutIPThreshold = 20
+
+	// DefaultLockoutDuration is the default duration of the first lockout.
+	DefaultLockoutDuration = time.Minute
+
+	// DefaultLockoutMaxDuration is the default upper limit of the lockouts.
+	DefaultLockoutMaxDuration = time.Hour
+
+	// DefaultLockoutResetAfter is the default period without failures after which the failed attempts are forgotten.
+	DefaultLockoutResetAfter = 24 * time.Hour
+
+	// DefaultRateLimit is the default number of requests per second that the api serves to each remote client.
+	DefaultRateLimit = 20
+
+	// DefaultRateLimitBurst is the default number of requests that the api serves to each remote client at once.
+	DefaultRateLimitBurst = 50
+
+	// DefaultNATRetryMin is the delay before retrying to enable nat after the first failed attempt.
+	DefaultNATRetryMin = time.Second
+
+	// DefaultNATRetryMax is the upper limit of the delay between the attempts to enable nat.
+	DefaultNATRetryMax = time.Minute
+
+	// DefaultResolverPort is the default port that the embedded dns resolver listens on.
+	DefaultResolverPort = "53"
+
+	// DefaultResolverDomain is the default domain that the vpn clients are resolved under.
+	DefaultResolverDomain = "vpn"
+
+	// DefaultResolverTTL is the ttl of the answers of the embedded dns resolver, in seconds.
+	DefaultResolverTTL = 60
+
+	// DefaultResolverTimeout is the timeout of the queries that are forwarded upstream.
+	DefaultResolverTimeout = 2 * time.Second
+
+	// DefaultResolverRetryMin is the delay before retrying to start the embedded dns resolver after
+	// the first failed attempt.
+	DefaultResolverRetryMin = time.Second
+
+	// DefaultResolverRetryMax is the upper limit of the delay between the attempts to start the
+	// embedded dns resolver.
+	DefaultResolverRetryMax = 30 * time.Second
+
+	// DefaultIPPoolName is the name of the ip pool that covers the whole vpn network, which the
+	// addresses are leased from when there are no other pools.
+	DefaultIPPoolName = "default"
+
+	// DefaultOIDCUsernameClaim is the default ID token claim that is matched against usernames.
+	DefaultOIDCUsernameClaim = "preferred_username"
+
+	// DefaultOIDCGroupsClaim is the default ID token claim that lists the groups of a user.
+	DefaultOIDCGroupsClaim = "groups"
+
 	etcBasePath = "/etc/ovpm/"
 	varBasePath = "/var/db/ovpm/"
 
@@ -39,6 +149,9 @@ const (
 	_DefaultDHParamsPath  = varBasePath + "dh4096.pem"
 	_DefaultCRLPath       = varBasePath + "crl.pem"
 	_DefaultStatusLogPath = varBasePath + "openvpn-status.log"
+
+	// _DefaultManagementPath is the unix socket of the management interface of the OpenVPN process.
+	_DefaultManagementPath = varBasePath + "management.sock"
 )
 
 // Testing is used to determine whether we are testing or running normally.
@@ -28,14 +28,42 @@ func CreateDB(dialect string, args ...interface{}) *DB {
 	if err != nil {
 		logrus.Fatalf("couldn't open sqlite database %v: %v", args, err)
 	}
+	if len(args) > 0 && args[0] == ":memory:" {
+		// Each connection to an in-memory database opens a new, empty one; so the background
+		// jobs must share the same connection.
+		dbase.DB().SetMaxOpenConns(1)
+	}
+
+	// The addresses of the users were derived from their ids before the leases, they are kept below.
+	migrateLeases := dbase.HasTable(&dbUserModel{}) && !dbase.HasTable(&dbLeaseModel{})
 
 	dbase.AutoMigrate(&dbUserModel{})
 	dbase.AutoMigrate(&dbServerModel{})
 	dbase.AutoMigrate(&dbRevokedModel{})
 	dbase.AutoMigrate(&dbNetworkModel{})
+	dbase.AutoMigrate(&dbTokenModel{})
+	dbase.AutoMigrate(&dbLockoutModel{})
+	dbase.AutoMigrate(&dbAuditModel{})
+	dbase.AutoMigrate(&dbRoleModel{})
+	dbase.AutoMigrate(&dbGroupModel{})
+	dbase.AutoMigrate(&dbNetworkACLModel{})
+	dbase.AutoMigrate(&dbLeaseModel{})
+	dbase.AutoMigrate(&dbIPPoolModel{})
+	dbase.AutoMigrate(&dbPasswordResetModel{})
+	dbase.AutoMigrate(&dbProfileLinkModel{})
+	dbase.AutoMigrate(&dbInvitationModel{})
+	ensureBuiltInRoles(dbase)
+
+	// Auth tokens used to be stored in plaintext on the users table.
+	if dbase.Dialect().HasColumn("db_user_models", "auth_token") {
+		dbase.Exec("UPDATE db_user_models SET auth_token = NULL")
+	}
 
 	dbPTR := &DB{DB: dbase}
 	db = dbPTR
+	if migrateLeases {
+		migrateLeasesFromHostIDs()
+	}
 	return dbPTR
 }
 
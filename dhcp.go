@@ -0,0 +1,104 @@
+package ovpm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/sirupsen/logrus"
+)
+
+// DHCPOptions are the dhcp options that are pushed to all of the clients.
+type DHCPOptions struct {
+	DNS           []string // dns servers
+	Domain        string   // dns domain of the vpn network
+	SearchDomains []string // dns search domains
+	NTP           []string // ntp servers
+	WINS          []string // wins servers
+}
+
+// validate returns an error if the options can't be pushed.
+func (o DHCPOptions) validate() error {
+	if len(o.DNS) == 0 {
+		return fmt.Errorf("validation error: at least one dns server is required")
+	}
+	for _, ips := range []struct {
+		name string
+		ips  []string
+	}{{"dns", o.DNS}, {"ntp", o.NTP}, {"wins", o.WINS}} {
+		for _, ip := range ips.ips {
+			if !govalidator.IsIPv4(ip) {
+				return fmt.Errorf("validation error: %s server `%s` must be an IPv4 address", ips.name, ip)
+			}
+		}
+	}
+	for _, domain := range append([]string{o.Domain}, o.SearchDomains...) {
+		if domain != "" && !govalidator.IsDNSName(domain) {
+			return fmt.Errorf("validation error: `%s` must be a domain name", domain)
+		}
+	}
+	return nil
+}
+
+// pushes returns the options in the form of the push options.
+func (o DHCPOptions) pushes() []string {
+	var pushes []string
+	for _, ip := range o.DNS {
+		pushes = append(pushes, "dhcp-option DNS "+ip)
+	}
+	if o.Domain != "" {
+		pushes = append(pushes, "dhcp-option DOMAIN "+o.Domain)
+	}
+	for _, domain := range o.SearchDomains {
+		pushes = append(pushes, "dhcp-option DOMAIN-SEARCH "+domain)
+	}
+	for _, ip := range o.NTP {
+		pushes = append(pushes, "dhcp-option NTP "+ip)
+	}
+	for _, ip := range o.WINS {
+		pushes = append(pushes, "dhcp-option WINS "+ip)
+	}
+	return pushes
+}
+
+// splitList returns the items of the comma separated list.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetDHCPOptions returns the dhcp options that are pushed to all of the clients.
+func (svr *Server) GetDHCPOptions() DHCPOptions {
+	return DHCPOptions{
+		DNS:           splitList(svr.GetDNS()),
+		Domain:        svr.Domain,
+		SearchDomains: splitList(svr.SearchDomains),
+		NTP:           splitList(svr.NTP),
+		WINS:          splitList(svr.WINS),
+	}
+}
+
+// SetDHCPOptions replaces the dhcp options that are pushed to all of the clients.
+func (svr *Server) SetDHCPOptions(opts DHCPOptions) error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("server is not initialized")
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	svr.dbServerModel.DNS = strings.Join(opts.DNS, ",")
+	svr.dbServerModel.Domain = opts.Domain
+	svr.dbServerModel.SearchDomains = strings.Join(opts.SearchDomains, ",")
+	svr.dbServerModel.NTP = strings.Join(opts.NTP, ",")
+	svr.dbServerModel.WINS = strings.Join(opts.WINS, ",")
+	db.Save(svr.dbServerModel)
+	svr.EmitWithRestart()
+	logrus.Infof("server dhcp options updated")
+	return nil
+}
@@ -0,0 +1,132 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServerDHCPOptions(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "10.0.0.53, 10.0.0.54", "", "", false)
+
+	// Test:
+	if dns := svr.GetDHCPOptions().DNS; len(dns) != 2 || dns[1] != "10.0.0.54" {
+		t.Fatalf("server is expected to have two dns servers: %v", dns)
+	}
+	for _, opts := range []DHCPOptions{
+		{},
+		{DNS: []string{"ns1"}},
+		{DNS: []string{"10.0.0.53"}, Domain: "corp example"},
+		{DNS: []string{"10.0.0.53"}, SearchDomains: []string{"-"}},
+		{DNS: []string{"10.0.0.53"}, NTP: []string{"pool.ntp.org"}},
+		{DNS: []string{"10.0.0.53"}, WINS: []string{"10.0.0"}},
+	} {
+		if err := svr.SetDHCPOptions(opts); err == nil {
+			t.Errorf("SetDHCPOptions(%+v) is expected to fail", opts)
+		}
+	}
+	opts := DHCPOptions{
+		DNS:           []string{"10.0.0.53"},
+		Domain:        "vpn.example.com",
+		SearchDomains: []string{"example.com", "corp.example.com"},
+		NTP:           []string{"10.0.0.123"},
+		WINS:          []string{"10.0.0.139"},
+	}
+	if err := svr.SetDHCPOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	conf := fs[_DefaultVPNConfPath]
+	for _, push := range []string{
+		`push "dhcp-option DNS 10.0.0.53"`,
+		`push "dhcp-option DOMAIN vpn.example.com"`,
+		`push "dhcp-option DOMAIN-SEARCH example.com"`,
+		`push "dhcp-option DOMAIN-SEARCH corp.example.com"`,
+		`push "dhcp-option NTP 10.0.0.123"`,
+		`push "dhcp-option WINS 10.0.0.139"`,
+	} {
+		if !strings.Contains(conf, push) {
+			t.Fatalf("server.conf is expected to have %s:\n%s", push, conf)
+		}
+	}
+	if strings.Contains(conf, "10.0.0.54") {
+		t.Fatalf("server.conf is not expected to have the replaced dns server:\n%s", conf)
+	}
+}
+
+func TestNetworkSplitDNS(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, true, "")
+	CreateNewUser("carol", "1234", false, 0, true, "")
+	corp, _ := CreateNewNetwork("corp", "10.10.0.0/16", ROUTE, "")
+	lab, _ := CreateNewNetwork("lab", "10.20.0.0/16", ROUTE, "")
+	ops, _ := CreateGroup("ops", "")
+	ops.AddMember("carol")
+	ops.Update("", false, []string{"10.30.0.53"}, nil)
+	corp.Associate("alice")
+	lab.Associate("alice")
+	corp.AssociateGroup("ops")
+
+	// Test:
+	for _, tt := range []struct {
+		domain  string
+		servers []string
+	}{
+		{"corp example", []string{"10.10.0.53"}},
+		{"corp.example.com", nil},
+		{"corp.example.com", []string{"ns1"}},
+		{"", []string{"10.10.0.53"}},
+	} {
+		if err := corp.SetDNS(tt.domain, tt.servers); err == nil {
+			t.Errorf("SetDNS(%q, %v) is expected to fail", tt.domain, tt.servers)
+		}
+	}
+	if err := corp.SetDNS("corp.example.com", []string{"10.10.0.53", "10.10.0.54"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lab.SetDNS("lab.example.com", []string{"10.20.0.53"}); err != nil {
+		t.Fatal(err)
+	}
+	corp, _ = GetNetwork("corp")
+	if corp.GetDNSDomain() != "corp.example.com" || len(corp.GetDNSServers()) != 2 {
+		t.Fatalf("dns of corp is expected to be persisted: %s %v", corp.GetDNSDomain(), corp.GetDNSServers())
+	}
+
+	ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]
+	for _, push := range []string{
+		`push "dns server 0 address 8.8.8.8"`,
+		`push "dns server 1 address 10.10.0.53 10.10.0.54"`,
+		`push "dns server 1 resolve-domains corp.example.com"`,
+		`push "dns server 2 address 10.20.0.53"`,
+		`push "dns server 2 resolve-domains lab.example.com"`,
+	} {
+		if !strings.Contains(ccd, push) {
+			t.Fatalf("ccd of alice is expected to have %s:\n%s", push, ccd)
+		}
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "bob")]; strings.Contains(ccd, "dns server") {
+		t.Fatalf("ccd of bob is not expected to have split dns:\n%s", ccd)
+	}
+	ccd = fs[filepath.Join(_DefaultVPNCCDPath, "carol")]
+	if !strings.Contains(ccd, `push "dns server 0 address 10.30.0.53"`) || !strings.Contains(ccd, "resolve-domains corp.example.com") || strings.Contains(ccd, "lab.example.com") {
+		t.Fatalf("ccd of carol is expected to have the split dns of corp, and the dns of ops for the rest:\n%s", ccd)
+	}
+
+	// Removing the domain removes the split dns.
+	if err := corp.SetDNS("", nil); err != nil {
+		t.Fatal(err)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "carol")]; strings.Contains(ccd, "dns server") {
+		t.Fatalf("ccd of carol is not expected to have split dns after the removal:\n%s", ccd)
+	}
+}
@@ -0,0 +1,399 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/master312/ovpm/firewall"
+	"github.com/sirupsen/logrus"
+)
+
+// maxMultiportPorts is the maximum number of ports that can be matched by a single iptables multiport
+// rule, where port ranges count as two ports.
+const maxMultiportPorts = 15
+
+// ACLRule restricts the access to an associated network to a protocol and optionally to some ports.
+//
+// Its string form is `<proto>[/<ports>]` where ports are comma separated ports or port ranges,
+// e.g. `tcp/22,443`, `udp/53`, `tcp/8000-8100` or `icmp`.
+type ACLRule struct {
+	Proto string
+	Ports []string // ports or port ranges in the `from-to` form; empty means all ports
+}
+
+// ParseACLRule parses the string form of an access rule.
+func ParseACLRule(s string) (*ACLRule, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	rule := ACLRule{Proto: strings.ToLower(parts[0])}
+	switch rule.Proto {
+	case "tcp", "udp":
+	case "icmp":
+		if len(parts) > 1 {
+			return nil, fmt.Errorf("validation error: rule `%s` can not have ports for icmp", s)
+		}
+	default:
+		return nil, fmt.Errorf("validation error: rule `%s` must have one of the protocols tcp, udp or icmp", s)
+	}
+	if len(parts) == 1 {
+		return &rule, nil
+	}
+
+	var count int
+	for _, port := range strings.Split(parts[1], ",") {
+		bounds := strings.SplitN(port, "-", 2)
+		var prev uint64
+		for _, b := range bounds {
+			p, err := strconv.ParseUint(b, 10, 16)
+			if err != nil || p == 0 || p < prev {
+				return nil, fmt.Errorf("validation error: rule `%s` has an invalid port or port range: `%s`", s, port)
+			}
+			prev = p
+		}
+		count += len(bounds)
+		rule.Ports = append(rule.Ports, port)
+	}
+	if count > maxMultiportPorts {
+		return nil, fmt.Errorf("validation error: rule `%s` can not have more than %d ports (ranges count as two)", s, maxMultiportPorts)
+	}
+	return &rule, nil
+}
+
+// ParseACLRules parses the string forms of the access rules.
+func ParseACLRules(ss []string) ([]*ACLRule, error) {
+	var rules []*ACLRule
+	for _, s := range ss {
+		rule, err := ParseACLRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// String returns the string form of the rule.
+func (r *ACLRule) String() string {
+	if len(r.Ports) == 0 {
+		return r.Proto
+	}
+	return r.Proto + "/" + strings.Join(r.Ports, ",")
+}
+
+// forwardRule returns the firewall rule that accepts the traffic from src to dst that matches the rule.
+func (r *ACLRule) forwardRule(src, dst string) firewall.Rule {
+	return firewall.Rule{Source: src, Destination: dst, Proto: r.Proto, Ports: r.Ports, Verdict: firewall.Accept}
+}
+
+// dbNetworkACLModel is database model for the access rules of network associations.
+//
+// Either UserID or GroupID is set, depending on whether the rules are of a user or a group association.
+// Associations without any rules have unrestricted access to the network.
+type dbNetworkACLModel struct {
+	ID        uint   `gorm:"primary_key"`
+	NetworkID uint   `gorm:"unique_index:idx_network_acl"`
+	UserID    uint   `gorm:"unique_index:idx_network_acl"`
+	GroupID   uint   `gorm:"unique_index:idx_network_acl"`
+	Rules     string // space separated string forms of the rules
+}
+
+// NetworkACL represents the access rules of a user or a group association of a network.
+type NetworkACL struct {
+	dbNetworkACLModel
+
+	username  string
+	groupName string
+}
+
+// SetRules restricts the access of the associated user to the network with the given rules.
+//
+// Passing no rules lifts the restrictions.
+func (n *Network) SetRules(username string, rules []string) error {
+	user, err := GetUser(username)
+	if err != nil {
+		return fmt.Errorf("user can not be fetched: %v", err)
+	}
+	if !n.hasUser(user.ID) {
+		return fmt.Errorf("user %s is not associated with the network %s", username, n.Name)
+	}
+	if err := n.setACL(dbNetworkACLModel{NetworkID: n.ID, UserID: user.ID}, rules); err != nil {
+		return err
+	}
+	logrus.Infof("rules of '%s' for the network '%s' are set: %v", username, n.Name, rules)
+	return TheServer().EmitWithRestart()
+}
+
+// SetGroupRules restricts the access of the members of the associated group to the network with the given rules.
+//
+// Passing no rules lifts the restrictions.
+func (n *Network) SetGroupRules(groupName string, rules []string) error {
+	group, err := GetGroup(groupName)
+	if err != nil {
+		return fmt.Errorf("group can not be fetched: %v", err)
+	}
+	if !n.hasGroup(group.ID) {
+		return fmt.Errorf("group %s is not associated with the network %s", groupName, n.Name)
+	}
+	if err := n.setACL(dbNetworkACLModel{NetworkID: n.ID, GroupID: group.ID}, rules); err != nil {
+		return err
+	}
+	logrus.Infof("rules of the group '%s' for the network '%s' are set: %v", groupName, n.Name, rules)
+	return TheServer().EmitWithRestart()
+}
+
+// setACL validates and persists the rules of the association.
+func (n *Network) setACL(key dbNetworkACLModel, rules []string) error {
+	parsed, err := ParseACLRules(rules)
+	if err != nil {
+		return err
+	}
+	db.Where("network_id = ? AND user_id = ? AND group_id = ?", key.NetworkID, key.UserID, key.GroupID).Delete(&dbNetworkACLModel{})
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	var ss []string
+	for _, r := range parsed {
+		ss = append(ss, r.String())
+	}
+	key.Rules = strings.Join(ss, " ")
+	return db.Create(&key).Error
+}
+
+// GetACLs returns the access rules of the network's associations that are restricted.
+func (n *Network) GetACLs() []*NetworkACL {
+	var dbACLs []*dbNetworkACLModel
+	db.Where("network_id = ?", n.ID).Find(&dbACLs)
+
+	var acls []*NetworkACL
+	for _, a := range dbACLs {
+		acl := NetworkACL{dbNetworkACLModel: *a}
+		for _, u := range n.Users {
+			if u.ID == a.UserID {
+				acl.username = u.Username
+			}
+		}
+		for _, g := range n.Groups {
+			if g.ID == a.GroupID {
+				acl.groupName = g.Name
+			}
+		}
+		acls = append(acls, &acl)
+	}
+	return acls
+}
+
+// GetUsername returns the username of the user association, if it's one.
+func (a *NetworkACL) GetUsername() string {
+	return a.username
+}
+
+// GetGroupName returns the name of the group association, if it's one.
+func (a *NetworkACL) GetGroupName() string {
+	return a.groupName
+}
+
+// GetRules returns the string forms of the rules.
+func (a *NetworkACL) GetRules() []string {
+	return a.dbNetworkACLModel.rules()
+}
+
+func (a *dbNetworkACLModel) rules() []string {
+	if a.Rules == "" {
+		return nil
+	}
+	return strings.Split(a.Rules, " ")
+}
+
+// deleteNetworkACLs deletes the access rules that match the given conditions.
+func deleteNetworkACLs(query string, args ...interface{}) {
+	db.Where(query, args...).Delete(&dbNetworkACLModel{})
+}
+
+// accessRules returns whether the user has access to the network, either directly or through one of the
+// given groups of the user, along with the rules that restrict the access. Nil rules mean unrestricted access.
+func (n *Network) accessRules(user *User, groups []*Group, acls []*dbNetworkACLModel) ([]*ACLRule, bool) {
+	var associated, unrestricted bool
+	var rules []string
+	restrict := func(match func(a *dbNetworkACLModel) bool) {
+		associated = true
+		for _, a := range acls {
+			if a.NetworkID == n.ID && match(a) {
+				rules = append(rules, a.rules()...)
+				return
+			}
+		}
+		unrestricted = true
+	}
+
+	for _, u := range n.Users {
+		if u.ID == user.ID {
+			restrict(func(a *dbNetworkACLModel) bool { return a.UserID == user.ID })
+		}
+	}
+	for _, ng := range n.Groups {
+		for _, g := range groups {
+			if ng.ID == g.ID {
+				restrict(func(a *dbNetworkACLModel) bool { return a.GroupID == g.ID })
+			}
+		}
+	}
+	if !associated || unrestricted {
+		return nil, associated
+	}
+
+	// Rules are validated when they are set.
+	parsed, _ := ParseACLRules(rules)
+	return parsed, true
+}
+
+var firewallBackend = firewall.Auto
+var firewallInstance firewall.Firewall
+var firewallMu sync.Mutex
+
+// SetFirewallBackend selects the firewall backend that the rules are managed with; one of the
+// firewall.Backends().
+func SetFirewallBackend(backend string) error {
+	if !firewall.IsBackend(backend) {
+		return fmt.Errorf("validation error: unknown firewall backend `%s`, must be one of %s", backend, strings.Join(firewall.Backends(), ", "))
+	}
+	firewallMu.Lock()
+	defer firewallMu.Unlock()
+	firewallBackend = backend
+	firewallInstance = nil
+	return nil
+}
+
+// GetFirewallBackend returns the selected firewall backend.
+func GetFirewallBackend() string {
+	firewallMu.Lock()
+	defer firewallMu.Unlock()
+	return firewallBackend
+}
+
+// getFirewall returns the firewall backend that the rules of the server are managed with.
+//
+// An in-memory fake is used when testing.
+func (svr *Server) getFirewall() (firewall.Firewall, error) {
+	firewallMu.Lock()
+	defer firewallMu.Unlock()
+	if firewallInstance != nil {
+		return firewallInstance, nil
+	}
+	if Testing {
+		firewallInstance = firewall.NewFake()
+		return firewallInstance, nil
+	}
+	fw, err := firewall.New(firewallBackend, svr.firewallChain())
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("firewall backend: %s", fw.Name())
+	firewallInstance = fw
+	return fw, nil
+}
+
+// DumpFirewall returns the name of the firewall backend, and the rules owned by ovpm in its own syntax.
+func (svr *Server) DumpFirewall() (string, string, error) {
+	fw, err := svr.getFirewall()
+	if err != nil {
+		return "", "", err
+	}
+	dump, err := fw.Dump()
+	if err != nil {
+		return "", "", fmt.Errorf("can not dump the %s rules: %v", fw.Name(), err)
+	}
+	return fw.Name(), dump, nil
+}
+
+// firewallChain returns the name of the iptables chain that enforces the network associations of the server.
+func (svr *Server) firewallChain() string {
+	chain := "OVPM-" + svr.Name
+	if len(chain) > 28 { // iptables limit
+		chain = chain[:28]
+	}
+	return chain
+}
+
+// vpnNet returns the vpn network of the server.
+func (svr *Server) vpnNet() *net.IPNet {
+	mask := net.IPMask(net.ParseIP(svr.Mask).To4())
+	return &net.IPNet{IP: net.ParseIP(svr.Net).To4().Mask(mask), Mask: mask}
+}
+
+// clientSource returns the networks that the vpn clients have addresses in, in the comma separated
+// source form of the firewall.
+func (svr *Server) clientSource() string {
+	var nets []string
+	for _, ipnet := range svr.clientNets() {
+		nets = append(nets, ipnet.String())
+	}
+	return strings.Join(nets, ",")
+}
+
+// firewallRules returns the rules that filter the forwarded traffic of the clients.
+//
+// Clients can only reach the networks that they are associated with, optionally restricted by
+// the rules of their associations. The clients that get the vpn server as the default gw can
+// also reach the rest of the world, except the unassociated networks. Everything else is dropped.
+func (svr *Server) firewallRules() ([]firewall.Rule, error) {
+	users, err := GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	networks := GetAllNetworks()
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	var acls []*dbNetworkACLModel
+	db.Find(&acls)
+
+	leases := getLeases()
+
+	rules := []firewall.Rule{{Established: true, Verdict: firewall.Accept}}
+	var gwSources []string
+	for _, user := range users {
+		lease, ok := leases[user.ID]
+		if !ok {
+			// The user can't connect without an address anyway.
+			continue
+		}
+		groups, err := user.GetGroups()
+		if err != nil {
+			return nil, err
+		}
+		src := HostID2IP(lease.HostID).String() + "/32"
+		for _, network := range networks {
+			access, ok := network.accessRules(user, groups, acls)
+			if !ok {
+				continue
+			}
+			// Mapped networks are reached at their virtual addresses, which are translated
+			// before the traffic gets filtered.
+			dst, mapped := network.reachableCIDR(), network.MappedCIDR != ""
+			if len(access) == 0 {
+				rules = append(rules, firewall.Rule{Source: src, Destination: dst, Mapped: mapped, Verdict: firewall.Accept})
+				continue
+			}
+			for _, r := range access {
+				rule := r.forwardRule(src, dst)
+				rule.Mapped = mapped
+				rules = append(rules, rule)
+			}
+		}
+		if !user.isNoGW(groups) {
+			gwSources = append(gwSources, src)
+		}
+	}
+	for _, network := range networks {
+		if network.MappedCIDR != "" {
+			rules = append(rules, firewall.Rule{Destination: network.MappedCIDR, Mapped: true, Verdict: firewall.Drop})
+		}
+		rules = append(rules, firewall.Rule{Destination: network.CIDR, Verdict: firewall.Drop})
+	}
+	for _, src := range gwSources {
+		rules = append(rules, firewall.Rule{Source: src, Verdict: firewall.Return})
+	}
+	return append(rules, firewall.Rule{Verdict: firewall.Drop}), nil
+}
@@ -0,0 +1,84 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Fake is an in-memory firewall backend for the tests.
+type Fake struct {
+	mu     sync.Mutex
+	nat    *NAT
+	source string
+	rules  []Rule
+}
+
+// NewFake returns an in-memory firewall backend.
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// Name returns the name of the backend.
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+// EnsureNAT records the nat.
+func (f *Fake) EnsureNAT(nat NAT) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nat = &nat
+	return nil
+}
+
+// SetForwardRules records the forward rules.
+func (f *Fake) SetForwardRules(source string, rules []Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.source = source
+	f.rules = append([]Rule(nil), rules...)
+	return nil
+}
+
+// Cleanup forgets the nat and the forward rules.
+func (f *Fake) Cleanup() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nat, f.source, f.rules = nil, "", nil
+	return nil
+}
+
+// Dump returns the human readable representations of the nat and the forward rules.
+func (f *Fake) Dump() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var lines []string
+	if f.nat != nil {
+		lines = append(lines, fmt.Sprintf("nat via %s:", f.nat.VPNIface))
+		for _, l := range strings.Split(f.nat.String(), "\n") {
+			lines = append(lines, "\t"+l)
+		}
+	}
+	if f.source != "" {
+		lines = append(lines, fmt.Sprintf("forward from %s:", f.source))
+		for _, r := range f.rules {
+			lines = append(lines, "\t"+r.String())
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// NAT returns the recorded nat, if any.
+func (f *Fake) NAT() *NAT {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.nat
+}
+
+// ForwardRules returns the recorded forward rules.
+func (f *Fake) ForwardRules() []Rule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Rule(nil), f.rules...)
+}
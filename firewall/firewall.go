@@ -0,0 +1,222 @@
+// Package firewall provides the firewall backends that ovpm manages its NAT and forward rules with.
+package firewall
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Backends
+const (
+	Auto     = "auto" // iptables if it's installed, nftables otherwise
+	IPTables = "iptables"
+	NFTables = "nftables"
+)
+
+// Backends returns the names of the backends that can be selected.
+func Backends() []string {
+	return []string{Auto, IPTables, NFTables}
+}
+
+// Verdict is what happens to the packets that match a rule.
+type Verdict uint
+
+// Verdicts
+const (
+	Accept Verdict = iota
+	Drop
+	Return // continue with the rules that aren't owned by ovpm
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Drop:
+		return "drop"
+	case Return:
+		return "return"
+	}
+	return "accept"
+}
+
+// Rule is a backend independent rule that filters the forwarded traffic of the vpn clients.
+//
+// Empty fields match everything.
+type Rule struct {
+	Established bool     // match only the packets of the established or related connections
+	Source      string   // source network in the CIDR form
+	Destination string   // destination network in the CIDR form
+	Mapped      bool     // match Destination before the NETMAP translation of the destination
+	Proto       string   // tcp, udp or icmp
+	Ports       []string // destination ports or port ranges in the `from-to` form; only for tcp and udp
+	Verdict     Verdict
+}
+
+// String returns a human readable representation of the rule.
+func (r Rule) String() string {
+	var parts []string
+	if r.Established {
+		parts = append(parts, "established")
+	}
+	if r.Source != "" {
+		parts = append(parts, "from "+r.Source)
+	}
+	if r.Destination != "" && r.Mapped {
+		parts = append(parts, "to mapped "+r.Destination)
+	} else if r.Destination != "" {
+		parts = append(parts, "to "+r.Destination)
+	}
+	if r.Proto != "" {
+		proto := r.Proto
+		if len(r.Ports) > 0 {
+			proto += "/" + strings.Join(r.Ports, ",")
+		}
+		parts = append(parts, proto)
+	}
+	return strings.Join(append(parts, r.Verdict.String()), " ")
+}
+
+// splitSources returns the networks of the comma separated source.
+func splitSources(source string) []string {
+	var sources []string
+	for _, s := range strings.Split(source, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sources = append(sources, s)
+		}
+	}
+	return sources
+}
+
+// NATMode is how the source address of the vpn clients' traffic is translated.
+type NATMode string
+
+// NAT modes
+const (
+	Masquerade NATMode = "masquerade" // to the address of the interface that the traffic goes out of
+	SNAT       NATMode = "snat"       // to a fixed address
+	NoNAT      NATMode = "none"       // not at all; the network routes the vpn network back to the server
+)
+
+// NATModes returns the nat modes.
+func NATModes() []NATMode {
+	return []NATMode{Masquerade, SNAT, NoNAT}
+}
+
+// IsNATMode returns whether s is a nat mode.
+func IsNATMode(s string) bool {
+	for _, m := range NATModes() {
+		if NATMode(s) == m {
+			return true
+		}
+	}
+	return false
+}
+
+// NAT describes the translation of the vpn clients' traffic.
+type NAT struct {
+	Source    string        // vpn networks in the CIDR form, comma separated
+	VPNIface  string        // vpn network interface
+	OutIface  string        // interface that the traffic goes out of
+	Mode      NATMode       // translation of the traffic that goes out of OutIface
+	SNATTo    string        // source address when Mode is SNAT
+	Overrides []NATOverride // translations of the traffic to specific networks, regardless of the interface
+	Maps      []NetMap      // 1:1 translations of the destination networks
+}
+
+// NetMap translates the destination addresses of a virtual network to the addresses of a real
+// network 1:1, so that the networks with the same addresses can be told apart by the clients.
+type NetMap struct {
+	Virtual string // network that the clients reach, in the CIDR form
+	Real    string // network that the traffic is sent to, in the CIDR form; same size as Virtual
+}
+
+// NATOverride overrides the nat mode for the traffic to a network.
+type NATOverride struct {
+	Destination string // network in the CIDR form
+	Mode        NATMode
+	SNATTo      string // source address when Mode is SNAT
+}
+
+// String returns a human readable representation of the nat.
+func (n NAT) String() string {
+	var lines []string
+	for _, m := range n.Maps {
+		lines = append(lines, fmt.Sprintf("from %s to %s netmap to %s", n.Source, m.Virtual, m.Real))
+	}
+	for _, o := range n.Overrides {
+		lines = append(lines, fmt.Sprintf("from %s to %s %s", n.Source, o.Destination, natTarget(o.Mode, o.SNATTo)))
+	}
+	lines = append(lines, fmt.Sprintf("from %s out %s %s", n.Source, n.OutIface, natTarget(n.Mode, n.SNATTo)))
+	return strings.Join(lines, "\n")
+}
+
+// natTarget returns a human readable representation of the translation.
+func natTarget(mode NATMode, snatTo string) string {
+	switch mode {
+	case SNAT:
+		return "snat to " + snatTo
+	case NoNAT:
+		return "no nat"
+	}
+	return "masquerade"
+}
+
+// Firewall is implemented by the firewall backends.
+type Firewall interface {
+	// Name returns the name of the backend.
+	Name() string
+
+	// EnsureNAT translates the traffic of the vpn clients as described by nat, and lets it be
+	// forwarded between nat.VPNIface and nat.OutIface. The previous nat is replaced.
+	EnsureNAT(nat NAT) error
+
+	// SetForwardRules replaces the rules that the forwarded traffic of the vpn clients in the
+	// source networks (comma separated) is filtered with. The rules are evaluated in order.
+	SetForwardRules(source string, rules []Rule) error
+
+	// Cleanup removes all of the rules that are owned by ovpm.
+	Cleanup() error
+
+	// Dump returns the rules that are owned by ovpm in the backend's own syntax.
+	Dump() (string, error)
+}
+
+// New returns the firewall backend with the given name.
+//
+// chain is the name of the chain that holds the forward rules of the vpn clients.
+func New(backend, chain string) (Firewall, error) {
+	switch backend {
+	case IPTables:
+		return NewIPTables(chain)
+	case NFTables:
+		return NewNFTables(chain)
+	case Auto, "":
+		if hasExecutable("iptables") {
+			logrus.Debugf("firewall backend detected: %s", IPTables)
+			return NewIPTables(chain)
+		}
+		if hasExecutable("nft") {
+			logrus.Debugf("firewall backend detected: %s", NFTables)
+			return NewNFTables(chain)
+		}
+		return nil, fmt.Errorf("neither iptables nor nft executable can be found")
+	}
+	return nil, fmt.Errorf("unknown firewall backend: %s", backend)
+}
+
+// IsBackend returns whether s is the name of a backend.
+func IsBackend(s string) bool {
+	for _, b := range Backends() {
+		if s == b {
+			return true
+		}
+	}
+	return false
+}
+
+func hasExecutable(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,383 @@
+package firewall
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRule(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		str  string
+		ipt  []string
+		nft  string
+	}{
+		{
+			Rule{Established: true, Verdict: Accept},
+			"established accept",
+			[]string{"-m", "state", "--state", "RELATED,ESTABLISHED", "-j", "ACCEPT"},
+			"ct state related,established accept",
+		},
+		{
+			Rule{Source: "10.9.0.2/32", Destination: "10.10.0.0/16", Proto: "tcp", Ports: []string{"22", "8000-8100"}, Verdict: Accept},
+			"from 10.9.0.2/32 to 10.10.0.0/16 tcp/22,8000-8100 accept",
+			[]string{"-s", "10.9.0.2/32", "-d", "10.10.0.0/16", "-p", "tcp", "-m", "multiport", "--dports", "22,8000:8100", "-j", "ACCEPT"},
+			"ip saddr 10.9.0.2/32 ip daddr 10.10.0.0/16 tcp dport { 22, 8000-8100 } accept",
+		},
+		{
+			Rule{Source: "10.9.0.2/32", Proto: "icmp", Verdict: Return},
+			"from 10.9.0.2/32 icmp return",
+			[]string{"-s", "10.9.0.2/32", "-p", "icmp", "-j", "RETURN"},
+			"ip saddr 10.9.0.2/32 ip protocol icmp return",
+		},
+		{
+			Rule{Source: "10.9.0.2/32", Destination: "10.200.1.0/24", Mapped: true, Verdict: Accept},
+			"from 10.9.0.2/32 to mapped 10.200.1.0/24 accept",
+			[]string{"-s", "10.9.0.2/32", "-m", "conntrack", "--ctorigdst", "10.200.1.0/24", "-j", "ACCEPT"},
+			"ip saddr 10.9.0.2/32 ct original ip daddr 10.200.1.0/24 accept",
+		},
+		{
+			Rule{Verdict: Drop},
+			"drop",
+			[]string{"-j", "DROP"},
+			"drop",
+		},
+	}
+	for _, tt := range tests {
+		if s := tt.rule.String(); s != tt.str {
+			t.Errorf("String() = %s, want %s", s, tt.str)
+		}
+		if spec := ruleSpec(tt.rule); !reflect.DeepEqual(spec, tt.ipt) {
+			t.Errorf("ruleSpec(%s) = %v, want %v", tt.str, spec, tt.ipt)
+		}
+		if r := nftRule(tt.rule); r != tt.nft {
+			t.Errorf("nftRule(%s) = %s, want %s", tt.str, r, tt.nft)
+		}
+	}
+}
+
+func TestNFTables(t *testing.T) {
+	var scripts []string
+	fw := &NFTablesFirewall{chain: "OVPM-test", run: func(input string, args ...string) (string, error) {
+		if input != "" {
+			scripts = append(scripts, input)
+		}
+		return "", nil
+	}}
+
+	nat := NAT{
+		Source:    "10.9.0.0/24",
+		VPNIface:  "tun0",
+		OutIface:  "eth0",
+		Overrides: []NATOverride{{Destination: "192.168.1.0/24", Mode: SNAT, SNATTo: "192.168.1.10"}},
+		Maps:      []NetMap{{Virtual: "10.200.1.0/24", Real: "192.168.1.0/24"}, {Virtual: "10.200.2.0/24", Real: "192.168.1.0/24"}},
+	}
+	if err := fw.EnsureNAT(nat); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.SetForwardRules("10.9.0.0/24", []Rule{{Established: true, Verdict: Accept}, {Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 2 {
+		t.Fatalf("each change is expected to be applied at once: %d", len(scripts))
+	}
+	script := scripts[1]
+	for _, line := range []string{
+		"delete table ip ovpm",
+		"ip saddr 10.9.0.0/24 jump OVPM-test",
+		"ip saddr 10.9.0.0/24 dnat ip prefix to ip daddr map { 10.200.1.0/24 : 192.168.1.0/24, 10.200.2.0/24 : 192.168.1.0/24 }",
+		`iifname "tun0" oifname "eth0" accept`,
+		"chain OVPM-test {\n\t\tct state related,established accept\n\t\tdrop\n\t}",
+		"ip saddr 10.9.0.0/24 ip daddr 192.168.1.0/24 snat to 192.168.1.10\n\t\tip saddr 10.9.0.0/24 oifname \"eth0\" masquerade",
+	} {
+		if !strings.Contains(script, line) {
+			t.Errorf("ruleset is expected to contain %q:\n%s", line, script)
+		}
+	}
+
+	if err := fw.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if last := scripts[len(scripts)-1]; !strings.HasSuffix(last, "delete table ip ovpm\n") {
+		t.Fatalf("cleanup is expected to delete the table:\n%s", last)
+	}
+	if fw.nat != nil || fw.source != "" || fw.rules != nil {
+		t.Fatalf("cleanup is expected to forget the rules")
+	}
+}
+
+func TestFake(t *testing.T) {
+	fw := NewFake()
+	nat := NAT{Source: "10.9.0.0/24", VPNIface: "tun0", OutIface: "eth0", Overrides: []NATOverride{{Destination: "192.168.1.0/24", Mode: NoNAT}}}
+	rules := []Rule{{Source: "10.9.0.2/32", Verdict: Return}, {Verdict: Drop}}
+	fw.EnsureNAT(nat)
+	fw.SetForwardRules("10.9.0.0/24", rules)
+	if got := fw.NAT(); got == nil || !reflect.DeepEqual(*got, nat) {
+		t.Fatalf("nat is not recorded: %v", got)
+	}
+	if got := fw.ForwardRules(); !reflect.DeepEqual(got, rules) {
+		t.Fatalf("forward rules are not recorded: %v", got)
+	}
+	dump, _ := fw.Dump()
+	if !strings.Contains(dump, "from 10.9.0.0/24 to 192.168.1.0/24 no nat\n\tfrom 10.9.0.0/24 out eth0 masquerade\n") || !strings.Contains(dump, "from 10.9.0.2/32 return") {
+		t.Fatalf("dump is expected to contain the rules:\n%s", dump)
+	}
+
+	fw.Cleanup()
+	if fw.NAT() != nil || len(fw.ForwardRules()) != 0 {
+		t.Fatalf("cleanup is expected to forget the rules")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("pf", "OVPM-test"); err == nil {
+		t.Fatalf("unknown backends are not expected to be created")
+	}
+	if !IsBackend(NFTables) || IsBackend("pf") {
+		t.Fatalf("IsBackend is not as expected")
+	}
+}
+
+// fakeIPTables is an in-memory iptables that lists the rules the way iptables -S does.
+type fakeIPTables struct {
+	chains     map[string][]string // table/chain -> rule specs
+	failAppend string              // rule spec that can't be appended
+}
+
+func newFakeIPTables() *fakeIPTables {
+	return &fakeIPTables{chains: map[string][]string{"filter/FORWARD": nil, "nat/PREROUTING": nil, "nat/POSTROUTING": nil}}
+}
+
+func (ipt *fakeIPTables) index(table, chain string, rulespec []string) int {
+	for i, r := range ipt.chains[table+"/"+chain] {
+		if r == strings.Join(rulespec, " ") {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ipt *fakeIPTables) Exists(table, chain string, rulespec ...string) (bool, error) {
+	return ipt.index(table, chain, rulespec) >= 0, nil
+}
+
+func (ipt *fakeIPTables) Insert(table, chain string, pos int, rulespec ...string) error {
+	key := table + "/" + chain
+	rules := append([]string{}, ipt.chains[key][:pos-1]...)
+	rules = append(rules, strings.Join(rulespec, " "))
+	ipt.chains[key] = append(rules, ipt.chains[key][pos-1:]...)
+	return nil
+}
+
+func (ipt *fakeIPTables) Append(table, chain string, rulespec ...string) error {
+	if ipt.failAppend != "" && strings.Join(rulespec, " ") == ipt.failAppend {
+		return fmt.Errorf("can not append: %v", rulespec)
+	}
+	key := table + "/" + chain
+	ipt.chains[key] = append(ipt.chains[key], strings.Join(rulespec, " "))
+	return nil
+}
+
+func (ipt *fakeIPTables) Delete(table, chain string, rulespec ...string) error {
+	i := ipt.index(table, chain, rulespec)
+	// Rules can be deleted by their positions as well.
+	if n, err := strconv.Atoi(strings.Join(rulespec, " ")); err == nil && n >= 1 && n <= len(ipt.chains[table+"/"+chain]) {
+		i = n - 1
+	}
+	if i < 0 {
+		return fmt.Errorf("no such rule: %v", rulespec)
+	}
+	key := table + "/" + chain
+	ipt.chains[key] = append(ipt.chains[key][:i], ipt.chains[key][i+1:]...)
+	return nil
+}
+
+func (ipt *fakeIPTables) List(table, chain string) ([]string, error) {
+	rules := []string{"-N " + chain}
+	if chain == "FORWARD" || chain == "PREROUTING" || chain == "POSTROUTING" {
+		rules = []string{"-P " + chain + " ACCEPT"}
+	}
+	for _, r := range ipt.chains[table+"/"+chain] {
+		// iptables quotes the comments that have special characters in them.
+		r = strings.Replace(r, "--comment OVPM-vpn.example", `--comment "OVPM-vpn.example"`, 1)
+		rules = append(rules, "-A "+chain+" "+r)
+	}
+	return rules, nil
+}
+
+func (ipt *fakeIPTables) ChainExists(table, chain string) (bool, error) {
+	_, ok := ipt.chains[table+"/"+chain]
+	return ok, nil
+}
+
+func (ipt *fakeIPTables) ClearChain(table, chain string) error {
+	ipt.chains[table+"/"+chain] = []string{}
+	return nil
+}
+
+func (ipt *fakeIPTables) ClearAndDeleteChain(table, chain string) error {
+	delete(ipt.chains, table+"/"+chain)
+	return nil
+}
+
+func TestIPTables(t *testing.T) {
+	ipt := newFakeIPTables()
+	ipt.Append("filter", "FORWARD", "-i", "eth1", "-j", "ACCEPT") // not owned by ovpm
+	fw := &IPTablesFirewall{ipt: ipt, chain: "OVPM-vpn.example"}
+
+	if err := fw.EnsureNAT(NAT{Source: "10.9.0.0/24", VPNIface: "tun0", OutIface: "eth0"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.SetForwardRules("10.9.0.0/24", []Rule{{Established: true, Verdict: Accept}, {Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"-s 10.9.0.0/24 -m comment --comment OVPM-vpn.example -j OVPM-vpn.example",
+		"-i eth1 -j ACCEPT",
+		"-i eth0 -o tun0 -m state --state RELATED,ESTABLISHED -m comment --comment OVPM-vpn.example -j ACCEPT",
+		"-i tun0 -o eth0 -m comment --comment OVPM-vpn.example -j ACCEPT",
+	}
+	if got := ipt.chains["filter/FORWARD"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("FORWARD is not as expected:\ngot:  %v\nwant: %v", got, want)
+	}
+	if got := ipt.chains["filter/OVPM-vpn.example"]; !reflect.DeepEqual(got, []string{"-m state --state RELATED,ESTABLISHED -j ACCEPT", "-j DROP"}) {
+		t.Fatalf("chain is not as expected: %v", got)
+	}
+
+	// Changing the vpn network and the outbound interface replaces the stale rules.
+	fw = &IPTablesFirewall{ipt: ipt, chain: "OVPM-vpn.example"} // as if the daemon is restarted
+	nat := NAT{
+		Source:    "10.8.0.0/24",
+		VPNIface:  "tun0",
+		OutIface:  "wlan0",
+		Mode:      SNAT,
+		SNATTo:    "203.0.113.5",
+		Overrides: []NATOverride{{Destination: "192.168.1.0/24", Mode: NoNAT}},
+		Maps:      []NetMap{{Virtual: "10.200.1.0/24", Real: "192.168.1.0/24"}},
+	}
+	if err := fw.EnsureNAT(nat); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.SetForwardRules("10.8.0.0/24", []Rule{{Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{
+		"-s 10.8.0.0/24 -m comment --comment OVPM-vpn.example -j OVPM-vpn.example",
+		"-i eth1 -j ACCEPT",
+		"-i wlan0 -o tun0 -m state --state RELATED,ESTABLISHED -m comment --comment OVPM-vpn.example -j ACCEPT",
+		"-i tun0 -o wlan0 -m comment --comment OVPM-vpn.example -j ACCEPT",
+	}
+	if got := ipt.chains["filter/FORWARD"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("FORWARD is not as expected:\ngot:  %v\nwant: %v", got, want)
+	}
+	if got := ipt.chains["nat/POSTROUTING"]; !reflect.DeepEqual(got, []string{"-s 10.8.0.0/24 -m comment --comment OVPM-vpn.example -j OVPM-vpn.example"}) {
+		t.Fatalf("POSTROUTING is not as expected: %v", got)
+	}
+	if got := ipt.chains["nat/PREROUTING"]; !reflect.DeepEqual(got, []string{"-s 10.8.0.0/24 -d 10.200.1.0/24 -m comment --comment OVPM-vpn.example -j NETMAP --to 192.168.1.0/24"}) {
+		t.Fatalf("PREROUTING is not as expected: %v", got)
+	}
+	if got := ipt.chains["nat/OVPM-vpn.example"]; !reflect.DeepEqual(got, []string{"-d 192.168.1.0/24 -j RETURN", "-o wlan0 -j SNAT --to-source 203.0.113.5"}) {
+		t.Fatalf("nat chain is not as expected: %v", got)
+	}
+	dump, err := fw.Dump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dump, "*filter\n-N OVPM-vpn.example\n-A OVPM-vpn.example -j DROP\n") || !strings.Contains(dump, "*nat\n-N OVPM-vpn.example\n-A OVPM-vpn.example -d 192.168.1.0/24 -j RETURN\n") {
+		t.Fatalf("dump is not as expected:\n%s", dump)
+	}
+
+	// Cleaning up leaves only the rules that aren't owned by ovpm.
+	fw = &IPTablesFirewall{ipt: ipt, chain: "OVPM-vpn.example"}
+	if err := fw.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if got := ipt.chains["filter/FORWARD"]; !reflect.DeepEqual(got, []string{"-i eth1 -j ACCEPT"}) {
+		t.Fatalf("FORWARD is not as expected: %v", got)
+	}
+	if got := ipt.chains["nat/POSTROUTING"]; len(got) != 0 {
+		t.Fatalf("POSTROUTING is not as expected: %v", got)
+	}
+	if got := ipt.chains["nat/PREROUTING"]; len(got) != 0 {
+		t.Fatalf("PREROUTING is not as expected: %v", got)
+	}
+	if ok, _ := ipt.ChainExists("filter", "OVPM-vpn.example"); ok {
+		t.Fatalf("chain is expected to be deleted")
+	}
+	if ok, _ := ipt.ChainExists("nat", "OVPM-vpn.example"); ok {
+		t.Fatalf("nat chain is expected to be deleted")
+	}
+}
+
+func TestMultipleSources(t *testing.T) {
+	sources := "10.9.0.0/24,10.9.8.0/24"
+	ipt := newFakeIPTables()
+	fw := &IPTablesFirewall{ipt: ipt, chain: "OVPM-test"}
+	if err := fw.EnsureNAT(NAT{Source: sources, VPNIface: "tun0", OutIface: "eth0"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.SetForwardRules(sources, []Rule{{Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+	jumps := []string{
+		"-s 10.9.0.0/24 -m comment --comment OVPM-test -j OVPM-test",
+		"-s 10.9.8.0/24 -m comment --comment OVPM-test -j OVPM-test",
+	}
+	if got := ipt.chains["nat/POSTROUTING"]; !reflect.DeepEqual(got, jumps) {
+		t.Fatalf("POSTROUTING is expected to have a jump for each source: %v", got)
+	}
+	for _, jump := range jumps {
+		found := false
+		for _, rule := range ipt.chains["filter/FORWARD"] {
+			found = found || rule == jump
+		}
+		if !found {
+			t.Fatalf("FORWARD is expected to have %q: %v", jump, ipt.chains["filter/FORWARD"])
+		}
+	}
+
+	var script string
+	nft := &NFTablesFirewall{chain: "OVPM-test", run: func(input string, args ...string) (string, error) {
+		script = input
+		return "", nil
+	}}
+	nft.EnsureNAT(NAT{Source: sources, VPNIface: "tun0", OutIface: "eth0"})
+	nft.SetForwardRules(sources, []Rule{{Verdict: Drop}})
+	for _, line := range []string{
+		"ip saddr { 10.9.0.0/24, 10.9.8.0/24 } jump OVPM-test",
+		"ip saddr { 10.9.0.0/24, 10.9.8.0/24 } oifname \"eth0\" masquerade",
+	} {
+		if !strings.Contains(script, line) {
+			t.Errorf("ruleset is expected to contain %q:\n%s", line, script)
+		}
+	}
+}
+
+func TestIPTablesFailsClosed(t *testing.T) {
+	ipt := newFakeIPTables()
+	fw := &IPTablesFirewall{ipt: ipt, chain: "OVPM-vpn.example"}
+	if err := fw.SetForwardRules("10.9.0.0/24", []Rule{{Source: "10.9.0.2/32", Verdict: Return}, {Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain is left closed if the new rules can't be set.
+	ipt.failAppend = "-s 10.9.0.3/32 -j RETURN"
+	if err := fw.SetForwardRules("10.9.0.0/24", []Rule{{Source: "10.9.0.3/32", Verdict: Return}, {Verdict: Drop}}); err == nil {
+		t.Fatalf("rules are expected to fail")
+	}
+	if got := ipt.chains["filter/OVPM-vpn.example"]; !reflect.DeepEqual(got, []string{"-j DROP"}) {
+		t.Fatalf("chain is expected to be left closed: %v", got)
+	}
+
+	// The chain is opened once the rules are set again.
+	ipt.failAppend = ""
+	if err := fw.SetForwardRules("10.9.0.0/24", []Rule{{Source: "10.9.0.3/32", Verdict: Return}, {Verdict: Drop}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := ipt.chains["filter/OVPM-vpn.example"]; !reflect.DeepEqual(got, []string{"-s 10.9.0.3/32 -j RETURN", "-j DROP"}) {
+		t.Fatalf("chain is not as expected: %v", got)
+	}
+}
@@ -0,0 +1,320 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-iptables/iptables"
+)
+
+// iptablesClient is the part of the iptables api that the backend uses.
+type iptablesClient interface {
+	Exists(table, chain string, rulespec ...string) (bool, error)
+	Insert(table, chain string, pos int, rulespec ...string) error
+	Append(table, chain string, rulespec ...string) error
+	Delete(table, chain string, rulespec ...string) error
+	List(table, chain string) ([]string, error)
+	ChainExists(table, chain string) (bool, error)
+	ClearChain(table, chain string) error
+	ClearAndDeleteChain(table, chain string) error
+}
+
+// builtinChains are the built-in chains that ovpm owns rules in.
+var builtinChains = [][2]string{{"filter", "FORWARD"}, {"nat", "PREROUTING"}, {"nat", "POSTROUTING"}}
+
+// IPTablesFirewall manages the rules with iptables.
+//
+// Forward rules live in a dedicated chain of the filter table, which the FORWARD chain jumps to
+// for the traffic of the vpn clients. Likewise, the nat rules live in a chain of the same name in
+// the nat table, which the POSTROUTING chain jumps to. The rules that ovpm puts in the built-in chains are tagged
+// with a comment, so that the stale ones can be found and removed, even after a restart.
+type IPTablesFirewall struct {
+	ipt   iptablesClient
+	chain string
+
+	mu     sync.Mutex
+	nat    *NAT   // desired nat
+	source string // source network of the desired forward rules
+}
+
+// NewIPTables returns an iptables backend that keeps the forward rules in the given chain.
+func NewIPTables(chain string) (*IPTablesFirewall, error) {
+	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
+	if err != nil {
+		return nil, fmt.Errorf("can not create new iptables object: %v", err)
+	}
+	return &IPTablesFirewall{ipt: ipt, chain: chain}, nil
+}
+
+// Name returns the name of the backend.
+func (f *IPTablesFirewall) Name() string {
+	return IPTables
+}
+
+// ownedRule is a rule that ovpm puts in a built-in chain.
+type ownedRule struct {
+	table, chain string
+	spec         []string
+	first        bool // whether it goes in front of the rest of the chain
+}
+
+// ownedRules returns the rules that should be in the built-in chains, tagged with the comment.
+func (f *IPTablesFirewall) ownedRules() []ownedRule {
+	tag := []string{"-m", "comment", "--comment", f.chain}
+	var rules []ownedRule
+	for _, source := range splitSources(f.source) {
+		rules = append(rules, ownedRule{"filter", "FORWARD", append([]string{"-s", source}, append(tag, "-j", f.chain)...), true})
+	}
+	if f.nat != nil {
+		for _, source := range splitSources(f.nat.Source) {
+			rules = append(rules, ownedRule{"nat", "POSTROUTING", append([]string{"-s", source}, append(tag, "-j", f.chain)...), false})
+		}
+		rules = append(rules,
+			ownedRule{"filter", "FORWARD", append([]string{"-i", f.nat.OutIface, "-o", f.nat.VPNIface, "-m", "state", "--state", "RELATED,ESTABLISHED"}, append(tag, "-j", "ACCEPT")...), false},
+			ownedRule{"filter", "FORWARD", append([]string{"-i", f.nat.VPNIface, "-o", f.nat.OutIface}, append(tag, "-j", "ACCEPT")...), false},
+		)
+		for _, m := range f.nat.Maps {
+			for _, source := range splitSources(f.nat.Source) {
+				rules = append(rules, ownedRule{"nat", "PREROUTING", append([]string{"-s", source, "-d", m.Virtual}, append(tag, "-j", "NETMAP", "--to", m.Real)...), false})
+			}
+		}
+	}
+	return rules
+}
+
+// listOwned returns the specs of the tagged rules in the given built-in chain.
+func (f *IPTablesFirewall) listOwned(table, chain string) ([][]string, error) {
+	rules, err := f.ipt.List(table, chain)
+	if err != nil {
+		return nil, err
+	}
+	var owned [][]string
+	for _, r := range rules {
+		fields := strings.Fields(r)
+		if len(fields) < 2 || fields[0] != "-A" {
+			continue
+		}
+		spec := fields[2:]
+		tagged := false
+		for i := range spec {
+			spec[i] = strings.Trim(spec[i], `"`)
+			if i > 0 && spec[i-1] == "--comment" && spec[i] == f.chain {
+				tagged = true
+			}
+		}
+		if tagged {
+			owned = append(owned, spec)
+		}
+	}
+	return owned, nil
+}
+
+// reconcile makes the tagged rules in the built-in chains match the desired nat and forward rules.
+func (f *IPTablesFirewall) reconcile() error {
+	desired := f.ownedRules()
+	for _, bc := range builtinChains {
+		owned, err := f.listOwned(bc[0], bc[1])
+		if err != nil {
+			return err
+		}
+	next:
+		for _, spec := range owned {
+			for _, r := range desired {
+				if r.table == bc[0] && r.chain == bc[1] && strings.Join(r.spec, " ") == strings.Join(spec, " ") {
+					continue next
+				}
+			}
+			if err := f.ipt.Delete(bc[0], bc[1], spec...); err != nil {
+				return fmt.Errorf("can not delete stale rule from %s: %v", bc[1], err)
+			}
+		}
+	}
+	for _, r := range desired {
+		exists, err := f.ipt.Exists(r.table, r.chain, r.spec...)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if r.first {
+			err = f.ipt.Insert(r.table, r.chain, 1, r.spec...)
+		} else {
+			err = f.ipt.Append(r.table, r.chain, r.spec...)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// natSpecs returns the rule specs of the nat chain.
+func natSpecs(nat NAT) [][]string {
+	var specs [][]string
+	for _, o := range nat.Overrides {
+		specs = append(specs, append([]string{"-d", o.Destination}, natJump(o.Mode, o.SNATTo)...))
+	}
+	if nat.Mode != NoNAT {
+		specs = append(specs, append([]string{"-o", nat.OutIface}, natJump(nat.Mode, nat.SNATTo)...))
+	}
+	return specs
+}
+
+// natJump returns the target of the translation.
+func natJump(mode NATMode, snatTo string) []string {
+	switch mode {
+	case SNAT:
+		return []string{"-j", "SNAT", "--to-source", snatTo}
+	case NoNAT:
+		return []string{"-j", "RETURN"}
+	}
+	return []string{"-j", "MASQUERADE"}
+}
+
+// EnsureNAT translates the traffic of the vpn clients as described by nat.
+//
+// The rules of the previous nat are removed.
+func (f *IPTablesFirewall) EnsureNAT(nat NAT) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.ipt.ClearChain("nat", f.chain); err != nil {
+		return fmt.Errorf("can not clear chain %s: %v", f.chain, err)
+	}
+	for _, spec := range natSpecs(nat) {
+		if err := f.ipt.Append("nat", f.chain, spec...); err != nil {
+			return err
+		}
+	}
+	f.nat = &nat
+	return f.reconcile()
+}
+
+// ruleSpec returns the iptables rule spec of the rule.
+func ruleSpec(r Rule) []string {
+	var spec []string
+	if r.Established {
+		spec = append(spec, "-m", "state", "--state", "RELATED,ESTABLISHED")
+	}
+	if r.Source != "" {
+		spec = append(spec, "-s", r.Source)
+	}
+	if r.Destination != "" && r.Mapped {
+		spec = append(spec, "-m", "conntrack", "--ctorigdst", r.Destination)
+	} else if r.Destination != "" {
+		spec = append(spec, "-d", r.Destination)
+	}
+	if r.Proto != "" {
+		spec = append(spec, "-p", r.Proto)
+		if len(r.Ports) > 0 {
+			var ports []string
+			for _, p := range r.Ports {
+				ports = append(ports, strings.Replace(p, "-", ":", 1))
+			}
+			spec = append(spec, "-m", "multiport", "--dports", strings.Join(ports, ","))
+		}
+	}
+	return append(spec, "-j", strings.ToUpper(r.Verdict.String()))
+}
+
+// SetForwardRules replaces the rules of the chain.
+//
+// The chain fails closed while it's being rebuilt: a drop rule goes in front of it first, and it's
+// only removed once the old rules are deleted and all of the new ones are in place. If any of them
+// can't be, the drop rule is left in place, so the traffic of the clients is dropped rather than
+// forwarded without the rules until they are set again.
+func (f *IPTablesFirewall) SetForwardRules(source string, rules []Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if exists, err := f.ipt.ChainExists("filter", f.chain); err != nil {
+		return err
+	} else if !exists {
+		if err := f.ipt.ClearChain("filter", f.chain); err != nil {
+			return fmt.Errorf("can not create chain %s: %v", f.chain, err)
+		}
+	}
+	listed, err := f.ipt.List("filter", f.chain)
+	if err != nil {
+		return err
+	}
+	if err := f.ipt.Insert("filter", f.chain, 1, "-j", "DROP"); err != nil {
+		return fmt.Errorf("can not close chain %s: %v", f.chain, err)
+	}
+	// The old rules are deleted by their positions, right after the drop rule.
+	for _, r := range listed {
+		if !strings.HasPrefix(r, "-A ") {
+			continue
+		}
+		if err := f.ipt.Delete("filter", f.chain, "2"); err != nil {
+			return fmt.Errorf("can not delete old rule from %s, it's left closed: %v", f.chain, err)
+		}
+	}
+	for _, r := range rules {
+		if err := f.ipt.Append("filter", f.chain, ruleSpec(r)...); err != nil {
+			return fmt.Errorf("can not append rule to %s, it's left closed: %v", f.chain, err)
+		}
+	}
+	if err := f.ipt.Delete("filter", f.chain, "1"); err != nil {
+		return fmt.Errorf("can not open chain %s: %v", f.chain, err)
+	}
+
+	// Send the traffic of the clients through the chain.
+	f.source = source
+	return f.reconcile()
+}
+
+// Cleanup removes the chains along with the jumps to them, and the rules that let the nat traffic
+// be forwarded.
+func (f *IPTablesFirewall) Cleanup() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nat, f.source = nil, ""
+	if err := f.reconcile(); err != nil {
+		return err
+	}
+	for _, table := range []string{"filter", "nat"} {
+		if exists, err := f.ipt.ChainExists(table, f.chain); err == nil && exists {
+			if err := f.ipt.ClearAndDeleteChain(table, f.chain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Dump returns the rules of the chains, and the rules of the built-in chains that are owned by ovpm.
+func (f *IPTablesFirewall) Dump() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var lines []string
+	tables := map[string][]string{}
+	for _, table := range []string{"filter", "nat"} {
+		if exists, err := f.ipt.ChainExists(table, f.chain); err != nil {
+			return "", err
+		} else if exists {
+			rules, err := f.ipt.List(table, f.chain)
+			if err != nil {
+				return "", err
+			}
+			tables[table] = append(tables[table], rules...)
+		}
+	}
+	for _, bc := range builtinChains {
+		owned, err := f.listOwned(bc[0], bc[1])
+		if err != nil {
+			return "", err
+		}
+		for _, spec := range owned {
+			tables[bc[0]] = append(tables[bc[0]], strings.Join(append([]string{"-A", bc[1]}, spec...), " "))
+		}
+	}
+	for _, table := range []string{"filter", "nat"} {
+		if len(tables[table]) == 0 {
+			continue
+		}
+		lines = append(lines, "*"+table)
+		lines = append(lines, tables[table]...)
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
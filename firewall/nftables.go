@@ -0,0 +1,203 @@
+package firewall
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// nftTable is the nftables table that holds all of the rules owned by ovpm.
+const nftTable = "ovpm"
+
+// NFTablesFirewall manages the rules with nftables.
+//
+// All of the rules live in a dedicated table, which is replaced atomically whenever the rules
+// change; so the rules are never seen half applied.
+type NFTablesFirewall struct {
+	chain string
+
+	// run runs nft with the given arguments, feeding it the given input.
+	run func(input string, args ...string) (string, error)
+
+	mu     sync.Mutex
+	nat    *NAT
+	source string
+	rules  []Rule
+}
+
+// NewNFTables returns an nftables backend that keeps the forward rules in the given chain.
+func NewNFTables(chain string) (*NFTablesFirewall, error) {
+	nft, err := exec.LookPath("nft")
+	if err != nil {
+		return nil, fmt.Errorf("nft executable can not be found: %v", err)
+	}
+	return &NFTablesFirewall{chain: chain, run: func(input string, args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(nft, args...)
+		cmd.Stdin = strings.NewReader(input)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("nft %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	}}, nil
+}
+
+// Name returns the name of the backend.
+func (f *NFTablesFirewall) Name() string {
+	return NFTables
+}
+
+// EnsureNAT translates the traffic of the vpn clients as described by nat.
+func (f *NFTablesFirewall) EnsureNAT(nat NAT) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prev := f.nat
+	f.nat = &nat
+	if err := f.apply(); err != nil {
+		f.nat = prev
+		return err
+	}
+	return nil
+}
+
+// SetForwardRules replaces the rules of the chain.
+func (f *NFTablesFirewall) SetForwardRules(source string, rules []Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prevSource, prevRules := f.source, f.rules
+	f.source, f.rules = source, rules
+	if err := f.apply(); err != nil {
+		f.source, f.rules = prevSource, prevRules
+		return err
+	}
+	return nil
+}
+
+// Cleanup removes the table.
+func (f *NFTablesFirewall) Cleanup() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Declaring the table first makes the deletion succeed even if it doesn't exist.
+	if _, err := f.run(fmt.Sprintf("table ip %s\ndelete table ip %s\n", nftTable, nftTable), "-f", "-"); err != nil {
+		return err
+	}
+	f.nat, f.source, f.rules = nil, "", nil
+	return nil
+}
+
+// Dump returns the table.
+func (f *NFTablesFirewall) Dump() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out, err := f.run("", "list", "table", "ip", nftTable)
+	if err != nil && f.nat == nil && f.source == "" {
+		return "", nil // nothing is applied yet
+	}
+	return out, err
+}
+
+// apply replaces the table with the current rules.
+func (f *NFTablesFirewall) apply() error {
+	_, err := f.run(f.ruleset(), "-f", "-")
+	return err
+}
+
+// ruleset returns the nft script that replaces the table with the current rules.
+func (f *NFTablesFirewall) ruleset() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "table ip %s\n", nftTable)
+	fmt.Fprintf(&b, "delete table ip %s\n", nftTable)
+	fmt.Fprintf(&b, "table ip %s {\n", nftTable)
+
+	b.WriteString("\tchain forward {\n")
+	b.WriteString("\t\ttype filter hook forward priority 0; policy accept;\n")
+	if f.source != "" {
+		fmt.Fprintf(&b, "\t\tip saddr %s jump %s\n", nftAddrs(f.source), f.chain)
+	}
+	if f.nat != nil {
+		fmt.Fprintf(&b, "\t\tiifname %q oifname %q ct state related,established accept\n", f.nat.OutIface, f.nat.VPNIface)
+		fmt.Fprintf(&b, "\t\tiifname %q oifname %q accept\n", f.nat.VPNIface, f.nat.OutIface)
+	}
+	b.WriteString("\t}\n")
+
+	if f.source != "" {
+		fmt.Fprintf(&b, "\tchain %s {\n", f.chain)
+		for _, r := range f.rules {
+			fmt.Fprintf(&b, "\t\t%s\n", nftRule(r))
+		}
+		b.WriteString("\t}\n")
+	}
+
+	if f.nat != nil && len(f.nat.Maps) > 0 {
+		var maps []string
+		for _, m := range f.nat.Maps {
+			maps = append(maps, m.Virtual+" : "+m.Real)
+		}
+		b.WriteString("\tchain prerouting {\n")
+		b.WriteString("\t\ttype nat hook prerouting priority -100; policy accept;\n")
+		fmt.Fprintf(&b, "\t\tip saddr %s dnat ip prefix to ip daddr map { %s }\n", nftAddrs(f.nat.Source), strings.Join(maps, ", "))
+		b.WriteString("\t}\n")
+	}
+
+	if f.nat != nil {
+		b.WriteString("\tchain postrouting {\n")
+		b.WriteString("\t\ttype nat hook postrouting priority 100; policy accept;\n")
+		for _, o := range f.nat.Overrides {
+			fmt.Fprintf(&b, "\t\tip saddr %s ip daddr %s %s\n", nftAddrs(f.nat.Source), o.Destination, nftNAT(o.Mode, o.SNATTo))
+		}
+		if f.nat.Mode != NoNAT {
+			fmt.Fprintf(&b, "\t\tip saddr %s oifname %q %s\n", nftAddrs(f.nat.Source), f.nat.OutIface, nftNAT(f.nat.Mode, f.nat.SNATTo))
+		}
+		b.WriteString("\t}\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// nftAddrs returns the nft expression of the comma separated networks, an anonymous set if there
+// are more than one.
+func nftAddrs(source string) string {
+	sources := splitSources(source)
+	if len(sources) == 1 {
+		return sources[0]
+	}
+	return "{ " + strings.Join(sources, ", ") + " }"
+}
+
+// nftNAT returns the nft statement of the translation.
+func nftNAT(mode NATMode, snatTo string) string {
+	switch mode {
+	case SNAT:
+		return "snat to " + snatTo
+	case NoNAT:
+		return "return"
+	}
+	return "masquerade"
+}
+
+// nftRule returns the nft statement of the rule.
+func nftRule(r Rule) string {
+	var parts []string
+	if r.Established {
+		parts = append(parts, "ct state related,established")
+	}
+	if r.Source != "" {
+		parts = append(parts, "ip saddr "+r.Source)
+	}
+	if r.Destination != "" && r.Mapped {
+		parts = append(parts, "ct original ip daddr "+r.Destination)
+	} else if r.Destination != "" {
+		parts = append(parts, "ip daddr "+r.Destination)
+	}
+	if r.Proto != "" {
+		if len(r.Ports) > 0 {
+			parts = append(parts, fmt.Sprintf("%s dport { %s }", r.Proto, strings.Join(r.Ports, ", ")))
+		} else {
+			parts = append(parts, "ip protocol "+r.Proto)
+		}
+	}
+	return strings.Join(append(parts, r.Verdict.String()), " ")
+}
@@ -0,0 +1,378 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/master312/ovpm/firewall"
+	"go.uber.org/thriftrw/ptr"
+)
+
+func TestParseACLRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		forward string
+		ok      bool
+	}{
+		{"tcp/22,443", "from 10.9.0.2/32 to 10.10.0.0/16 tcp/22,443 accept", true},
+		{"UDP/53", "from 10.9.0.2/32 to 10.10.0.0/16 udp/53 accept", true},
+		{"tcp/8000-8100", "from 10.9.0.2/32 to 10.10.0.0/16 tcp/8000-8100 accept", true},
+		{"tcp", "from 10.9.0.2/32 to 10.10.0.0/16 tcp accept", true},
+		{"icmp", "from 10.9.0.2/32 to 10.10.0.0/16 icmp accept", true},
+		{"icmp/8", "", false},
+		{"sctp/22", "", false},
+		{"tcp/0", "", false},
+		{"tcp/65536", "", false},
+		{"tcp/22,", "", false},
+		{"tcp/100-10", "", false},
+		{"tcp/1,2,3,4,5,6,7,8,9,10,11,12,13,14-15", "from 10.9.0.2/32 to 10.10.0.0/16 tcp/1,2,3,4,5,6,7,8,9,10,11,12,13,14-15 accept", true},
+		{"tcp/1,2,3,4,5,6,7,8,9,10,11,12,13,14,15-16", "", false},
+	}
+	for _, tt := range tests {
+		rule, err := ParseACLRule(tt.rule)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseACLRule(%s) error = %v, want ok %t", tt.rule, err, tt.ok)
+			continue
+		}
+		if tt.ok {
+			if forward := rule.forwardRule("10.9.0.2/32", "10.10.0.0/16").String(); forward != tt.forward {
+				t.Errorf("ParseACLRule(%s) forward rule = %s, want %s", tt.rule, forward, tt.forward)
+			}
+		}
+	}
+}
+
+func TestFirewallRules(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, err := CreateNewUser("alice", "1234", false, 0, false, "")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	bob, err := CreateNewUser("bob", "1234", true, 0, false, "")
+	if err != nil {
+		t.Fatalf("user can not be created: %v", err)
+	}
+	office, err := CreateNewNetwork("office", "10.10.0.0/16", ROUTE, "")
+	if err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+	lab, err := CreateNewNetwork("lab", "10.20.0.0/16", ROUTE, "")
+	if err != nil {
+		t.Fatalf("network can not be created: %v", err)
+	}
+	eng, err := CreateGroup("engineering", "")
+	if err != nil {
+		t.Fatalf("group can not be created: %v", err)
+	}
+	if err := eng.AddMember("bob"); err != nil {
+		t.Fatalf("bob can not be added to the group: %v", err)
+	}
+	if err := office.Associate("alice"); err != nil {
+		t.Fatalf("alice can not be associated: %v", err)
+	}
+	if err := lab.AssociateGroup("engineering"); err != nil {
+		t.Fatalf("group can not be associated: %v", err)
+	}
+	aliceIP, bobIP := alice.getIP().String()+"/32", bob.getIP().String()+"/32"
+
+	// Test:
+	if err := office.SetRules("bob", []string{"tcp/22"}); err == nil {
+		t.Fatalf("rules are not expected to be set for an unassociated user")
+	}
+	if err := office.SetRules("alice", []string{"tcp/22,443", "icmp"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := office.SetRules("alice", []string{"foo"}); err == nil {
+		t.Fatalf("invalid rules are not expected to be set")
+	}
+	if err := lab.SetGroupRules("engineering", []string{"udp/53"}); err != nil {
+		t.Fatal(err)
+	}
+	office, _ = GetNetwork("office")
+	if acls := office.GetACLs(); len(acls) != 1 || acls[0].GetUsername() != "alice" || strings.Join(acls[0].GetRules(), " ") != "tcp/22,443 icmp" {
+		t.Fatalf("office is expected to have the rules of alice: %+v", acls)
+	}
+
+	rules, err := svr.firewallRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range rules {
+		got = append(got, r.String())
+	}
+	want := []string{
+		"established accept",
+		"from " + aliceIP + " to 10.10.0.0/16 tcp/22,443 accept",
+		"from " + aliceIP + " to 10.10.0.0/16 icmp accept",
+		"from " + bobIP + " to 10.20.0.0/16 udp/53 accept",
+		"to 10.20.0.0/16 drop",
+		"to 10.10.0.0/16 drop",
+		"from " + aliceIP + " return", // bob is no-gw
+		"drop",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("firewall rules are not as expected:\ngot:  %s\nwant: %s", strings.Join(got, "\n      "), strings.Join(want, "\n      "))
+	}
+
+	// Emitting applies the rules with the firewall backend.
+	if err := svr.emitFirewall(); err != nil {
+		t.Fatal(err)
+	}
+	backend, dump, err := svr.DumpFirewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend != "fake" || !strings.Contains(dump, want[1]) {
+		t.Fatalf("firewall dump is not as expected: %s\n%s", backend, dump)
+	}
+
+	// Direct associations without rules lift the group's restrictions.
+	lab.Associate("bob")
+	rules, _ = svr.firewallRules()
+	if r := rules[3].String(); r != "from "+bobIP+" to 10.20.0.0/16 accept" {
+		t.Fatalf("bob is expected to have unrestricted access to lab: %s", r)
+	}
+
+	// Dissociating drops the rules.
+	if err := office.Dissociate("alice"); err != nil {
+		t.Fatal(err)
+	}
+	office, _ = GetNetwork("office")
+	if acls := office.GetACLs(); len(acls) != 0 {
+		t.Fatalf("rules are not expected to survive the dissociation: %+v", acls)
+	}
+}
+
+func TestFirewallNAT(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	fw, _ := svr.getFirewall()
+	fake := fw.(*firewall.Fake)
+
+	// Test:
+	svr.StartVPNProc()
+	if status := waitNAT(); !status.Enabled || status.Attempts != 1 {
+		t.Fatalf("nat is expected to be enabled: %+v", status)
+	}
+	if nat := fake.NAT(); nat == nil || nat.Source != "10.9.0.0/24" {
+		t.Fatalf("nat is expected to masquerade the vpn network: %+v", nat)
+	}
+
+	// Changing the vpn network replaces the nat.
+	if err := svr.Update("10.8.0.0/24", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	waitNAT()
+	if nat := fake.NAT(); nat == nil || nat.Source != "10.8.0.0/24" {
+		t.Fatalf("nat is expected to masquerade the new vpn network: %+v", nat)
+	}
+
+	// Stopping removes the rules.
+	svr.StopVPNProc()
+	if status := GetNATStatus(); status.Enabled {
+		t.Fatalf("nat is not expected to be enabled after stopping: %+v", status)
+	}
+	if nat := fake.NAT(); nat != nil || len(fake.ForwardRules()) != 0 {
+		t.Fatalf("rules are expected to be cleaned up: %+v %v", nat, fake.ForwardRules())
+	}
+}
+
+func TestServerUpdateNAT(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	lan, _ := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, "")
+	office, _ := CreateNewNetwork("office", "10.10.0.0/16", ROUTE, "")
+	fw, _ := svr.getFirewall()
+	fake := fw.(*firewall.Fake)
+	svr.StartVPNProc()
+	defer svr.StopVPNProc()
+
+	// Test:
+	if svr.GetNATMode() != "masquerade" {
+		t.Fatalf("nat mode is expected to be masquerade by default, got %s", svr.GetNATMode())
+	}
+	for _, tt := range []struct {
+		mode, snatTo string
+		egress       *string
+	}{
+		{"bridge", "", nil},
+		{"snat", "", nil},
+		{"snat", "foo", nil},
+		{"none", "203.0.113.5", nil},
+		{"", "", ptr.String("eth 0")},
+	} {
+		if err := svr.UpdateNAT(tt.mode, tt.snatTo, tt.egress); err == nil {
+			t.Errorf("UpdateNAT(%s, %s) is expected to fail", tt.mode, tt.snatTo)
+		}
+	}
+	if svr.GetNATMode() != "masquerade" || svr.GetEgressIface() != "" {
+		t.Fatalf("failed updates are not expected to change the nat")
+	}
+
+	if err := svr.UpdateNAT("snat", "203.0.113.5", ptr.String("eth1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := lan.SetNAT("none", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := office.SetNAT("none", ""); err == nil {
+		t.Fatalf("nat is not expected to be overridden for ROUTE networks")
+	}
+	if err := lan.SetNAT("snat", ""); err == nil {
+		t.Fatalf("snat override is expected to require an address")
+	}
+	waitNAT()
+	want := firewall.NAT{
+		Source:    "10.9.0.0/24",
+		VPNIface:  "tun0",
+		OutIface:  "eth1",
+		Mode:      firewall.SNAT,
+		SNATTo:    "203.0.113.5",
+		Overrides: []firewall.NATOverride{{Destination: "192.168.1.0/24", Mode: firewall.NoNAT}},
+	}
+	if nat := fake.NAT(); nat == nil || !reflect.DeepEqual(*nat, want) {
+		t.Fatalf("nat is not as expected:\ngot:  %+v\nwant: %+v", nat, want)
+	}
+	lan, _ = GetNetwork("lan")
+	if lan.GetNATMode() != "none" {
+		t.Fatalf("nat override of lan is expected to be persisted: %s", lan.GetNATMode())
+	}
+
+	// Switching to the routed mode drops the snat address, and the override is removed.
+	if err := svr.UpdateNAT("none", "", ptr.String("")); err != nil {
+		t.Fatal(err)
+	}
+	if err := lan.SetNAT("", ""); err != nil {
+		t.Fatal(err)
+	}
+	waitNAT()
+	if nat := fake.NAT(); nat == nil || nat.Mode != firewall.NoNAT || nat.SNATTo != "" || nat.OutIface != "eth0" || len(nat.Overrides) != 0 {
+		t.Fatalf("nat is not as expected: %+v", nat)
+	}
+}
+
+func TestNetworkMapping(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", true, 0, false, "")
+	siteA, _ := CreateNewNetwork("site_a", "192.168.1.0/24", ROUTE, "")
+	siteA.Associate("alice")
+	fw, _ := svr.getFirewall()
+	fake := fw.(*firewall.Fake)
+	svr.StartVPNProc()
+	defer svr.StopVPNProc()
+	aliceIP := alice.getIP().String() + "/32"
+
+	// Test:
+	for _, cidr := range []string{"foo", "10.200.0.0/16", "10.9.0.0/24", "192.168.1.0/24"} {
+		if err := siteA.SetMapping(cidr); err == nil {
+			t.Errorf("site_a is not expected to be mapped to %s", cidr)
+		}
+	}
+	if err := siteA.SetMapping("10.200.1.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	siteB, err := CreateNewNetwork("site_b", "192.168.1.0/24", SERVERNET, "")
+	if err != nil {
+		t.Fatalf("site_b is expected to be defined with the addresses of the mapped site_a: %v", err)
+	}
+	siteB.Associate("alice")
+	if err := siteB.SetMapping("10.200.1.0/24"); err == nil {
+		t.Fatalf("site_b is not expected to be mapped to the virtual network of site_a")
+	}
+	if err := siteB.SetMapping("10.200.2.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	siteA, _ = GetNetwork("site_a")
+	if siteA.GetMappedCIDR() != "10.200.1.0/24" {
+		t.Fatalf("mapping of site_a is expected to be persisted: %s", siteA.GetMappedCIDR())
+	}
+
+	ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]
+	for _, route := range []string{`push "route 10.200.1.0 255.255.255.0"`, `push "route 10.200.2.0 255.255.255.0"`} {
+		if !strings.Contains(ccd, route) {
+			t.Fatalf("ccd is expected to push the virtual network: %s\n%s", route, ccd)
+		}
+	}
+	if strings.Contains(ccd, "192.168.1.0") {
+		t.Fatalf("ccd is not expected to push the real network:\n%s", ccd)
+	}
+
+	rules, err := svr.firewallRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range rules {
+		got = append(got, r.String())
+	}
+	want := []string{
+		"established accept",
+		"from " + aliceIP + " to mapped 10.200.1.0/24 accept",
+		"from " + aliceIP + " to mapped 10.200.2.0/24 accept",
+		"to mapped 10.200.1.0/24 drop",
+		"to 192.168.1.0/24 drop",
+		"to mapped 10.200.2.0/24 drop",
+		"to 192.168.1.0/24 drop",
+		"drop",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("firewall rules are not as expected:\ngot:  %s\nwant: %s", strings.Join(got, "\n      "), strings.Join(want, "\n      "))
+	}
+
+	waitNAT()
+	wantMaps := []firewall.NetMap{{Virtual: "10.200.1.0/24", Real: "192.168.1.0/24"}, {Virtual: "10.200.2.0/24", Real: "192.168.1.0/24"}}
+	if nat := fake.NAT(); nat == nil || !reflect.DeepEqual(nat.Maps, wantMaps) {
+		t.Fatalf("nat is expected to map the networks: %+v", nat)
+	}
+
+	// Removing the mapping pushes the real network again, as long as the networks can be told apart.
+	if err := siteB.SetMapping(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := siteA.SetMapping(""); err == nil {
+		t.Fatalf("both networks are not expected to be unmapped")
+	}
+	if err := siteB.SetMapping("10.200.2.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if err := siteA.SetMapping(""); err != nil {
+		t.Fatal(err)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, `push "route 192.168.1.0 255.255.255.0`) {
+		t.Fatalf("ccd is expected to push the real network after unmapping:\n%s", ccd)
+	}
+	waitNAT()
+	if nat := fake.NAT(); nat == nil || len(nat.Maps) != 1 {
+		t.Fatalf("nat is expected to map only site-b: %+v", nat)
+	}
+}
+
+// waitNAT waits for the nat to be enabled.
+func waitNAT() NATStatus {
+	for i := 0; i < 50 && !GetNATStatus().Enabled; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return GetNATStatus()
+}
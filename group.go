@@ -0,0 +1,262 @@
+package ovpm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// dbGroupModel is database model for user groups.
+//
+// Networks can be associated with groups as well as with users; members of a group have access
+// to the group's networks. Groups also carry defaults that are applied to their members.
+type dbGroupModel struct {
+	gorm.Model
+
+	Name        string `gorm:"unique_index"`
+	Description string
+	NoGW        bool           `gorm:"not null;default:false"` // don't push the vpn server as the default gw to the members
+	DNS         string         // comma separated dns servers to push to the members instead of the server's
+	Pushes      string         // newline separated extra options to push to the members
+	IPPool      string         // name of the ip pool that the members lease their addresses from
+	Users       []*dbUserModel `gorm:"many2many:group_users;"`
+}
+
+// Group represents a group of vpn users.
+type Group struct {
+	dbGroupModel
+}
+
+// CreateGroup creates a new user group.
+func CreateGroup(name, description string) (*Group, error) {
+	if !govalidator.Matches(name, "^[\\w\\.\\-]+$") {
+		return nil, fmt.Errorf("validation error: group name `%s` can only contain letters, numbers, underscores, dots and dashes", name)
+	}
+	if _, err := GetGroup(name); err == nil {
+		return nil, fmt.Errorf("group %s already exists", name)
+	}
+
+	group := dbGroupModel{Name: name, Description: description}
+	db.Create(&group)
+	if db.NewRecord(&group) {
+		return nil, fmt.Errorf("can not create group in database: %s", name)
+	}
+	logrus.Infof("group created: %s", name)
+	return &Group{dbGroupModel: group}, nil
+}
+
+// GetGroup returns the group with the given name.
+func GetGroup(name string) (*Group, error) {
+	var group dbGroupModel
+	db.Preload("Users").Where(&dbGroupModel{Name: name}).First(&group)
+	if db.NewRecord(&group) {
+		return nil, fmt.Errorf("group not found: %s", name)
+	}
+	return &Group{dbGroupModel: group}, nil
+}
+
+// GetAllGroups returns all of the groups.
+func GetAllGroups() ([]*Group, error) {
+	var dbGroups []*dbGroupModel
+	if err := db.Preload("Users").Order("name").Find(&dbGroups).Error; err != nil {
+		return nil, err
+	}
+
+	var groups []*Group
+	for _, g := range dbGroups {
+		groups = append(groups, &Group{dbGroupModel: *g})
+	}
+	return groups, nil
+}
+
+// Update updates the description and the member defaults of the group.
+//
+// dns is the list of dns servers to push to the members instead of the server's; pushes are the
+// extra options to push to the members, e.g. `route 10.10.0.0 255.255.0.0`.
+func (g *Group) Update(description string, noGW bool, dns []string, pushes []string) error {
+	for _, ip := range dns {
+		if !govalidator.IsIPv4(ip) {
+			return fmt.Errorf("validation error: dns server `%s` must be an IPv4 address", ip)
+		}
+	}
+	for _, push := range pushes {
+		if err := validatePushOption(push); err != nil {
+			return err
+		}
+	}
+
+	g.Description = description
+	g.NoGW = noGW
+	g.DNS = strings.Join(dns, ",")
+	g.Pushes = strings.Join(pushes, "\n")
+	db.Save(&g.dbGroupModel)
+	logrus.Infof("group updated: %s", g.Name)
+	return TheServer().EmitWithRestart()
+}
+
+// validatePushOption returns an error if the option can't be pushed to the clients as is.
+func validatePushOption(option string) error {
+	if strings.TrimSpace(option) == "" {
+		return fmt.Errorf("validation error: push option can not be empty")
+	}
+	if strings.ContainsAny(option, "\"\r\n\\") {
+		return fmt.Errorf("validation error: push option `%s` can not contain quotes, backslashes or line breaks", option)
+	}
+	if directive := strings.Fields(option)[0]; !pushDirectives[directive] {
+		return fmt.Errorf("validation error: `%s` is not allowed to be pushed", directive)
+	}
+	return nil
+}
+
+// Delete deletes the group. Its members lose access to the networks that are associated with the group.
+func (g *Group) Delete() error {
+	db.Model(&g.dbGroupModel).Association("Users").Clear()
+	db.Exec("DELETE FROM network_groups WHERE db_group_model_id = ?", g.ID)
+	deleteNetworkACLs("group_id = ?", g.ID)
+	db.Unscoped().Delete(&g.dbGroupModel)
+	logrus.Infof("group deleted: %s", g.Name)
+	return TheServer().EmitWithRestart()
+}
+
+// AddMember adds the user with the given username to the group.
+func (g *Group) AddMember(username string) error {
+	user, err := GetUser(username)
+	if err != nil {
+		return fmt.Errorf("user can not be fetched: %v", err)
+	}
+	if g.hasMember(user.ID) {
+		return fmt.Errorf("user %s is already a member of the group %s", username, g.Name)
+	}
+
+	userAssoc := db.Model(&g.dbGroupModel).Association("Users")
+	userAssoc.Append(&user.dbUserModel)
+	if userAssoc.Error != nil {
+		return fmt.Errorf("membership failed: %v", userAssoc.Error)
+	}
+	logrus.Infof("user '%s' is added to the group '%s'", username, g.Name)
+	return TheServer().EmitWithRestart()
+}
+
+// RemoveMember removes the user with the given username from the group.
+func (g *Group) RemoveMember(username string) error {
+	user, err := GetUser(username)
+	if err != nil {
+		return fmt.Errorf("user can not be fetched: %v", err)
+	}
+	if !g.hasMember(user.ID) {
+		return fmt.Errorf("user %s is not a member of the group %s", username, g.Name)
+	}
+
+	userAssoc := db.Model(&g.dbGroupModel).Association("Users")
+	userAssoc.Delete(&user.dbUserModel)
+	if userAssoc.Error != nil {
+		return fmt.Errorf("membership removal failed: %v", userAssoc.Error)
+	}
+	logrus.Infof("user '%s' is removed from the group '%s'", username, g.Name)
+	return TheServer().EmitWithRestart()
+}
+
+// hasMember returns whether the user with the given id is a member of the group.
+func (g *Group) hasMember(userID uint) bool {
+	var users []dbUserModel
+	db.Model(&g.dbGroupModel).Association("Users").Find(&users)
+	for _, u := range users {
+		if u.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetName returns the group's name.
+func (g *Group) GetName() string {
+	return g.Name
+}
+
+// GetDescription returns the group's description.
+func (g *Group) GetDescription() string {
+	return g.Description
+}
+
+// IsNoGW returns whether the vpn server isn't pushed as the default gw to the members.
+func (g *Group) IsNoGW() bool {
+	return g.NoGW
+}
+
+// GetDNS returns the dns servers that are pushed to the members instead of the server's.
+func (g *Group) GetDNS() []string {
+	if g.DNS == "" {
+		return nil
+	}
+	return strings.Split(g.DNS, ",")
+}
+
+// GetPushes returns the extra options that are pushed to the members.
+func (g *Group) GetPushes() []string {
+	if g.Pushes == "" {
+		return nil
+	}
+	return strings.Split(g.Pushes, "\n")
+}
+
+// GetMemberUsernames returns the usernames of the group's members.
+func (g *Group) GetMemberUsernames() []string {
+	var usernames []string
+	for _, u := range g.Users {
+		usernames = append(usernames, u.Username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// GetAssociatedNetworkNames returns the names of the networks that are associated with the group.
+func (g *Group) GetAssociatedNetworkNames() []string {
+	var names []string
+	for _, n := range GetAllNetworks() {
+		for _, ng := range n.Groups {
+			if ng.ID == g.ID {
+				names = append(names, n.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// GetGroups returns the groups that the user is a member of.
+func (u *User) GetGroups() ([]*Group, error) {
+	var ids []uint
+	db.Table("group_users").Where("db_user_model_id = ?", u.ID).Pluck("db_group_model_id", &ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbGroups []*dbGroupModel
+	if err := db.Where("id IN (?)", ids).Order("name").Find(&dbGroups).Error; err != nil {
+		return nil, err
+	}
+	var groups []*Group
+	for _, g := range dbGroups {
+		groups = append(groups, &Group{dbGroupModel: *g})
+	}
+	return groups, nil
+}
+
+// isNoGW returns whether the vpn server isn't pushed as the default gw to the user, either because
+// of the user itself or one of the given groups of the user.
+func (u *User) isNoGW(groups []*Group) bool {
+	noGW := u.IsNoGW()
+	for _, g := range groups {
+		noGW = noGW || g.IsNoGW()
+	}
+	return noGW
+}
+
+// deleteGroupMemberships removes the user with the given id from all of the groups.
+func deleteGroupMemberships(userID uint) {
+	db.Exec("DELETE FROM group_users WHERE db_user_model_id = ?", userID)
+}
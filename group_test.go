@@ -0,0 +1,189 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGroupMembership(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+	CreateNewUser("bob", "1234", false, 0, false, "")
+
+	// Test:
+	var createtests = []struct {
+		name string
+		ok   bool
+	}{
+		{"engineering", true},
+		{"sales.emea", true},
+		{"engineering", false}, // duplicate
+		{"with space", false},
+		{"", false},
+	}
+	for _, tt := range createtests {
+		if _, err := CreateGroup(tt.name, ""); (err == nil) != tt.ok {
+			t.Errorf("CreateGroup(%s) error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+
+	eng, _ := GetGroup("engineering")
+	if err := eng.AddMember("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := eng.AddMember("alice"); err == nil {
+		t.Fatalf("user is not expected to be added twice")
+	}
+	if err := eng.AddMember("nobody"); err == nil {
+		t.Fatalf("unknown user is not expected to be added")
+	}
+	eng.AddMember("bob")
+	eng, _ = GetGroup("engineering")
+	if usernames := eng.GetMemberUsernames(); len(usernames) != 2 {
+		t.Fatalf("engineering is expected to have 2 members: %v", usernames)
+	}
+
+	// Deleting the user takes its memberships.
+	bob, _ := GetUser("bob")
+	bob.Delete()
+	eng, _ = GetGroup("engineering")
+	if usernames := eng.GetMemberUsernames(); len(usernames) != 1 || usernames[0] != "alice" {
+		t.Fatalf("deleted user is not expected to stay in the group: %v", usernames)
+	}
+
+	if err := eng.RemoveMember("alice"); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := GetUser("alice")
+	if groups, _ := alice.GetGroups(); len(groups) != 0 {
+		t.Fatalf("alice is not expected to be in any groups: %v", groups)
+	}
+}
+
+func TestGroupUpdate(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	group, _ := CreateGroup("engineering", "")
+
+	// Test:
+	var updatetests = []struct {
+		name   string
+		dns    []string
+		pushes []string
+		ok     bool
+	}{
+		{"defaults", nil, nil, true},
+		{"dns and pushes", []string{"10.0.0.53", "10.0.1.53"}, []string{"route 10.10.0.0 255.255.0.0", "dhcp-option DOMAIN corp.example.com"}, true},
+		{"invalid dns", []string{"ns1.example.com"}, nil, false},
+		{"quoted push", nil, []string{`route 10.10.0.0 255.255.0.0" "foo`}, false},
+		{"multiline push", nil, []string{"route 10.10.0.0 255.255.0.0\nfoo"}, false},
+		{"empty push", nil, []string{" "}, false},
+	}
+	for _, tt := range updatetests {
+		if err := group.Update("", true, tt.dns, tt.pushes); (err == nil) != tt.ok {
+			t.Errorf("%s: Update() error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+
+	group, _ = GetGroup("engineering")
+	if !group.IsNoGW() || len(group.GetDNS()) != 2 || len(group.GetPushes()) != 2 {
+		t.Fatalf("group is expected to keep the last valid update: %+v", group.dbGroupModel)
+	}
+}
+
+func TestGroupNetworkAssociation(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+	CreateNewUser("bob", "1234", false, 0, false, "")
+	CreateNewUser("carol", "1234", false, 0, false, "")
+	office, _ := CreateNewNetwork("office", "10.10.0.0/16", ROUTE, "")
+	lab, _ := CreateNewNetwork("lab", "10.20.0.0/16", ROUTE, "")
+	servers, _ := CreateNewNetwork("servers", "172.16.0.0/24", SERVERNET, "")
+	eng, _ := CreateGroup("engineering", "")
+	eng.AddMember("alice")
+	eng.AddMember("bob")
+
+	// Test:
+	if err := office.AssociateGroup("engineering"); err != nil {
+		t.Fatal(err)
+	}
+	if err := office.AssociateGroup("engineering"); err == nil {
+		t.Fatalf("group is not expected to be associated twice")
+	}
+	if err := office.AssociateGroup("nobody"); err == nil {
+		t.Fatalf("unknown group is not expected to be associated")
+	}
+	servers.AssociateGroup("engineering")
+	lab.Associate("alice")
+	lab.Associate("carol")
+
+	office, _ = GetNetwork("office")
+	if names := office.GetAssociatedGroupNames(); len(names) != 1 || names[0] != "engineering" {
+		t.Fatalf("office is expected to be associated with engineering: %v", names)
+	}
+
+	// Routes are the union of the direct and the group associations.
+	ccd := func(username string) string {
+		return fs[filepath.Join(_DefaultVPNCCDPath, username)]
+	}
+	var routetests = []struct {
+		username string
+		route    string
+		want     bool
+	}{
+		{"alice", `push "route 10.10.0.0 255.255.0.0`, true},
+		{"alice", `push "route 10.20.0.0 255.255.0.0`, true},
+		{"bob", `push "route 10.10.0.0 255.255.0.0`, true},
+		{"bob", `push "route 10.20.0.0 255.255.0.0`, false},
+		{"carol", `push "route 10.10.0.0 255.255.0.0`, false},
+		{"carol", `push "route 10.20.0.0 255.255.0.0`, true},
+	}
+	for _, tt := range routetests {
+		if got := strings.Contains(ccd(tt.username), tt.route); got != tt.want {
+			t.Errorf("%s: route %s is expected to be pushed: %t\n%s", tt.username, tt.route, tt.want, ccd(tt.username))
+		}
+	}
+
+	// Group defaults are applied to the members.
+	if err := eng.Update("", true, []string{"10.0.0.53"}, []string{"dhcp-option DOMAIN corp.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`push "route 172.16.0.0 255.255.255.0"`, `push-remove "dhcp-option DNS"`, `push "dhcp-option DNS 10.0.0.53"`, `push "dhcp-option DOMAIN corp.example.com"`} {
+		if !strings.Contains(ccd("bob"), want) {
+			t.Errorf("bob's ccd is expected to contain %s:\n%s", want, ccd("bob"))
+		}
+	}
+	if strings.Contains(ccd("bob"), "redirect-gateway") {
+		t.Errorf("bob is not expected to get the vpn server as default gw:\n%s", ccd("bob"))
+	}
+	if !strings.Contains(ccd("carol"), "redirect-gateway") || strings.Contains(ccd("carol"), "10.0.0.53") {
+		t.Errorf("carol is not expected to get the group defaults:\n%s", ccd("carol"))
+	}
+
+	// Deleting the group takes its associations.
+	if err := office.DissociateGroup("engineering"); err != nil {
+		t.Fatal(err)
+	}
+	if err := eng.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(ccd("alice"), "10.10.0.0") || strings.Contains(ccd("alice"), "172.16.0.0") || !strings.Contains(ccd("alice"), "10.20.0.0") {
+		t.Errorf("alice is expected to keep only her direct associations:\n%s", ccd("alice"))
+	}
+	servers, _ = GetNetwork("servers")
+	if names := servers.GetAssociatedGroupNames(); len(names) != 0 {
+		t.Fatalf("deleted group is not expected to stay associated: %v", names)
+	}
+}
@@ -0,0 +1,254 @@
+package ovpm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// InvitationPath is the path of the REST server that the invitations are accepted under.
+const InvitationPath = "/api/v1/invitation/"
+
+// dbInvitationModel is database model for the invitations of the pending users.
+//
+// Only the hash of the token is stored; the token itself is shown once, when the invitation is
+// issued.
+type dbInvitationModel struct {
+	gorm.Model
+	UserID     uint   `gorm:"unique_index"`
+	Hash       string `gorm:"unique_index"` // hex encoded sha256 of the token
+	IssuedBy   string // username of the admin that issued the invitation
+	ExpiresAt  time.Time
+	TOTPSecret string // secret that the user enrols in TOTP with, if it's required when it accepts
+}
+
+// Invitation represents an invitation of a pending user to set its password and download its vpn
+// profile.
+type Invitation struct {
+	dbInvitationModel
+
+	username string
+}
+
+// CreatePendingUser creates a user without a password, like CreateNewUser does. The user can
+// neither connect nor log in until it accepts the invitation that is issued to it with Invite.
+func CreatePendingUser(username string, nogw bool, hostid uint32, admin bool, description string) (*User, error) {
+	user, err := createNewUser(username, "", nogw, hostid, admin, description)
+	if err != nil {
+		return nil, err
+	}
+	db.Model(&user.dbUserModel).Update("Pending", true)
+	user.Pending = true
+
+	// EmitWithRestart server config
+	if err = TheServer().EmitWithRestart(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Invite issues an invitation to the pending user that expires after the ttl, or
+// DefaultInvitationTTL if it's zero. The previous invitation of the user is revoked.
+//
+// The url of the invitation is returned along with it. It's also sent to the user, if the user has
+// an email address and the email notifications are enabled.
+func (u *User) Invite(issuer string, ttl time.Duration) (*Invitation, string, error) {
+	if ttl < 0 {
+		return nil, "", fmt.Errorf("validation error: invitation ttl can not be negative")
+	}
+	if ttl == 0 {
+		ttl = DefaultInvitationTTL
+	}
+	if !u.Pending {
+		return nil, "", fmt.Errorf("user is not pending: %s", u.Username)
+	}
+	base := GetWebURL()
+	if base == "" {
+		return nil, "", fmt.Errorf("web url is not configured")
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	// The secret is generated even if TOTP isn't required yet, in case it's required by the time
+	// the invitation is accepted.
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	invitation := dbInvitationModel{
+		UserID:     u.ID,
+		Hash:       hashToken(token),
+		IssuedBy:   issuer,
+		ExpiresAt:  time.Now().Add(ttl),
+		TOTPSecret: secret,
+	}
+	deleteInvitations(u.ID)
+	db.Create(&invitation)
+	if db.NewRecord(&invitation) {
+		return nil, "", fmt.Errorf("invitation can not be created: %s", u.Username)
+	}
+	link := base + InvitationPath + token
+
+	audit(AuditInvitationIssued, issuer, u.Username, "", fmt.Sprintf("expires at %s", invitation.ExpiresAt.Format(time.RFC3339)))
+	logrus.Infof("user invited: %s", u.Username)
+	if u.Email != "" && IsSMTPEnabled() {
+		err := sendMail([]string{u.Email}, MailInvitation, struct {
+			Username, Hostname, Link string
+			ExpiresAt                time.Time
+		}{u.Username, TheServer().GetHostname(), link, invitation.ExpiresAt})
+		if err != nil {
+			logrus.Errorf("invitation can not be sent to %s: %v", u.Email, err)
+		}
+	}
+	return &Invitation{dbInvitationModel: invitation, username: u.Username}, link, nil
+}
+
+// CheckInvitation returns the username of the pending user that the invitation is issued to, or
+// an error if it's invalid or expired. If TOTP is required, the secret that the user must enrol
+// with is returned as well.
+func CheckInvitation(token string) (string, string, error) {
+	invitation, user, err := findInvitation(token)
+	if err != nil {
+		return "", "", err
+	}
+	if !IsTOTPRequired() {
+		return user.Username, "", nil
+	}
+	return user.Username, invitation.TOTPSecret, nil
+}
+
+// AcceptInvitation sets the password of the pending user that the invitation is issued to, and
+// activates it. If TOTP is required, the user is enrolled with the secret of the invitation as
+// well; the code must be generated with it, to prove that the user's authenticator is set up. The
+// username and the vpn profile of the user are returned.
+func AcceptInvitation(token, password, code, remoteAddr string) (string, string, error) {
+	if password == "" {
+		return "", "", fmt.Errorf("validation error: password can not be empty")
+	}
+	invitation, user, err := findInvitation(token)
+	if err != nil {
+		return "", "", err
+	}
+	var step int64
+	if IsTOTPRequired() {
+		if step, err = matchTOTP(invitation.TOTPSecret, code, time.Now(), 0); err != nil {
+			return "", "", err
+		}
+		user.TOTPSecret, user.TOTPLastStep = invitation.TOTPSecret, step
+	}
+
+	// The invitation is deleted before the user is activated, so that it can't be accepted twice
+	// concurrently.
+	q := db.Unscoped().Delete(invitation)
+	if q.Error != nil || q.RowsAffected != 1 {
+		return "", "", fmt.Errorf("invitation is invalid")
+	}
+	if err := user.setPassword(password); err != nil {
+		return "", "", err
+	}
+	user.Pending = false
+	db.Save(user.dbUserModel)
+	if err := TheServer().Emit(); err != nil {
+		return "", "", err
+	}
+	details := fmt.Sprintf("invited by '%s'", invitation.IssuedBy)
+	if user.HasTOTP() {
+		details += ", enrolled in totp"
+	}
+	audit(AuditInvitationAccepted, user.Username, user.Username, remoteAddr, details)
+	logrus.Infof("invitation accepted: %s", user.Username)
+
+	profile, err := TheServer().DumpsClientConfig(user.Username)
+	if err != nil {
+		return "", "", err
+	}
+	return user.Username, profile, nil
+}
+
+// findInvitation returns the invitation of the token along with its pending user.
+func findInvitation(token string) (*dbInvitationModel, *User, error) {
+	var invitation dbInvitationModel
+	db.Where(&dbInvitationModel{Hash: hashToken(token)}).First(&invitation)
+	if db.NewRecord(&invitation) {
+		return nil, nil, fmt.Errorf("invitation is invalid")
+	}
+	if !time.Now().Before(invitation.ExpiresAt) {
+		return nil, nil, fmt.Errorf("invitation is expired")
+	}
+	var u dbUserModel
+	db.Where("service_account = ?", false).First(&u, invitation.UserID)
+	if db.NewRecord(&u) || !u.Pending {
+		return nil, nil, fmt.Errorf("invitation is invalid")
+	}
+	return &invitation, &User{dbUserModel: u}, nil
+}
+
+// GetInvitations returns the invitations of the pending users, including the expired ones.
+func GetInvitations() ([]*Invitation, error) {
+	var dbInvitations []*dbInvitationModel
+	q := db.Order("expires_at").Find(&dbInvitations)
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	var invitations []*Invitation
+	for _, i := range dbInvitations {
+		var u dbUserModel
+		db.First(&u, i.UserID)
+		if db.NewRecord(&u) {
+			continue
+		}
+		invitations = append(invitations, &Invitation{dbInvitationModel: *i, username: u.Username})
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation revokes the invitation of the pending user and deletes the user, since it can't
+// be used without accepting an invitation.
+func RevokeInvitation(username, revoker string) error {
+	user, err := GetUser(username)
+	if err != nil {
+		return err
+	}
+	if !user.Pending {
+		return fmt.Errorf("user is not pending: %s", username)
+	}
+	if err := user.Delete(); err != nil {
+		return err
+	}
+	audit(AuditInvitationRevoked, revoker, username, "", "pending user is deleted")
+	return nil
+}
+
+// deleteInvitations deletes the invitations of the user.
+func deleteInvitations(userID uint) {
+	db.Unscoped().Where(&dbInvitationModel{UserID: userID}).Delete(&dbInvitationModel{})
+}
+
+// GetUsername returns the username of the pending user that the invitation is issued to.
+func (i *Invitation) GetUsername() string {
+	return i.username
+}
+
+// GetIssuedBy returns the username of the admin that issued the invitation.
+func (i *Invitation) GetIssuedBy() string {
+	return i.IssuedBy
+}
+
+// GetCreatedAt returns the time that the invitation is issued at.
+func (i *Invitation) GetCreatedAt() time.Time {
+	return i.CreatedAt
+}
+
+// GetExpiresAt returns the time that the invitation expires at.
+func (i *Invitation) GetExpiresAt() time.Time {
+	return i.ExpiresAt
+}
+
+// IsExpired returns whether the invitation is expired.
+func (i *Invitation) IsExpired() bool {
+	return !time.Now().Before(i.ExpiresAt)
+}
@@ -0,0 +1,170 @@
+package ovpm
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvitations(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	smtpd := newSMTPStub(t)
+	defer smtpd.close()
+	defer SetSMTPConfig(nil)
+	err := SetSMTPConfig(&SMTPConfig{Host: "127.0.0.1", Port: smtpd.port(), TLS: SMTPTLSNone, From: "ovpm@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test:
+	alice, err := CreatePendingUser("alice", false, 0, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !alice.IsPending() || !alice.IsDisabled() {
+		t.Fatalf("alice is expected to be pending and disabled")
+	}
+	if _, err := Authenticate("alice", ""); err == nil {
+		t.Fatalf("pending user is not expected to log in")
+	}
+	if _, _, err := alice.Invite("admin", 0); err == nil {
+		t.Fatalf("invitation is not expected to be issued without a web url")
+	}
+	defer SetWebURL("")
+	if err := SetWebURL("https://vpn.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := alice.Invite("admin", -time.Hour); err == nil {
+		t.Fatalf("negative ttl is expected to be rejected")
+	}
+	bob, _ := CreateNewUser("bob", "1234", false, 0, false, "")
+	if _, _, err := bob.Invite("admin", 0); err == nil {
+		t.Fatalf("active user is not expected to be invited")
+	}
+
+	// Invitations are emailed to the users that have email addresses.
+	alice.SetEmail("alice@example.com")
+	invitation, url, err := alice.Invite("admin", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl := time.Until(invitation.GetExpiresAt()); ttl <= DefaultInvitationTTL-time.Minute || ttl > DefaultInvitationTTL {
+		t.Fatalf("invitation is expected to expire after the default ttl: %s", invitation.GetExpiresAt())
+	}
+	msg := smtpd.receive(t)
+	match := regexp.MustCompile(`https://vpn\.example\.com/api/v1/invitation/([0-9a-f]+)`).FindStringSubmatch(msg.data)
+	if match == nil || match[0] != url || msg.to[0] != "alice@example.com" {
+		t.Fatalf("invitation is expected to be emailed to alice:\n%s", msg.data)
+	}
+	token := match[1]
+
+	// Reissued invitations replace the previous ones.
+	if _, url, err = alice.Invite("admin", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	smtpd.receive(t)
+	if _, _, err := CheckInvitation(token); err == nil {
+		t.Fatalf("previous invitation is expected to be revoked")
+	}
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+InvitationPath)
+	if username, secret, err := CheckInvitation(token); err != nil || username != "alice" || secret != "" {
+		t.Fatalf("invitation is expected to be issued to alice without totp: %s %s %v", username, secret, err)
+	}
+
+	// Accepting the invitation sets the password and activates the user.
+	if _, _, err := AcceptInvitation(token, "", "", ""); err == nil {
+		t.Fatalf("empty password is expected to be rejected")
+	}
+	if _, _, err := AcceptInvitation("foo", "5678", "", ""); err == nil {
+		t.Fatalf("invalid invitation is expected to be rejected")
+	}
+	username, profile, err := AcceptInvitation(token, "5678", "", "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if username != "alice" || !strings.Contains(profile, "<cert>") {
+		t.Fatalf("profile of alice is expected to be returned: %s\n%s", username, profile)
+	}
+	if _, err := Authenticate("alice", "5678"); err != nil {
+		t.Fatalf("alice is expected to log in with the new password: %v", err)
+	}
+	if _, _, err := AcceptInvitation(token, "9012", "", ""); err == nil {
+		t.Fatalf("invitation is not expected to be accepted twice")
+	}
+
+	// Invited users enrol in TOTP when they accept, if it's required.
+	SetTOTPRequired(true)
+	defer SetTOTPRequired(false)
+	erin, _ := CreatePendingUser("erin", false, 0, false, "")
+	_, url, _ = erin.Invite("admin", time.Hour)
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+InvitationPath)
+	_, secret, err := CheckInvitation(token)
+	if err != nil || secret == "" {
+		t.Fatalf("totp secret is expected to be returned for the invitation: %v", err)
+	}
+	if _, _, err := AcceptInvitation(token, "5678", "000000", ""); err == nil {
+		t.Fatalf("invitation is not expected to be accepted with an incorrect totp code")
+	}
+	key, _ := totpEncoding.DecodeString(secret)
+	code := totpCode(key, time.Now().Unix()/totpPeriod)
+	if _, _, err := AcceptInvitation(token, "5678", code, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AuthenticateFrom("erin", "5678", ""); err == nil {
+		t.Fatalf("erin is not expected to log in without a totp code")
+	}
+	if _, err := AuthenticateWithTOTP("erin", "5678", code, ""); err == nil {
+		t.Fatalf("totp code of the enrolment is not expected to be accepted again")
+	}
+	if _, err := AuthenticateWithTOTP("erin", "5678", totpCode(key, time.Now().Unix()/totpPeriod+1), ""); err != nil {
+		t.Fatalf("erin is expected to log in with a totp code: %v", err)
+	}
+	erin.Delete()
+	SetTOTPRequired(false)
+
+	// Invitations are listed until they are accepted or revoked.
+	carol, _ := CreatePendingUser("carol", false, 0, false, "")
+	carol.Invite("admin", time.Hour)
+	dave, _ := CreatePendingUser("dave", false, 0, false, "")
+	_, url, _ = dave.Invite("admin", time.Hour)
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+InvitationPath)
+	db.Model(&dbInvitationModel{}).Where(&dbInvitationModel{Hash: hashToken(token)}).Update("ExpiresAt", time.Now().Add(-time.Minute))
+	if _, _, err := AcceptInvitation(token, "5678", "", ""); err == nil {
+		t.Fatalf("expired invitation is not expected to be accepted")
+	}
+	invitations, err := GetInvitations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitations) != 2 || invitations[0].GetUsername() != "dave" || !invitations[0].IsExpired() || invitations[1].GetUsername() != "carol" || invitations[1].IsExpired() {
+		t.Fatalf("invitations of dave (expired) and carol are expected to be listed: %+v", invitations)
+	}
+
+	// Revoking an invitation deletes its pending user.
+	if err := RevokeInvitation("alice", "admin"); err == nil {
+		t.Fatalf("invitation of an active user is not expected to be revoked")
+	}
+	if err := RevokeInvitation("dave", "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUser("dave"); err == nil {
+		t.Fatalf("dave is expected to be deleted")
+	}
+	if invitations, _ := GetInvitations(); len(invitations) != 1 {
+		t.Fatalf("only the invitation of carol is expected to be left: %+v", invitations)
+	}
+
+	// Issuing, accepting and revoking the invitations are audited.
+	for action, count := range map[string]int{AuditInvitationIssued: 5, AuditInvitationAccepted: 2, AuditInvitationRevoked: 1} {
+		entries, _ := GetAuditEntries(action, 0)
+		if len(entries) != count {
+			t.Fatalf("%s is expected to be audited %d times: %d", action, count, len(entries))
+		}
+	}
+}
@@ -0,0 +1,667 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// dbLeaseModel is a database model for the vpn addresses that are leased to the users.
+//
+// A user keeps the lease until it's released, i.e. the user is deleted, the user's static address
+// or ip pool is changed or the vpn network is changed.
+type dbLeaseModel struct {
+	UserID    uint   `gorm:"primary_key;auto_increment:false"`
+	HostID    uint32 `gorm:"unique_index"`
+	Pool      string // name of the pool that the address is leased from, empty for static reservations
+	Static    bool
+	CreatedAt time.Time
+}
+
+// dbIPPoolModel is a database model for the address ranges that the dynamic addresses are leased
+// from.
+type dbIPPoolModel struct {
+	gorm.Model
+
+	Name   string `gorm:"unique_index"`
+	Start  string // first address of the range
+	End    string // last address of the range
+	Subnet string // network of the pool in the CIDR form, if it's beside the vpn network
+}
+
+// IPPool represents an address range that the dynamic addresses are leased from.
+//
+// Pools are either carved from the vpn network, or they are whole networks beside it. Pools that
+// are assigned to groups or users are reserved for them; the rest of the users lease from the
+// other pools carved from the vpn network, or from the whole vpn network, which is represented by
+// the DefaultIPPoolName pool, when there are none.
+type IPPool struct {
+	dbIPPoolModel
+}
+
+var leaseMu sync.Mutex
+
+// CreateIPPool creates a new range of the vpn network to lease the dynamic addresses from.
+//
+// Pools are tried in the order they are created.
+func CreateIPPool(name, start, end string) (*IPPool, error) {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	if err := validateIPPoolName(name); err != nil {
+		return nil, err
+	}
+	for _, ip := range []string{start, end} {
+		if !govalidator.IsIPv4(ip) {
+			return nil, fmt.Errorf("validation error: `%s` must be an IPv4 address", ip)
+		}
+		if err := svr.checkHostID(IP2HostID(net.ParseIP(ip).To4())); err != nil {
+			return nil, err
+		}
+	}
+	pool := &IPPool{dbIPPoolModel{Name: name, Start: start, End: end}}
+	first, last := pool.hostIDs()
+	if first > last {
+		return nil, fmt.Errorf("validation error: ip pool %s starts after it ends", name)
+	}
+	pools, err := getIPPools()
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range pools {
+		if otherFirst, otherLast := other.hostIDs(); first <= otherLast && otherFirst <= last {
+			return nil, fmt.Errorf("validation error: ip pool %s overlaps with %s (%s-%s)", name, other.Name, other.Start, other.End)
+		}
+	}
+	return pool, pool.create()
+}
+
+// CreateSubnetIPPool creates a new pool of a whole network beside the vpn network to lease the
+// dynamic addresses from, e.g. to tell the clients apart by their subnets downstream.
+//
+// The first address of the network is the gateway of the clients, and the server routes the network
+// to the vpn interface.
+func CreateSubnetIPPool(name, subnet string) (*IPPool, error) {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	if err := validateIPPoolName(name); err != nil {
+		return nil, err
+	}
+	ip, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("validation error: `%s` must be an IPv4 network in the CIDR form", subnet)
+	}
+	if ones, _ := ipnet.Mask.Size(); ones > 30 {
+		return nil, fmt.Errorf("validation error: ip pool %s needs a network that is at least a /30", name)
+	}
+	if err := checkCIDRConflict(CIDRBlock{Kind: CIDRPool, Name: name, CIDR: ipnet.String()}, nil); err != nil {
+		return nil, err
+	}
+	first, last := subnetHostIDs(ipnet)
+	pool := &IPPool{dbIPPoolModel{
+		Name:   name,
+		Start:  HostID2IP(first + 1).String(), // first is the gateway
+		End:    HostID2IP(last).String(),
+		Subnet: ipnet.String(),
+	}}
+	return pool, pool.create()
+}
+
+func validateIPPoolName(name string) error {
+	if !govalidator.Matches(name, "^[\\w\\.]+$") {
+		return fmt.Errorf("validation error: pool name `%s` can only contain letters, numbers, underscores and dots", name)
+	}
+	if name == DefaultIPPoolName {
+		return fmt.Errorf("validation error: pool name `%s` is reserved", name)
+	}
+	if _, err := GetIPPool(name); err == nil {
+		return fmt.Errorf("ip pool %s already exists", name)
+	}
+	return nil
+}
+
+func (p *IPPool) create() error {
+	db.Create(&p.dbIPPoolModel)
+	if db.NewRecord(&p.dbIPPoolModel) {
+		return fmt.Errorf("can not create ip pool in database: %s", p.Name)
+	}
+	logrus.Infof("ip pool created: %s (%s-%s)", p.Name, p.Start, p.End)
+	return TheServer().EmitWithRestart()
+}
+
+// GetIPPool returns the ip pool with the given name.
+func GetIPPool(name string) (*IPPool, error) {
+	var pool dbIPPoolModel
+	q := db.Where(&dbIPPoolModel{Name: name}).First(&pool)
+	if q.RecordNotFound() {
+		return nil, fmt.Errorf("ip pool not found: %s", name)
+	}
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	return &IPPool{pool}, nil
+}
+
+// GetAllIPPools returns the ip pools in the order they are tried, and the DefaultIPPoolName pool
+// if the users that have no pools assigned lease from it.
+func GetAllIPPools() ([]*IPPool, error) {
+	plan, err := newIPPlan()
+	if err != nil {
+		return nil, err
+	}
+	pools := plan.pools
+	if general := plan.generalPools(); len(general) == 1 && general[0].ID == 0 {
+		pools = append([]*IPPool{general[0]}, pools...)
+	}
+	return pools, nil
+}
+
+// getIPPools returns the ip pools that are created by the users.
+func getIPPools() ([]*IPPool, error) {
+	var dbPools []*dbIPPoolModel
+	q := db.Order("id").Find(&dbPools)
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	var pools []*IPPool
+	for _, p := range dbPools {
+		pools = append(pools, &IPPool{*p})
+	}
+	return pools, nil
+}
+
+// defaultIPPool returns the pool that covers the whole vpn network, except the server address.
+func (svr *Server) defaultIPPool() *IPPool {
+	first, last := subnetHostIDs(svr.vpnNet())
+	return &IPPool{dbIPPoolModel{
+		Name:  DefaultIPPoolName,
+		Start: HostID2IP(first + 1).String(), // first is the server
+		End:   HostID2IP(last).String(),
+	}}
+}
+
+// subnetHostIDs returns the first and the last host addresses of the network.
+func subnetHostIDs(ipnet *net.IPNet) (first, last uint32) {
+	ones, bits := ipnet.Mask.Size()
+	network := IP2HostID(ipnet.IP.To4())
+	return network + 1, network + uint32(1)<<uint(bits-ones) - 2
+}
+
+// Delete deletes the ip pool. Pools that have leases or that are assigned can't be deleted.
+func (p *IPPool) Delete() error {
+	if p.ID == 0 {
+		return fmt.Errorf("ip pool %s is not created", p.Name)
+	}
+	var count int
+	db.Model(&dbLeaseModel{}).Where("pool = ?", p.Name).Count(&count)
+	if count > 0 {
+		return fmt.Errorf("ip pool %s can not be deleted because it has %d leases", p.Name, count)
+	}
+	if groups, users := p.GetAssignees(); len(groups) > 0 || len(users) > 0 {
+		return fmt.Errorf("ip pool %s can not be deleted because it's assigned to groups %v and users %v", p.Name, groups, users)
+	}
+	db.Unscoped().Delete(&p.dbIPPoolModel)
+	logrus.Infof("ip pool deleted: %s", p.Name)
+	return TheServer().EmitWithRestart()
+}
+
+// GetName returns the name of the ip pool.
+func (p *IPPool) GetName() string {
+	return p.Name
+}
+
+// GetStart returns the first address of the ip pool.
+func (p *IPPool) GetStart() string {
+	return p.Start
+}
+
+// GetEnd returns the last address of the ip pool.
+func (p *IPPool) GetEnd() string {
+	return p.End
+}
+
+// GetSubnet returns the network of the ip pool in the CIDR form, if it's beside the vpn network.
+func (p *IPPool) GetSubnet() string {
+	return p.Subnet
+}
+
+// GetAssignees returns the names of the groups and the users that the ip pool is assigned to.
+func (p *IPPool) GetAssignees() (groups, users []string) {
+	if p.ID == 0 {
+		return nil, nil
+	}
+	db.Model(&dbGroupModel{}).Where("ip_pool = ?", p.Name).Order("name").Pluck("name", &groups)
+	db.Model(&dbUserModel{}).Where("ip_pool = ?", p.Name).Order("username").Pluck("username", &users)
+	return groups, users
+}
+
+// Size returns the number of the addresses in the ip pool.
+func (p *IPPool) Size() int {
+	first, last := p.hostIDs()
+	return int(last-first) + 1
+}
+
+// Used returns the number of the addresses in the ip pool that are leased, including the static
+// reservations in its range.
+func (p *IPPool) Used() int {
+	first, last := p.hostIDs()
+	var count int
+	db.Model(&dbLeaseModel{}).Where("host_id BETWEEN ? AND ?", first, last).Count(&count)
+	return count
+}
+
+func (p *IPPool) hostIDs() (first, last uint32) {
+	return IP2HostID(net.ParseIP(p.Start).To4()), IP2HostID(net.ParseIP(p.End).To4())
+}
+
+func (p *IPPool) contains(hostID uint32) bool {
+	first, last := p.hostIDs()
+	return first <= hostID && hostID <= last
+}
+
+// subnet returns the network of the ip pool, if it's beside the vpn network.
+func (p *IPPool) subnet() *net.IPNet {
+	if p.Subnet == "" {
+		return nil
+	}
+	_, ipnet, err := net.ParseCIDR(p.Subnet)
+	if err != nil {
+		return nil
+	}
+	return ipnet
+}
+
+// subnetIPPools returns the networks of the ip pools that are beside the vpn network.
+func subnetIPPools() []*net.IPNet {
+	var subnets []string
+	db.Model(&dbIPPoolModel{}).Where("subnet <> ''").Order("id").Pluck("subnet", &subnets)
+	var ipnets []*net.IPNet
+	for _, subnet := range subnets {
+		if _, ipnet, err := net.ParseCIDR(subnet); err == nil {
+			ipnets = append(ipnets, ipnet)
+		}
+	}
+	return ipnets
+}
+
+// clientNets returns the networks that the vpn clients have addresses in.
+func (svr *Server) clientNets() []*net.IPNet {
+	return append([]*net.IPNet{svr.vpnNet()}, subnetIPPools()...)
+}
+
+// GetIPPool returns the name of the ip pool that is assigned to the user, if any.
+func (u *User) GetIPPool() string {
+	return u.IPPool
+}
+
+// SetIPPool assigns the ip pool to the user, so that the user leases its address from the pool
+// rather than the pool of its groups or the rest of the pools. Empty name unassigns it.
+func (u *User) SetIPPool(name string) error {
+	if name != "" {
+		if _, err := GetIPPool(name); err != nil {
+			return err
+		}
+	}
+	prev := u.IPPool
+	db.Model(&u.dbUserModel).Update("IPPool", name)
+	if _, err := leaseFor(u); err != nil {
+		db.Model(&u.dbUserModel).Update("IPPool", prev)
+		return fmt.Errorf("can not lease an ip to %s from %s: %v", u.Username, name, err)
+	}
+	logrus.Infof("ip pool of user %s is set to %s", u.Username, name)
+	return TheServer().EmitWithRestart()
+}
+
+// GetIPPool returns the name of the ip pool that is assigned to the group, if any.
+func (g *Group) GetIPPool() string {
+	return g.IPPool
+}
+
+// SetIPPool assigns the ip pool to the group, so that its members lease their addresses from the
+// pool, unless they have their own pools assigned. Empty name unassigns it.
+func (g *Group) SetIPPool(name string) error {
+	if name != "" {
+		if _, err := GetIPPool(name); err != nil {
+			return err
+		}
+	}
+	db.Model(&g.dbGroupModel).Update("IPPool", name)
+	for _, username := range g.GetMemberUsernames() {
+		user, err := GetUser(username)
+		if err != nil {
+			return err
+		}
+		if _, err := leaseFor(user); err != nil {
+			logrus.Errorf("can not lease an ip to %s from %s: %v", username, name, err)
+		}
+	}
+	logrus.Infof("ip pool of group %s is set to %s", g.Name, name)
+	return TheServer().EmitWithRestart()
+}
+
+// ipPlan is a snapshot of the ip pools that the leases are made from.
+type ipPlan struct {
+	svr      *Server
+	pools    []*IPPool // created pools, in order
+	byName   map[string]*IPPool
+	assigned map[string]bool // pools that are assigned to groups or users
+}
+
+func newIPPlan() (*ipPlan, error) {
+	pools, err := getIPPools()
+	if err != nil {
+		return nil, err
+	}
+	plan := &ipPlan{svr: TheServer(), pools: pools, byName: map[string]*IPPool{}, assigned: map[string]bool{}}
+	for _, pool := range pools {
+		plan.byName[pool.Name] = pool
+	}
+	var userPools, groupPools []string
+	db.Model(&dbUserModel{}).Where("ip_pool <> ''").Pluck("ip_pool", &userPools)
+	db.Model(&dbGroupModel{}).Where("ip_pool <> ''").Pluck("ip_pool", &groupPools)
+	for _, name := range append(userPools, groupPools...) {
+		plan.assigned[name] = true
+	}
+	return plan, nil
+}
+
+// assignedPool returns the pool that is assigned to the user or the first of its groups, if any.
+func (p *ipPlan) assignedPool(user *User, groups []*Group) *IPPool {
+	if user.IPPool != "" {
+		return p.byName[user.IPPool]
+	}
+	for _, group := range groups {
+		if group.IPPool != "" {
+			return p.byName[group.IPPool]
+		}
+	}
+	return nil
+}
+
+// generalPools returns the pools that the users that have no pools assigned lease from.
+func (p *ipPlan) generalPools() []*IPPool {
+	var pools []*IPPool
+	for _, pool := range p.pools {
+		if pool.Subnet == "" && !p.assigned[pool.Name] {
+			pools = append(pools, pool)
+		}
+	}
+	if len(pools) == 0 {
+		pools = append(pools, p.svr.defaultIPPool())
+	}
+	return pools
+}
+
+// poolsFor returns the pools that the user leases its dynamic address from.
+func (p *ipPlan) poolsFor(user *User, groups []*Group) []*IPPool {
+	if pool := p.assignedPool(user, groups); pool != nil {
+		return []*IPPool{pool}
+	}
+	return p.generalPools()
+}
+
+// isValid returns whether the user can keep the lease.
+func (p *ipPlan) isValid(lease *dbLeaseModel, user *User, groups []*Group) bool {
+	if user.HostID != 0 || lease.Static {
+		return lease.Static && lease.HostID == user.HostID
+	}
+	if pool := p.assignedPool(user, groups); pool != nil {
+		return lease.Pool == pool.Name
+	}
+	for _, pool := range p.pools {
+		if pool.contains(lease.HostID) && (pool.Subnet != "" || p.assigned[pool.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// free returns the first free address of the pools.
+func (p *ipPlan) free(pools []*IPPool) (uint32, *IPPool, error) {
+	var leased []uint32
+	db.Model(&dbLeaseModel{}).Pluck("host_id", &leased)
+	used := make(map[uint32]bool, len(leased))
+	for _, hostID := range leased {
+		used[hostID] = true
+	}
+	for _, pool := range pools {
+		first, last := pool.hostIDs()
+	next:
+		for hostID := first; hostID <= last; hostID++ {
+			if used[hostID] {
+				continue
+			}
+			if pool.ID == 0 {
+				// The whole vpn network excludes the created pools.
+				for _, other := range p.pools {
+					if other.contains(hostID) {
+						continue next
+					}
+				}
+			}
+			return hostID, pool, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("ip pools are exhausted, there are no free addresses left")
+}
+
+// ipNet returns the address of the lease along with the mask of its network.
+func (p *ipPlan) ipNet(lease *dbLeaseModel) *net.IPNet {
+	ipnet := &net.IPNet{IP: HostID2IP(lease.HostID), Mask: p.svr.vpnNet().Mask}
+	if pool := p.byName[lease.Pool]; pool != nil && pool.subnet() != nil {
+		ipnet.Mask = pool.subnet().Mask
+	}
+	return ipnet
+}
+
+// gateway returns the gateway of the clients that lease from a pool beside the vpn network.
+func (p *ipPlan) gateway(lease *dbLeaseModel) net.IP {
+	if pool := p.byName[lease.Pool]; pool != nil && pool.subnet() != nil {
+		first, _ := subnetHostIDs(pool.subnet())
+		return HostID2IP(first)
+	}
+	return nil
+}
+
+// checkHostID returns an error if the address can't be assigned to a user statically.
+func (svr *Server) checkHostID(hostID uint32) error {
+	ip := HostID2IP(hostID)
+	vpnNet := svr.vpnNet()
+	if !vpnNet.Contains(ip) {
+		return fmt.Errorf("ip %s, is out of vpn network %s", ip, vpnNet.String())
+	}
+	pool := svr.defaultIPPool()
+	if first, last := pool.hostIDs(); hostID == first-1 {
+		return fmt.Errorf("can't assign server's ip address to a user")
+	} else if hostID < first || hostID > last {
+		return fmt.Errorf("ip %s is the network or the broadcast address of the vpn network", ip)
+	}
+	return nil
+}
+
+// leaseFor leases an address to the user unless the user already has a valid lease, and returns
+// the lease.
+//
+// Users that have a static HostID get that address reserved. If it's leased dynamically to another
+// user, that user gets a new lease. The rest of the users lease from their pools.
+func leaseFor(user *User) (*dbLeaseModel, error) {
+	leaseMu.Lock()
+	defer leaseMu.Unlock()
+	plan, err := newIPPlan()
+	if err != nil {
+		return nil, err
+	}
+	return plan.lease(user)
+}
+
+// lease leases an address to the user, see leaseFor. leaseMu must be held.
+func (p *ipPlan) lease(user *User) (*dbLeaseModel, error) {
+	groups, err := user.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+	var lease dbLeaseModel
+	found := !db.Where("user_id = ?", user.ID).First(&lease).RecordNotFound()
+	if found && p.isValid(&lease, user, groups) {
+		return &lease, nil
+	}
+
+	if user.HostID == 0 {
+		hostID, pool, err := p.free(p.poolsFor(user, groups))
+		if err != nil {
+			return nil, err
+		}
+		db.Delete(&dbLeaseModel{}, "user_id = ?", user.ID)
+		lease = dbLeaseModel{UserID: user.ID, HostID: hostID, Pool: pool.Name}
+		if err := db.Create(&lease).Error; err != nil {
+			return nil, fmt.Errorf("can not lease ip %s: %v", HostID2IP(hostID), err)
+		}
+		return &lease, nil
+	}
+
+	if err := p.svr.checkHostID(user.HostID); err != nil {
+		return nil, err
+	}
+	var holder dbLeaseModel
+	if !db.Where("host_id = ?", user.HostID).First(&holder).RecordNotFound() && holder.UserID != user.ID {
+		if holder.Static {
+			return nil, fmt.Errorf("ip %s is already allocated", HostID2IP(user.HostID))
+		}
+		// Static reservations take precedence over the dynamic leases.
+		db.Delete(&dbLeaseModel{}, "user_id = ?", holder.UserID)
+		defer func() {
+			var holderUser dbUserModel
+			if db.First(&holderUser, holder.UserID).RecordNotFound() {
+				return
+			}
+			if _, err := p.lease(&User{dbUserModel: holderUser}); err != nil {
+				logrus.Errorf("can not lease a new ip to %s: %v", holderUser.Username, err)
+			}
+		}()
+	}
+	db.Delete(&dbLeaseModel{}, "user_id = ?", user.ID)
+	lease = dbLeaseModel{UserID: user.ID, HostID: user.HostID, Static: true}
+	if err := db.Create(&lease).Error; err != nil {
+		return nil, fmt.Errorf("can not reserve ip %s: %v", HostID2IP(user.HostID), err)
+	}
+	return &lease, nil
+}
+
+// releaseLease releases the address that is leased to the user.
+func releaseLease(userID uint) {
+	leaseMu.Lock()
+	defer leaseMu.Unlock()
+	db.Delete(&dbLeaseModel{}, "user_id = ?", userID)
+}
+
+// releaseAllLeases releases all of the addresses, e.g. when the vpn network is changed.
+func releaseAllLeases() {
+	leaseMu.Lock()
+	defer leaseMu.Unlock()
+	db.Delete(&dbLeaseModel{})
+}
+
+// migrateLeasesFromHostIDs leases the users the addresses that they had before the leases were
+// introduced, so that upgrading doesn't renumber them. The dynamic addresses were derived from
+// the order of the users' ids; each user got the next address after the server's that isn't
+// reserved statically, and the deleted users kept theirs taken.
+func migrateLeasesFromHostIDs() {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return
+	}
+	leaseMu.Lock()
+	defer leaseMu.Unlock()
+
+	var users []*dbUserModel
+	db.Unscoped().Where("service_account = ?", false).Order("id").Find(&users)
+	static := make(map[uint32]bool)
+	for _, u := range users {
+		if u.HostID != 0 {
+			static[u.HostID] = true
+		}
+	}
+	next := IP2HostID(svr.vpnNet().IP) + 2
+	for _, u := range users {
+		lease := dbLeaseModel{UserID: u.ID, HostID: u.HostID, Static: true}
+		if u.HostID == 0 {
+			for static[next] {
+				next++
+			}
+			lease = dbLeaseModel{UserID: u.ID, HostID: next, Pool: DefaultIPPoolName}
+			next++
+		}
+		if u.DeletedAt != nil {
+			continue
+		}
+		if err := db.Create(&lease).Error; err != nil {
+			logrus.Errorf("can not migrate ip %s of %s: %v", HostID2IP(lease.HostID), u.Username, err)
+		}
+	}
+	logrus.Infof("ip leases are migrated for %d users", len(users))
+}
+
+// getLeases returns the leases of the users by their ids.
+func getLeases() map[uint]*dbLeaseModel {
+	var leases []*dbLeaseModel
+	db.Find(&leases)
+	byUser := make(map[uint]*dbLeaseModel, len(leases))
+	for _, lease := range leases {
+		byUser[lease.UserID] = lease
+	}
+	return byUser
+}
+
+// getUserByIP returns the user that the address is leased to.
+func getUserByIP(ip net.IP) (*User, error) {
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("ip %s is not an IPv4 address", ip)
+	}
+	var lease dbLeaseModel
+	if db.Where("host_id = ?", IP2HostID(ip.To4())).First(&lease).RecordNotFound() {
+		return nil, fmt.Errorf("ip %s is not leased", ip)
+	}
+	var user dbUserModel
+	if db.First(&user, lease.UserID).RecordNotFound() {
+		return nil, fmt.Errorf("user #%d not found", lease.UserID)
+	}
+	return &User{dbUserModel: user}, nil
+}
+
+// getLease returns the address that is leased to the user, or nil if the user has no lease yet.
+//
+// It doesn't lease one. The addresses are leased when the users are created or their pools are
+// changed, and the leases that are missing or out of the users' pools (e.g. of the users that are
+// created before the leases) are renewed when the configs are emitted.
+func (u *User) getLease() *dbLeaseModel {
+	var lease dbLeaseModel
+	if db.Where("user_id = ?", u.ID).First(&lease).RecordNotFound() {
+		return nil
+	}
+	return &lease
+}
+
+// getIPNet returns the vpn address of the user along with the mask of its network, or nil if the
+// user has no lease yet.
+func (u *User) getIPNet() *net.IPNet {
+	lease := u.getLease()
+	if lease == nil {
+		return nil
+	}
+	ipnet := &net.IPNet{IP: HostID2IP(lease.HostID), Mask: TheServer().vpnNet().Mask}
+	if lease.Pool == "" || lease.Pool == DefaultIPPoolName {
+		return ipnet
+	}
+	if pool, err := GetIPPool(lease.Pool); err == nil && pool.subnet() != nil {
+		ipnet.Mask = pool.subnet().Mask
+	}
+	return ipnet
+}
@@ -0,0 +1,266 @@
+package ovpm
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLeases(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	bob, _ := CreateNewUser("bob", "1234", false, 0, true, "")
+	carol, _ := CreateNewUser("carol", "1234", false, 0, true, "")
+
+	// Test:
+	// Deleting a user doesn't renumber the ones after it.
+	if ip := carol.getIP().String(); ip != "10.9.0.4" {
+		t.Fatalf("carol is expected to have 10.9.0.4, got %s", ip)
+	}
+	if err := bob.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if ip := carol.getIP().String(); ip != "10.9.0.4" {
+		t.Fatalf("carol is expected to keep 10.9.0.4 after bob is deleted, got %s", ip)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "carol")]; !strings.Contains(ccd, "ifconfig-push 10.9.0.4 ") {
+		t.Fatalf("ccd of carol is expected to have 10.9.0.4:\n%s", ccd)
+	}
+
+	// Released addresses are leased again.
+	dave, _ := CreateNewUser("dave", "1234", false, 0, true, "")
+	if ip := dave.getIP().String(); ip != "10.9.0.3" {
+		t.Fatalf("dave is expected to get the address of bob, got %s", ip)
+	}
+	if user, err := getUserByIP(net.ParseIP("10.9.0.3")); err != nil || user.Username != "dave" {
+		t.Fatalf("10.9.0.3 is expected to be leased to dave: %v %v", user, err)
+	}
+
+	// Static reservations take the address over from the dynamic leases.
+	if err := alice.Update("", false, IP2HostID(net.ParseIP("10.9.0.4").To4()), true, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ip := alice.getIP().String(); ip != "10.9.0.4" {
+		t.Fatalf("alice is expected to have the static 10.9.0.4, got %s", ip)
+	}
+	carol, _ = GetUser("carol")
+	if ip := carol.getIP().String(); ip != "10.9.0.2" {
+		t.Fatalf("carol is expected to get a new lease, got %s", ip)
+	}
+	if err := dave.Update("", false, IP2HostID(net.ParseIP("10.9.0.4").To4()), true, ""); err == nil {
+		t.Fatalf("static address of alice is not expected to be reserved twice")
+	}
+
+	// Users that have no leases (e.g. created before the leases) get them when the configs are
+	// emitted, not when their addresses are looked up.
+	releaseLease(dave.ID)
+	if ip := dave.getIP(); ip != nil || dave.GetIPNet() != "" {
+		t.Fatalf("dave is not expected to have an address after its lease is released, got %s", ip)
+	}
+	if lease := dave.getLease(); lease != nil {
+		t.Fatalf("looking up the address of dave is not expected to lease one: %+v", lease)
+	}
+	if err := svr.Emit(); err != nil {
+		t.Fatal(err)
+	}
+	if ipnet := dave.GetIPNet(); ipnet != "10.9.0.3/24" {
+		t.Fatalf("dave is expected to get a lease when the configs are emitted, got %s", ipnet)
+	}
+}
+
+func TestMigrateLeases(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	bob, _ := CreateNewUser("bob", "1234", false, 0, true, "")
+	if _, err := CreateServiceAccount("ci", "", false); err != nil {
+		t.Fatal(err)
+	}
+	carol, _ := CreateNewUser("carol", "1234", false, IP2HostID(net.ParseIP("10.9.0.2").To4()), true, "")
+	dave, _ := CreateNewUser("dave", "1234", false, 0, true, "")
+	// Users were soft deleted before the leases.
+	db.Delete(&bob.dbUserModel)
+	releaseAllLeases()
+
+	// Test:
+	// Upgraded users keep the addresses that were derived from their ids.
+	migrateLeasesFromHostIDs()
+	for _, tt := range []struct {
+		user *User
+		ip   string
+	}{
+		{alice, "10.9.0.3"},
+		{carol, "10.9.0.2"},
+		{dave, "10.9.0.5"},
+	} {
+		if ip := tt.user.getIP(); ip == nil || ip.String() != tt.ip {
+			t.Errorf("%s is expected to keep %s, got %s", tt.user.Username, tt.ip, ip)
+		}
+	}
+	if lease := bob.getLease(); lease != nil {
+		t.Errorf("deleted bob is not expected to be leased an address: %+v", lease)
+	}
+}
+
+func TestIPPools(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "10.9.0.0/28", "", "", "", false)
+
+	// Test:
+	pools, _ := GetAllIPPools()
+	if len(pools) != 1 || pools[0].Name != DefaultIPPoolName || pools[0].Start != "10.9.0.2" || pools[0].End != "10.9.0.14" {
+		t.Fatalf("default pool is expected to cover the vpn network: %+v", pools)
+	}
+	for _, tt := range []struct {
+		name, start, end string
+	}{
+		{"office-a", "10.9.0.2", "10.9.0.3"},
+		{"office", "10.9.0.1", "10.9.0.3"},
+		{"office", "10.9.0.2", "10.9.0.15"},
+		{"office", "10.9.0.5", "10.9.0.3"},
+		{"office", "10.9.1.2", "10.9.1.3"},
+	} {
+		if _, err := CreateIPPool(tt.name, tt.start, tt.end); err == nil {
+			t.Errorf("CreateIPPool(%s, %s, %s) is expected to fail", tt.name, tt.start, tt.end)
+		}
+	}
+	if _, err := CreateIPPool("office", "10.9.0.10", "10.9.0.11"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateIPPool("remote", "10.9.0.11", "10.9.0.12"); err == nil {
+		t.Fatalf("overlapping pools are not expected to be created")
+	}
+	if _, err := CreateIPPool("remote", "10.9.0.12", "10.9.0.12"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Addresses are leased from the pools in order, until they are exhausted.
+	for _, tt := range []struct{ username, ip string }{
+		{"usera", "10.9.0.10"},
+		{"userb", "10.9.0.11"},
+		{"userc", "10.9.0.12"},
+	} {
+		user, err := CreateNewUser(tt.username, "1234", false, 0, true, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.getIP().String() != tt.ip {
+			t.Fatalf("%s is expected to get %s, got %s", user.Username, tt.ip, user.getIP())
+		}
+	}
+	if _, err := CreateNewUser("userd", "1234", false, 0, true, ""); err == nil {
+		t.Fatalf("user is not expected to be created when the pools are exhausted")
+	}
+	if _, err := GetUser("userd"); err == nil {
+		t.Fatalf("user is not expected to be kept when the pools are exhausted")
+	}
+	office, _ := GetIPPool("office")
+	if office.Used() != 2 || office.Size() != 2 {
+		t.Fatalf("office is expected to be full: %d/%d", office.Used(), office.Size())
+	}
+
+	// Pools with leases can't be deleted.
+	if err := office.Delete(); err == nil {
+		t.Fatalf("office is not expected to be deleted while it has leases")
+	}
+	// Vpn network can't be moved away from the pools.
+	if err := svr.Update("10.8.0.0/24", "", nil); err == nil {
+		t.Fatalf("vpn network is not expected to be moved away from the pools")
+	}
+}
+
+func TestSubnetIPPools(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, true, "")
+	contractors, _ := CreateGroup("contractors", "")
+
+	// Test:
+	for _, subnet := range []string{"10.9.0.128/25", "10.9.8.0/31", "10.9.8.0"} {
+		if _, err := CreateSubnetIPPool("contractors", subnet); err == nil {
+			t.Errorf("CreateSubnetIPPool(contractors, %s) is expected to fail", subnet)
+		}
+	}
+	if _, err := CreateSubnetIPPool("contractors", "10.9.8.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if err := contractors.SetIPPool("nonexistent"); err == nil {
+		t.Fatalf("nonexistent pool is not expected to be assigned")
+	}
+	if err := contractors.SetIPPool("contractors"); err != nil {
+		t.Fatal(err)
+	}
+	if err := contractors.AddMember("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Members lease from the pool of the group, with the mask and the gateway of its network.
+	alice, _ := GetUser("alice")
+	if ipnet := alice.GetIPNet(); ipnet != "10.9.8.2/24" {
+		t.Fatalf("alice is expected to lease from contractors, got %s", ipnet)
+	}
+	ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]
+	for _, line := range []string{
+		"ifconfig-push 10.9.8.2 255.255.255.0",
+		`push "route-gateway 10.9.8.1"`,
+		`push "route 10.9.0.0 255.255.255.0"`,
+	} {
+		if !strings.Contains(ccd, line) {
+			t.Fatalf("ccd of alice is expected to have %s:\n%s", line, ccd)
+		}
+	}
+	ccd = fs[filepath.Join(_DefaultVPNCCDPath, "bob")]
+	if !strings.Contains(ccd, "ifconfig-push 10.9.0.3 255.255.255.0") || strings.Contains(ccd, "route-gateway") {
+		t.Fatalf("ccd of bob is expected to stay on the vpn network:\n%s", ccd)
+	}
+	if conf := fs[_DefaultVPNConfPath]; !strings.Contains(conf, "route 10.9.8.0 255.255.255.0") {
+		t.Fatalf("server.conf is expected to route contractors to the vpn interface:\n%s", conf)
+	}
+	if src := svr.clientSource(); src != "10.9.0.0/24,10.9.8.0/24" {
+		t.Fatalf("firewall source is expected to have contractors, got %s", src)
+	}
+	if user, err := getUserByIP(net.ParseIP("10.9.8.2")); err != nil || user.Username != "alice" {
+		t.Fatalf("10.9.8.2 is expected to be leased to alice: %v %v", user, err)
+	}
+
+	// User's own pool takes precedence over the group's.
+	bob, _ := GetUser("bob")
+	if err := bob.SetIPPool("contractors"); err != nil {
+		t.Fatal(err)
+	}
+	if err := contractors.RemoveMember("alice"); err != nil {
+		t.Fatal(err)
+	}
+	bob, _ = GetUser("bob")
+	alice, _ = GetUser("alice")
+	if bob.GetIPNet() != "10.9.8.3/24" || alice.GetIPNet() != "10.9.0.2/24" {
+		t.Fatalf("bob is expected to move to contractors and alice back: %s %s", bob.GetIPNet(), alice.GetIPNet())
+	}
+
+	// Assigned pools can't be deleted.
+	pool, _ := GetIPPool("contractors")
+	if err := pool.Delete(); err == nil {
+		t.Fatalf("contractors is not expected to be deleted while it's assigned")
+	}
+	if groups, users := pool.GetAssignees(); len(groups) != 1 || len(users) != 1 {
+		t.Fatalf("contractors is expected to be assigned to the group and bob: %v %v", groups, users)
+	}
+}
@@ -0,0 +1,392 @@
+package ovpm
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// LDAPConfig holds the settings of the LDAP (or Active Directory) authentication backend.
+type LDAPConfig struct {
+	URL                string              // Directory server url. e.g. ldaps://dc.example.com
+	StartTLS           bool                // Upgrade ldap:// connections with StartTLS.
+	InsecureSkipVerify bool                // Don't verify the directory server's certificate.
+	BindDN             string              // DN of the service account that is used for searches.
+	BindPassword       string              // Password of the service account.
+	BaseDN             string              // Search base for the users.
+	UsernameAttr       string              // Attribute that holds the username. e.g. sAMAccountName, uid
+	MemberOfAttr       string              // Attribute that lists the groups of a user. e.g. memberOf
+	UserFilter         string              // Filter that the users must match to have access. e.g. (memberOf=cn=vpn,ou=groups,dc=example,dc=com)
+	AdminGroups        []string            // Members of these groups are given admin rights.
+	GroupNetworks      map[string][]string // Group DN -> names of the networks that the group's members are associated with.
+}
+
+// ldapEntry is a user entry found on the directory.
+type ldapEntry struct {
+	DN       string
+	Username string
+	Groups   []string
+}
+
+var ldapConfig *LDAPConfig
+var ldapConfigMu sync.RWMutex
+
+// SetLDAPConfig validates and sets the configuration of the LDAP backend.
+//
+// Passing nil disables the LDAP backend.
+func SetLDAPConfig(cfg *LDAPConfig) error {
+	if cfg != nil {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return fmt.Errorf("validation error: ldap url `%s` can not be parsed: %v", cfg.URL, err)
+		}
+		if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+			return fmt.Errorf("validation error: ldap url `%s` should start with ldap:// or ldaps://", cfg.URL)
+		}
+		if cfg.BaseDN == "" {
+			return fmt.Errorf("validation error: ldap base dn can not be empty")
+		}
+		if cfg.UserFilter != "" {
+			if _, err := ldap.CompileFilter(cfg.UserFilter); err != nil {
+				return fmt.Errorf("validation error: ldap user filter `%s` is invalid: %v", cfg.UserFilter, err)
+			}
+		}
+		if cfg.UsernameAttr == "" {
+			cfg.UsernameAttr = DefaultLDAPUsernameAttr
+		}
+		if cfg.MemberOfAttr == "" {
+			cfg.MemberOfAttr = DefaultLDAPMemberOfAttr
+		}
+	}
+
+	ldapConfigMu.Lock()
+	defer ldapConfigMu.Unlock()
+	ldapConfig = cfg
+	return nil
+}
+
+// GetLDAPConfig returns the configuration of the LDAP backend. It returns nil if the LDAP backend is disabled.
+func GetLDAPConfig() *LDAPConfig {
+	ldapConfigMu.RLock()
+	defer ldapConfigMu.RUnlock()
+	return ldapConfig
+}
+
+// IsLDAPEnabled returns whether the LDAP backend is configured or not.
+func IsLDAPEnabled() bool {
+	return GetLDAPConfig() != nil
+}
+
+// dial connects to the directory server and binds with the service account.
+func (cfg *LDAPConfig) dial() (*ldap.Conn, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse ldap url %s: %v", cfg.URL, err)
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: cfg.InsecureSkipVerify}
+	conn, err := ldap.DialURL(cfg.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("can not connect to ldap server %s: %v", cfg.URL, err)
+	}
+	if cfg.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("can not start tls with ldap server %s: %v", cfg.URL, err)
+		}
+	}
+	if err := cfg.bindServiceAccount(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// bindServiceAccount (re)binds the connection with the service account.
+func (cfg *LDAPConfig) bindServiceAccount(conn *ldap.Conn) error {
+	if cfg.BindDN == "" {
+		if err := conn.UnauthenticatedBind(""); err != nil {
+			return fmt.Errorf("can not bind anonymously to ldap server: %v", err)
+		}
+		return nil
+	}
+	if err := conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+		return fmt.Errorf("can not bind to ldap server as %s: %v", cfg.BindDN, err)
+	}
+	return nil
+}
+
+// filterFor returns the search filter for the given username. Empty username matches all users.
+func (cfg *LDAPConfig) filterFor(username string) string {
+	userFilter := fmt.Sprintf("(%s=*)", cfg.UsernameAttr)
+	if username != "" {
+		userFilter = fmt.Sprintf("(%s=%s)", cfg.UsernameAttr, ldap.EscapeFilter(username))
+	}
+	if cfg.UserFilter == "" {
+		return userFilter
+	}
+	return fmt.Sprintf("(&%s%s)", userFilter, cfg.UserFilter)
+}
+
+// search returns the user entries matching the given filter.
+func (cfg *LDAPConfig) search(conn *ldap.Conn, filter string) ([]ldapEntry, error) {
+	req := ldap.NewSearchRequest(
+		cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{cfg.UsernameAttr, cfg.MemberOfAttr}, nil,
+	)
+	res, err := conn.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("ldap search failed %s: %v", filter, err)
+	}
+
+	var entries []ldapEntry
+	for _, e := range res.Entries {
+		entries = append(entries, ldapEntry{
+			DN:       e.DN,
+			Username: e.GetEqualFoldAttributeValue(cfg.UsernameAttr),
+			Groups:   e.GetEqualFoldAttributeValues(cfg.MemberOfAttr),
+		})
+	}
+	return entries, nil
+}
+
+// authenticate looks the user up on the directory and checks the password by binding as the user.
+func (cfg *LDAPConfig) authenticate(username, password string) (*ldapEntry, error) {
+	// Empty passwords would end up being unauthenticated binds, which always succeed.
+	if password == "" {
+		return nil, fmt.Errorf("ldap password can not be empty")
+	}
+
+	conn, err := cfg.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := cfg.search(conn, cfg.filterFor(username))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("ldap user not found or ambiguous: %s (%d entries)", username, len(entries))
+	}
+
+	if err := conn.Bind(entries[0].DN, password); err != nil {
+		return nil, fmt.Errorf("ldap bind failed for %s: %v", entries[0].DN, err)
+	}
+	return &entries[0], nil
+}
+
+// isMemberOf returns whether the entry is a member of the group with the given DN.
+func (e *ldapEntry) isMemberOf(groupDN string) bool {
+	for _, g := range e.Groups {
+		if isSameDN(g, groupDN) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameDN compares two DNs ignoring case and insignificant spaces.
+func isSameDN(a, b string) bool {
+	dnA, errA := ldap.ParseDN(a)
+	dnB, errB := ldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return dnA.Equal(dnB)
+}
+
+// isAdmin returns whether the entry should be given admin rights.
+func (cfg *LDAPConfig) isAdmin(e *ldapEntry) bool {
+	for _, groupDN := range cfg.AdminGroups {
+		if e.isMemberOf(groupDN) {
+			return true
+		}
+	}
+	return false
+}
+
+// networksOf returns the set of the networks that are mapped to LDAP groups and
+// whether the entry should be associated with each of them.
+func (cfg *LDAPConfig) networksOf(e *ldapEntry) map[string]bool {
+	networks := make(map[string]bool)
+	for groupDN, names := range cfg.GroupNetworks {
+		member := e.isMemberOf(groupDN)
+		for _, name := range names {
+			networks[name] = networks[name] || member
+		}
+	}
+	return networks
+}
+
+// applyLDAPEntry creates or updates the ovpm user that corresponds to the directory entry.
+//
+// It doesn't emit the server config, instead it reports whether anything is changed.
+func (cfg *LDAPConfig) applyLDAPEntry(e *ldapEntry) (*User, bool, error) {
+	var changed bool
+	admin := cfg.isAdmin(e)
+
+	user, err := GetUser(e.Username)
+	if err != nil {
+		user, err = createNewUser(e.Username, "", false, 0, admin, "managed by ldap")
+		if err != nil {
+			return nil, false, err
+		}
+		changed = true
+	} else if !user.IsLDAPUser() {
+		return nil, false, fmt.Errorf("user %s already exists and is not managed by ldap", e.Username)
+	}
+
+	if user.LDAPDN != e.DN || user.Admin != admin || user.Disabled {
+		user.LDAPDN = e.DN
+		user.Admin = admin
+		user.Disabled = false
+		db.Save(&user.dbUserModel)
+		changed = true
+	}
+
+	c, err := cfg.syncNetworks(user, cfg.networksOf(e))
+	if err != nil {
+		return nil, false, err
+	}
+	return user, changed || c, nil
+}
+
+// syncNetworks associates or dissociates the user with the networks that are mapped to LDAP groups.
+func (cfg *LDAPConfig) syncNetworks(user *User, networks map[string]bool) (bool, error) {
+	var changed bool
+	for name, want := range networks {
+		network, err := GetNetwork(name)
+		if err != nil {
+			logrus.Warnf("ldap: network %s is mapped to an ldap group but it doesn't exist", name)
+			continue
+		}
+		var associated bool
+		for _, u := range network.Users {
+			if u.ID == user.ID {
+				associated = true
+				break
+			}
+		}
+		if associated == want {
+			continue
+		}
+		userAssoc := db.Model(&network.dbNetworkModel).Association("Users")
+		if want {
+			userAssoc.Append(&user.dbUserModel)
+		} else {
+			userAssoc.Delete(&user.dbUserModel)
+		}
+		if userAssoc.Error != nil {
+			return false, fmt.Errorf("can not update network association %s of %s: %v", name, user.Username, userAssoc.Error)
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// SyncLDAPUsers synchronizes the ovpm users with the LDAP directory.
+//
+// Entries matching the user filter are created as ovpm users, and their admin rights and network
+// associations are set according to their group memberships. LDAP managed users that are no longer
+// matching the user filter are disabled, dissociated from the mapped networks and disconnected.
+func SyncLDAPUsers() error {
+	cfg := GetLDAPConfig()
+	if cfg == nil {
+		return fmt.Errorf("ldap is not configured")
+	}
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+
+	conn, err := cfg.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	entries, err := cfg.search(conn, cfg.filterFor(""))
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	found := make(map[string]bool)
+	for i := range entries {
+		e := &entries[i]
+		if !isValidUsername(e.Username) {
+			logrus.Warnf("ldap: skipping %s: `%s` is not a valid username", e.DN, e.Username)
+			continue
+		}
+		_, c, err := cfg.applyLDAPEntry(e)
+		if err != nil {
+			logrus.Warnf("ldap: skipping %s: %v", e.DN, err)
+			continue
+		}
+		found[e.Username] = true
+		changed = changed || c
+	}
+
+	users, err := GetAllUsers()
+	if err != nil {
+		return err
+	}
+	var disabled []string
+	for _, user := range users {
+		if !user.IsLDAPUser() || user.Disabled || found[user.Username] {
+			continue
+		}
+		user.Disabled = true
+		db.Save(&user.dbUserModel)
+		// Not being found, the user isn't a member of any of the mapped groups either.
+		if _, err := cfg.syncNetworks(user, cfg.networksOf(&ldapEntry{DN: user.LDAPDN})); err != nil {
+			logrus.Warnf("ldap: %v", err)
+		}
+		logrus.Infof("ldap: user disabled: %s", user.Username)
+		disabled = append(disabled, user.Username)
+		changed = true
+	}
+
+	logrus.Debugf("ldap: %d users synchronized", len(found))
+	if !changed {
+		return nil
+	}
+	// Only the sessions of the disabled users are dropped, instead of restarting the vpn server.
+	if err := svr.Emit(); err != nil {
+		return err
+	}
+	for _, username := range disabled {
+		if err := killClient(username); err != nil {
+			logrus.Warnf("ldap: %v", err)
+		}
+	}
+	return nil
+}
+
+// StartLDAPSync runs SyncLDAPUsers periodically in the background until the returned
+// function is called.
+func StartLDAPSync(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := SyncLDAPUsers(); err != nil {
+				logrus.Errorf("ldap sync failed: %v", err)
+			}
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,368 @@
+package ovpm
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// fakeLDAPEntry is an entry of the fakeLDAPServer's directory.
+type fakeLDAPEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// fakeLDAPServer is a minimal in-process LDAP server that only understands
+// simple binds, searches with and/or/not/equality/present filters and unbinds.
+type fakeLDAPServer struct {
+	lis     net.Listener
+	lock    sync.Mutex
+	entries []*fakeLDAPEntry
+}
+
+func newFakeLDAPServer(t *testing.T, entries ...*fakeLDAPEntry) *fakeLDAPServer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen: %v", err)
+	}
+	s := &fakeLDAPServer{lis: lis, entries: entries}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeLDAPServer) URL() string {
+	return "ldap://" + s.lis.Addr().String()
+}
+
+func (s *fakeLDAPServer) Close() {
+	s.lis.Close()
+}
+
+// setAttr replaces the attribute values of the entry with the given dn.
+func (s *fakeLDAPServer) setAttr(dn, attr string, values ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, e := range s.entries {
+		if e.dn == dn {
+			e.attrs[attr] = values
+		}
+	}
+}
+
+func (s *fakeLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		req, err := ber.ReadPacket(conn)
+		if err != nil || len(req.Children) < 2 {
+			return
+		}
+		msgID := req.Children[0].Value.(int64)
+		op := req.Children[1]
+
+		s.lock.Lock()
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+			if dn == "" && password == "" {
+				code = ldap.LDAPResultSuccess
+			}
+			for _, e := range s.entries {
+				if strings.EqualFold(e.dn, dn) && e.password != "" && e.password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			conn.Write(fakeLDAPResult(msgID, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			baseDN, filter := op.Children[0].Data.String(), op.Children[6]
+			for _, e := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(e.dn), strings.ToLower(baseDN)) || !e.matches(filter) {
+					continue
+				}
+				conn.Write(e.packet(msgID).Bytes())
+			}
+			conn.Write(fakeLDAPResult(msgID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			s.lock.Unlock()
+			return
+		default:
+			conn.Write(fakeLDAPResult(msgID, ber.Tag(op.Tag+1), ldap.LDAPResultUnwillingToPerform).Bytes())
+		}
+		s.lock.Unlock()
+	}
+}
+
+// matches evaluates the BER encoded search filter against the entry.
+func (e *fakeLDAPEntry) matches(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, f := range filter.Children {
+			if !e.matches(f) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, f := range filter.Children {
+			if e.matches(f) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !e.matches(filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		attr, value := filter.Children[0].Data.String(), filter.Children[1].Data.String()
+		for _, v := range e.values(attr) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(e.values(filter.Data.String())) > 0
+	}
+	return false
+}
+
+func (e *fakeLDAPEntry) values(attr string) []string {
+	for k, v := range e.attrs {
+		if strings.EqualFold(k, attr) {
+			return v
+		}
+	}
+	return nil
+}
+
+func (e *fakeLDAPEntry) packet(msgID int64) *ber.Packet {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+	attrs := ber.NewSequence("")
+	for name, values := range e.attrs {
+		attr := ber.NewSequence("")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	entry.AppendChild(attrs)
+	return fakeLDAPMessage(msgID, entry)
+}
+
+func fakeLDAPResult(msgID int64, op ber.Tag, code int64) *ber.Packet {
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return fakeLDAPMessage(msgID, res)
+}
+
+func fakeLDAPMessage(msgID int64, op *ber.Packet) *ber.Packet {
+	msg := ber.NewSequence("")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgID, ""))
+	msg.AppendChild(op)
+	return msg
+}
+
+const (
+	testLDAPVPNGroup   = "cn=vpn,ou=groups,dc=example,dc=com"
+	testLDAPAdminGroup = "cn=admins,ou=groups,dc=example,dc=com"
+	testLDAPEngGroup   = "cn=eng,ou=groups,dc=example,dc=com"
+)
+
+func setupLDAPTest(t *testing.T) *fakeLDAPServer {
+	srv := newFakeLDAPServer(t,
+		&fakeLDAPEntry{dn: "cn=svc,dc=example,dc=com", password: "svcpass", attrs: map[string][]string{}},
+		&fakeLDAPEntry{dn: "uid=alice,ou=people,dc=example,dc=com", password: "alicepass", attrs: map[string][]string{
+			"uid":      {"alice"},
+			"memberOf": {testLDAPVPNGroup, testLDAPAdminGroup},
+		}},
+		&fakeLDAPEntry{dn: "uid=bob,ou=people,dc=example,dc=com", password: "bobpass", attrs: map[string][]string{
+			"uid":      {"bob"},
+			"memberOf": {testLDAPVPNGroup, testLDAPEngGroup},
+		}},
+		&fakeLDAPEntry{dn: "uid=carol,ou=people,dc=example,dc=com", password: "carolpass", attrs: map[string][]string{
+			"uid": {"carol"},
+		}},
+	)
+	err := SetLDAPConfig(&LDAPConfig{
+		URL:           srv.URL(),
+		BindDN:        "cn=svc,dc=example,dc=com",
+		BindPassword:  "svcpass",
+		BaseDN:        "ou=people,dc=example,dc=com",
+		UsernameAttr:  "uid",
+		UserFilter:    "(memberOf=" + testLDAPVPNGroup + ")",
+		AdminGroups:   []string{testLDAPAdminGroup},
+		GroupNetworks: map[string][]string{testLDAPEngGroup: {"eng"}},
+	})
+	if err != nil {
+		t.Fatalf("ldap config can not be set: %v", err)
+	}
+	return srv
+}
+
+func TestSetLDAPConfig(t *testing.T) {
+	defer SetLDAPConfig(nil)
+
+	tests := []struct {
+		name string
+		cfg  *LDAPConfig
+		ok   bool
+	}{
+		{"disabled", nil, true},
+		{"valid", &LDAPConfig{URL: "ldaps://dc.example.com", BaseDN: "dc=example,dc=com"}, true},
+		{"bad scheme", &LDAPConfig{URL: "http://dc.example.com", BaseDN: "dc=example,dc=com"}, false},
+		{"no base dn", &LDAPConfig{URL: "ldap://dc.example.com"}, false},
+		{"bad filter", &LDAPConfig{URL: "ldap://dc.example.com", BaseDN: "dc=example,dc=com", UserFilter: "(memberOf="}, false},
+	}
+	for _, tt := range tests {
+		if err := SetLDAPConfig(tt.cfg); (err == nil) != tt.ok {
+			t.Errorf("%s: SetLDAPConfig() error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+
+	// Are the defaults set?
+	SetLDAPConfig(&LDAPConfig{URL: "ldap://dc.example.com", BaseDN: "dc=example,dc=com"})
+	if cfg := GetLDAPConfig(); cfg.UsernameAttr != DefaultLDAPUsernameAttr || cfg.MemberOfAttr != DefaultLDAPMemberOfAttr {
+		t.Fatalf("ldap config defaults are expected to be set but they aren't: %+v", cfg)
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	srv := setupLDAPTest(t)
+	defer srv.Close()
+	defer SetLDAPConfig(nil)
+
+	// Prepare:
+	if _, err := CreateNewUser("local", "localpass", false, 0, false, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test:
+	var authtests = []struct {
+		username string
+		password string
+		ok       bool
+	}{
+		{"alice", "alicepass", true},
+		{"alice", "wrongpass", false},
+		{"alice", "", false},
+		{"carol", "carolpass", false}, // not in the vpn group
+		{"nobody", "pass", false},
+		{"local", "localpass", true},
+		{"local", "wrongpass", false},
+	}
+	for _, tt := range authtests {
+		_, err := Authenticate(tt.username, tt.password)
+		if (err == nil) != tt.ok {
+			t.Errorf("Authenticate(%s, %s) error = %v, want ok %t", tt.username, tt.password, err, tt.ok)
+		}
+	}
+
+	// Is the ldap user created on the first login?
+	alice, err := GetUser("alice")
+	if err != nil {
+		t.Fatalf("ldap user is expected to be created on login but it isn't: %v", err)
+	}
+	if !alice.IsLDAPUser() || !alice.IsAdmin() {
+		t.Fatalf("alice is expected to be an ldap managed admin: %+v", alice.dbUserModel)
+	}
+}
+
+func TestSyncLDAPUsers(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	srv := setupLDAPTest(t)
+	defer srv.Close()
+	defer SetLDAPConfig(nil)
+
+	// Prepare:
+	if _, err := CreateNewNetwork("eng", "192.168.10.0/24", ROUTE, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test:
+	if err := SyncLDAPUsers(); err != nil {
+		t.Fatalf("ldap sync failed: %v", err)
+	}
+
+	// Are the group members created?
+	alice, err := GetUser("alice")
+	if err != nil {
+		t.Fatalf("alice is expected to be created: %v", err)
+	}
+	bob, err := GetUser("bob")
+	if err != nil {
+		t.Fatalf("bob is expected to be created: %v", err)
+	}
+	if _, err := GetUser("carol"); err == nil {
+		t.Fatalf("carol is not expected to be created")
+	}
+
+	// Are the admin rights mapped?
+	if !alice.IsAdmin() || bob.IsAdmin() {
+		t.Fatalf("only alice is expected to be admin: alice=%t bob=%t", alice.IsAdmin(), bob.IsAdmin())
+	}
+
+	// Are the networks associated?
+	network, _ := GetNetwork("eng")
+	if usernames := network.GetAssociatedUsernames(); len(usernames) != 1 || usernames[0] != "bob" {
+		t.Fatalf("only bob is expected to be associated with eng but associated users are %v", usernames)
+	}
+
+	// Remove bob from eng and from the vpn group.
+	commands := fakeManagement(t)
+	srv.setAttr("uid=bob,ou=people,dc=example,dc=com", "memberOf")
+	if err := SyncLDAPUsers(); err != nil {
+		t.Fatalf("ldap sync failed: %v", err)
+	}
+	if got := commands(); !reflect.DeepEqual(got, []string{"kill bob"}) {
+		t.Fatalf("only the sessions of bob are expected to be killed, got %v", got)
+	}
+
+	// Is bob disabled and dissociated?
+	bob, _ = GetUser("bob")
+	if !bob.IsDisabled() {
+		t.Fatalf("bob is expected to be disabled after leaving the vpn group")
+	}
+	if _, err := Authenticate("bob", "bobpass"); err == nil {
+		t.Fatalf("bob is not expected to be able to authenticate")
+	}
+	network, _ = GetNetwork("eng")
+	if usernames := network.GetAssociatedUsernames(); len(usernames) != 0 {
+		t.Fatalf("no one is expected to be associated with eng but associated users are %v", usernames)
+	}
+
+	// Bring bob back.
+	srv.setAttr("uid=bob,ou=people,dc=example,dc=com", "memberOf", testLDAPVPNGroup)
+	if err := SyncLDAPUsers(); err != nil {
+		t.Fatalf("ldap sync failed: %v", err)
+	}
+	bob, _ = GetUser("bob")
+	if bob.IsDisabled() {
+		t.Fatalf("bob is expected to be enabled after rejoining the vpn group")
+	}
+}
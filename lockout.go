@@ -0,0 +1,299 @@
+package ovpm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Lockout kinds.
+const (
+	LockoutKindUser = "user" // failed attempts against a username
+	LockoutKindIP   = "ip"   // failed attempts from a source ip
+)
+
+// LockoutPolicy configures the brute-force protection of the password authentication.
+//
+// After the threshold of consecutive failed attempts is reached, the username or the source ip
+// is locked out for Duration. Every further failure doubles the lockout, up to MaxDuration.
+// Failures are forgotten after ResetAfter without any failures.
+type LockoutPolicy struct {
+	UserThreshold int // failed attempts against a username before locking it out; 0 disables
+	IPThreshold   int // failed attempts from a source ip before locking it out; 0 disables
+	Duration      time.Duration
+	MaxDuration   time.Duration
+	ResetAfter    time.Duration
+}
+
+// DefaultLockoutPolicy returns the lockout policy that is in effect unless another one is set.
+func DefaultLockoutPolicy() *LockoutPolicy {
+	return &LockoutPolicy{
+		UserThreshold: DefaultLockoutUserThreshold,
+		IPThreshold:   DefaultLockoutIPThreshold,
+		Duration:      DefaultLockoutDuration,
+		MaxDuration:   DefaultLockoutMaxDuration,
+		ResetAfter:    DefaultLockoutResetAfter,
+	}
+}
+
+var lockoutPolicy = DefaultLockoutPolicy()
+var lockoutPolicyMu sync.RWMutex
+
+// lockoutMu serializes the updates of the failure counters.
+var lockoutMu sync.Mutex
+
+// SetLockoutPolicy validates and sets the brute-force protection policy.
+//
+// Passing nil disables the brute-force protection.
+func SetLockoutPolicy(p *LockoutPolicy) error {
+	if p != nil {
+		if p.UserThreshold < 0 || p.IPThreshold < 0 {
+			return fmt.Errorf("validation error: lockout thresholds can not be negative")
+		}
+		if p.Duration <= 0 {
+			return fmt.Errorf("validation error: lockout duration should be positive")
+		}
+		if p.MaxDuration < p.Duration {
+			return fmt.Errorf("validation error: max lockout duration can not be less than the lockout duration")
+		}
+		if p.ResetAfter <= 0 {
+			return fmt.Errorf("validation error: lockout reset period should be positive")
+		}
+	}
+	lockoutPolicyMu.Lock()
+	defer lockoutPolicyMu.Unlock()
+	lockoutPolicy = p
+	return nil
+}
+
+// GetLockoutPolicy returns the brute-force protection policy. It returns nil if the protection is disabled.
+func GetLockoutPolicy() *LockoutPolicy {
+	lockoutPolicyMu.RLock()
+	defer lockoutPolicyMu.RUnlock()
+	return lockoutPolicy
+}
+
+// lockoutDuration returns how long to lock out after the given number of consecutive failures.
+func (p *LockoutPolicy) lockoutDuration(failures, threshold int) time.Duration {
+	d := p.Duration
+	for i := threshold; i < failures && d < p.MaxDuration; i++ {
+		d *= 2
+	}
+	if d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+	return d
+}
+
+// threshold returns the threshold of the given kind of lockout.
+func (p *LockoutPolicy) threshold(kind string) int {
+	if kind == LockoutKindIP {
+		return p.IPThreshold
+	}
+	return p.UserThreshold
+}
+
+// LockoutError is returned when an authentication attempt is rejected because of a lockout.
+type LockoutError struct {
+	Kind  string
+	Key   string
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("too many failed attempts: %s %s is locked out for %s", e.Kind, e.Key, time.Until(e.Until).Round(time.Second))
+}
+
+// dbLockoutModel is database model for the failed authentication attempts of a username or a source ip.
+type dbLockoutModel struct {
+	ID            uint   `gorm:"primary_key"`
+	Kind          string `gorm:"unique_index:idx_lockout_kind_key"`
+	Key           string `gorm:"unique_index:idx_lockout_kind_key"`
+	Failures      int    // consecutive failed attempts
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+// Lockout represents a locked out username or source ip.
+type Lockout struct {
+	dbLockoutModel
+}
+
+// AuthenticateFrom is like Authenticate, but it's protected against brute-force attacks.
+//
+// Failed attempts are counted per username and per source ip. Once either of them is locked out,
+// the attempts are rejected with a *LockoutError without checking the credentials. remoteAddr is
+// the source ip of the attempt; it may be empty if it's unknown.
+//
+// Users that are enrolled in TOTP are rejected; they log in with AuthenticateWithTOTP instead.
+func AuthenticateFrom(username, password, remoteAddr string) (*User, error) {
+	return AuthenticateWithTOTP(username, password, "", remoteAddr)
+}
+
+// AuthenticateWithTOTP is like AuthenticateFrom, but the users that are enrolled in TOTP are
+// accepted if the code is valid for them as well. Incorrect codes are counted as failed attempts.
+func AuthenticateWithTOTP(username, password, code, remoteAddr string) (*User, error) {
+	policy := GetLockoutPolicy()
+	if policy == nil {
+		return authenticateTOTP(username, password, code)
+	}
+
+	keys := map[string]string{LockoutKindUser: username}
+	if remoteAddr != "" {
+		keys[LockoutKindIP] = remoteAddr
+	}
+	for _, kind := range []string{LockoutKindIP, LockoutKindUser} {
+		if key, ok := keys[kind]; ok {
+			if err := checkLockout(kind, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	user, err := authenticateTOTP(username, password, code)
+	if err != nil {
+		for kind, key := range keys {
+			recordAuthFailure(policy, kind, key, remoteAddr)
+		}
+		return nil, err
+	}
+
+	// Successful attempts only reset the username; otherwise an attacker
+	// could reset the ip by logging into an account of their own.
+	clearFailures(LockoutKindUser, username)
+	return user, nil
+}
+
+// checkLockout returns a *LockoutError if the given username or source ip is locked out.
+func checkLockout(kind, key string) error {
+	var l dbLockoutModel
+	db.Where(&dbLockoutModel{Kind: kind, Key: key}).First(&l)
+	if db.NewRecord(&l) || !l.isLocked() {
+		return nil
+	}
+	return &LockoutError{Kind: kind, Key: key, Until: l.LockedUntil}
+}
+
+// recordAuthFailure counts a failed attempt and locks out if the threshold is reached.
+func recordAuthFailure(policy *LockoutPolicy, kind, key, remoteAddr string) {
+	threshold := policy.threshold(kind)
+	if threshold == 0 {
+		return
+	}
+
+	lockoutMu.Lock()
+	defer lockoutMu.Unlock()
+
+	now := time.Now()
+	purgeStaleLockouts(policy)
+
+	l := dbLockoutModel{Kind: kind, Key: key}
+	db.Where(&dbLockoutModel{Kind: kind, Key: key}).First(&l)
+	if now.Sub(l.LastFailureAt) > policy.ResetAfter {
+		l.Failures = 0
+	}
+	l.Failures++
+	l.LastFailureAt = now
+	if l.Failures >= threshold {
+		d := policy.lockoutDuration(l.Failures, threshold)
+		l.LockedUntil = now.Add(d)
+		logrus.Warnf("%s %s is locked out for %s after %d failed authentication attempts", kind, key, d, l.Failures)
+		audit(AuditAuthLockout, "", key, remoteAddr, fmt.Sprintf("%s locked out for %s after %d failed attempts", kind, d, l.Failures))
+	}
+	db.Save(&l)
+}
+
+// clearFailures forgets the failed attempts of the given username or source ip.
+func clearFailures(kind, key string) {
+	db.Where(&dbLockoutModel{Kind: kind, Key: key}).Delete(&dbLockoutModel{})
+}
+
+// purgeStaleLockouts deletes the failure counters that would be reset anyway.
+func purgeStaleLockouts(policy *LockoutPolicy) {
+	now := time.Now()
+	db.Where("locked_until < ? AND last_failure_at < ?", now, now.Add(-policy.ResetAfter)).Delete(&dbLockoutModel{})
+}
+
+// GetLockouts returns the usernames and the source ips that are currently locked out.
+func GetLockouts() ([]*Lockout, error) {
+	var dbLockouts []*dbLockoutModel
+	if err := db.Where("locked_until > ?", time.Now()).Order("kind, key").Find(&dbLockouts).Error; err != nil {
+		return nil, err
+	}
+
+	var lockouts []*Lockout
+	for _, l := range dbLockouts {
+		lockouts = append(lockouts, &Lockout{dbLockoutModel: *l})
+	}
+	return lockouts, nil
+}
+
+// ClearLockout lifts the lockout of the given username or source ip, and forgets its failed attempts.
+//
+// actor is the username of whoever clears the lockout; it's recorded in the audit log.
+func ClearLockout(kind, key, actor string) error {
+	if kind != LockoutKindUser && kind != LockoutKindIP {
+		return fmt.Errorf("validation error: unknown lockout kind: %s", kind)
+	}
+	var l dbLockoutModel
+	db.Where(&dbLockoutModel{Kind: kind, Key: key}).First(&l)
+	if db.NewRecord(&l) {
+		return fmt.Errorf("lockout not found: %s %s", kind, key)
+	}
+	db.Delete(&l)
+	logrus.Infof("lockout cleared: %s %s", kind, key)
+	audit(AuditAuthLockoutCleared, actor, key, "", fmt.Sprintf("%s lockout cleared", kind))
+	return nil
+}
+
+// ClearAllLockouts lifts all of the lockouts and forgets all of the failed attempts.
+//
+// actor is the username of whoever clears the lockouts; it's recorded in the audit log.
+func ClearAllLockouts(actor string) error {
+	lockouts, err := GetLockouts()
+	if err != nil {
+		return err
+	}
+	db.Delete(&dbLockoutModel{})
+	for _, l := range lockouts {
+		logrus.Infof("lockout cleared: %s %s", l.Kind, l.Key)
+		audit(AuditAuthLockoutCleared, actor, l.Key, "", fmt.Sprintf("%s lockout cleared", l.Kind))
+	}
+	return nil
+}
+
+// GetKind returns whether the lockout is of a username or a source ip.
+func (l *Lockout) GetKind() string {
+	return l.Kind
+}
+
+// GetKey returns the locked out username or source ip.
+func (l *Lockout) GetKey() string {
+	return l.Key
+}
+
+// GetFailures returns the number of consecutive failed attempts.
+func (l *Lockout) GetFailures() int {
+	return l.Failures
+}
+
+// GetLastFailureAt returns the time of the last failed attempt.
+func (l *Lockout) GetLastFailureAt() time.Time {
+	return l.LastFailureAt
+}
+
+// GetLockedUntil returns the end of the lockout.
+func (l *Lockout) GetLockedUntil() time.Time {
+	return l.LockedUntil
+}
+
+// IsLocked returns whether the lockout is still in effect.
+func (l *Lockout) IsLocked() bool {
+	return l.isLocked()
+}
+
+func (l *dbLockoutModel) isLocked() bool {
+	return time.Now().Before(l.LockedUntil)
+}
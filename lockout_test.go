@@ -0,0 +1,139 @@
+package ovpm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuthenticateFromLockout(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	defer SetLockoutPolicy(DefaultLockoutPolicy())
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+	policy := &LockoutPolicy{UserThreshold: 3, IPThreshold: 5, Duration: time.Minute, MaxDuration: 3 * time.Minute, ResetAfter: time.Hour}
+	if err := SetLockoutPolicy(policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failures below the threshold don't lock out.
+	for i := 0; i < 2; i++ {
+		if _, err := AuthenticateFrom("alice", "wrong", "192.0.2.1"); err == nil {
+			t.Fatalf("wrong password is not expected to authenticate")
+		}
+	}
+	if _, err := AuthenticateFrom("alice", "1234", "192.0.2.1"); err != nil {
+		t.Fatalf("alice is expected to authenticate below the threshold: %v", err)
+	}
+
+	// Success resets the username.
+	for i := 0; i < 3; i++ {
+		AuthenticateFrom("alice", "wrong", "192.0.2.2")
+	}
+	_, err := AuthenticateFrom("alice", "1234", "192.0.2.2")
+	lerr, ok := err.(*LockoutError)
+	if !ok || lerr.Kind != LockoutKindUser || lerr.Key != "alice" {
+		t.Fatalf("alice is expected to be locked out even with the right password: %v", err)
+	}
+
+	// Lockouts are audited.
+	entries, _ := GetAuditEntries(AuditAuthLockout, 0)
+	if len(entries) != 1 || entries[0].GetTarget() != "alice" || entries[0].GetRemoteAddr() != "192.0.2.2" {
+		t.Fatalf("lockout is expected to be audited: %+v", entries)
+	}
+
+	// Lockouts are doubled with every further failure, up to the max.
+	var l dbLockoutModel
+	db.Where(&dbLockoutModel{Kind: LockoutKindUser, Key: "alice"}).First(&l)
+	db.Model(&l).UpdateColumn("locked_until", time.Now().Add(-time.Second))
+	for i, want := range []time.Duration{2 * time.Minute, 3 * time.Minute} {
+		AuthenticateFrom("alice", "wrong", "")
+		db.Where(&dbLockoutModel{Kind: LockoutKindUser, Key: "alice"}).First(&l)
+		if got := time.Until(l.LockedUntil); got > want || got < want-time.Second {
+			t.Fatalf("lockout #%d is expected to be %s but it's %s", i+2, want, got)
+		}
+		db.Model(&l).UpdateColumn("locked_until", time.Now().Add(-time.Second))
+	}
+
+	// Locked out usernames can be cleared by an admin.
+	AuthenticateFrom("alice", "wrong", "")
+	lockouts, _ := GetLockouts()
+	if len(lockouts) != 1 || lockouts[0].GetKey() != "alice" || !lockouts[0].IsLocked() {
+		t.Fatalf("alice is expected to be listed as locked out: %+v", lockouts)
+	}
+	if err := ClearLockout(LockoutKindUser, "alice", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AuthenticateFrom("alice", "1234", ""); err != nil {
+		t.Fatalf("alice is expected to authenticate after the lockout is cleared: %v", err)
+	}
+	entries, _ = GetAuditEntries(AuditAuthLockoutCleared, 0)
+	if len(entries) != 1 || entries[0].GetActor() != "root" {
+		t.Fatalf("clearing the lockout is expected to be audited: %+v", entries)
+	}
+	if err := ClearLockout(LockoutKindUser, "alice", "root"); err == nil {
+		t.Fatalf("clearing an unknown lockout is expected to fail")
+	}
+}
+
+func TestAuthenticateFromIPLockout(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	defer SetLockoutPolicy(DefaultLockoutPolicy())
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+	SetLockoutPolicy(&LockoutPolicy{UserThreshold: 3, IPThreshold: 5, Duration: time.Minute, MaxDuration: time.Hour, ResetAfter: time.Hour})
+
+	// Guessing different usernames from the same ip locks the ip out.
+	for _, username := range []string{"a", "b", "c", "d", "e"} {
+		AuthenticateFrom(username, "wrong", "192.0.2.1")
+	}
+	_, err := AuthenticateFrom("alice", "1234", "192.0.2.1")
+	if lerr, ok := err.(*LockoutError); !ok || lerr.Kind != LockoutKindIP {
+		t.Fatalf("ip is expected to be locked out: %v", err)
+	}
+	if _, err := AuthenticateFrom("alice", "1234", "192.0.2.2"); err != nil {
+		t.Fatalf("alice is expected to authenticate from another ip: %v", err)
+	}
+
+	// Clearing all lockouts.
+	if err := ClearAllLockouts("root"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AuthenticateFrom("alice", "1234", "192.0.2.1"); err != nil {
+		t.Fatalf("alice is expected to authenticate after lockouts are cleared: %v", err)
+	}
+
+	// Disabled protection.
+	SetLockoutPolicy(nil)
+	for i := 0; i < 10; i++ {
+		AuthenticateFrom("alice", "wrong", "192.0.2.1")
+	}
+	if _, err := AuthenticateFrom("alice", "1234", "192.0.2.1"); err != nil {
+		t.Fatalf("alice is expected to authenticate when the protection is disabled: %v", err)
+	}
+}
+
+func TestSetLockoutPolicy(t *testing.T) {
+	defer SetLockoutPolicy(DefaultLockoutPolicy())
+
+	tests := []struct {
+		name   string
+		policy *LockoutPolicy
+		ok     bool
+	}{
+		{"disabled", nil, true},
+		{"default", DefaultLockoutPolicy(), true},
+		{"negative threshold", &LockoutPolicy{UserThreshold: -1, Duration: time.Minute, MaxDuration: time.Hour, ResetAfter: time.Hour}, false},
+		{"no duration", &LockoutPolicy{UserThreshold: 5, MaxDuration: time.Hour, ResetAfter: time.Hour}, false},
+		{"max less than duration", &LockoutPolicy{UserThreshold: 5, Duration: time.Hour, MaxDuration: time.Minute, ResetAfter: time.Hour}, false},
+		{"no reset", &LockoutPolicy{UserThreshold: 5, Duration: time.Minute, MaxDuration: time.Hour}, false},
+	}
+	for _, tt := range tests {
+		if err := SetLockoutPolicy(tt.policy); (err == nil) != tt.ok {
+			t.Errorf("%s: SetLockoutPolicy() error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package ovpm
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ovpmd keeps a connection to the management interface of the OpenVPN process while it's running.
+// The clients are authorized through it when they connect (management-client-auth), and the
+// sessions of single users are killed through it without restarting the vpn server.
+
+var vpnPasswordAuth bool
+var vpnPasswordAuthMu sync.RWMutex
+
+var mgmtMu sync.Mutex
+var mgmtStop chan struct{}
+var mgmtClient *managementClient
+
+// SetVPNPasswordAuth sets whether the vpn clients must log in with the passwords of their users,
+// besides their certs, to connect. The passwords are checked with AuthenticateWithTOTP, so the LDAP
+// users log in with their directory passwords, and the users that are enrolled in TOTP are asked
+// for their codes through the static challenge of their profiles.
+//
+// Client configs that are generated afterwards ask for the password.
+func SetVPNPasswordAuth(enabled bool) {
+	vpnPasswordAuthMu.Lock()
+	defer vpnPasswordAuthMu.Unlock()
+	vpnPasswordAuth = enabled
+}
+
+// IsVPNPasswordAuth returns whether the vpn clients must log in with their passwords to connect.
+func IsVPNPasswordAuth() bool {
+	vpnPasswordAuthMu.RLock()
+	defer vpnPasswordAuthMu.RUnlock()
+	return vpnPasswordAuth
+}
+
+// authorizeClient returns an error if the client that the management interface reports with the
+// env is not allowed to connect.
+//
+// The password is only checked when the client connects; the renegotiations (reauth) only check
+// that the user is still allowed, since the clients don't cache their passwords.
+func authorizeClient(env map[string]string, reauth bool) error {
+	cn := env["common_name"]
+	if IsVPNPasswordAuth() && !reauth {
+		if env["username"] != cn {
+			return fmt.Errorf("username '%s' doesn't match the certificate of %s", env["username"], cn)
+		}
+		remoteAddr := env["untrusted_ip"]
+		if remoteAddr == "" {
+			remoteAddr = env["untrusted_ip6"]
+		}
+		password, code := splitStaticChallenge(env["password"])
+		// Disabled and expired users are rejected by Authenticate as well.
+		_, err := AuthenticateWithTOTP(cn, password, code, remoteAddr)
+		return err
+	}
+	user, err := GetUser(cn)
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return fmt.Errorf("user is disabled: %s", cn)
+	}
+	if err := user.checkValidity(time.Now()); err != nil {
+		return err
+	}
+	// Connecting with the renewed cert means the user has the new profile.
+	if serial, ok := new(big.Int).SetString(env["tls_serial_0"], 10); ok && serial.Text(16) == user.certSerial() {
+		if err := user.revokePreviousCerts(); err != nil {
+			logrus.Errorf("previous certs of %s can not be revoked: %v", cn, err)
+		}
+	}
+	return nil
+}
+
+// splitStaticChallenge splits the password that the clients send along with their responses to the
+// static challenge, SCRV1:<base64 password>:<base64 response>, into the password and the response.
+// Other passwords are returned as they are.
+func splitStaticChallenge(password string) (string, string) {
+	parts := strings.SplitN(password, ":", 3)
+	if len(parts) != 3 || parts[0] != "SCRV1" {
+		return password, ""
+	}
+	pass, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return password, ""
+	}
+	resp, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return password, ""
+	}
+	return string(pass), string(resp)
+}
+
+// killClient drops the sessions of the user through the management interface. It's a no-op if the
+// management interface isn't connected, e.g. the vpn server isn't running.
+func killClient(username string) error {
+	mgmtMu.Lock()
+	c := mgmtClient
+	mgmtMu.Unlock()
+	if c == nil {
+		return nil
+	}
+	reply, err := c.command("kill " + username)
+	if err != nil {
+		return err
+	}
+	// The user isn't connected.
+	if strings.HasPrefix(reply, "ERROR: common name") {
+		return nil
+	}
+	if !strings.HasPrefix(reply, "SUCCESS:") {
+		return fmt.Errorf("can not kill the sessions of %s: %s", username, reply)
+	}
+	logrus.Infof("sessions are killed: %s", username)
+	return nil
+}
+
+// ensureManagementConnected (re)connects to the management interface of the OpenVPN process, and
+// keeps reconnecting if the process is restarted until stopManagement is called.
+func ensureManagementConnected() {
+	if Testing {
+		return
+	}
+	mgmtMu.Lock()
+	defer mgmtMu.Unlock()
+	if mgmtStop != nil {
+		close(mgmtStop)
+	}
+	stop := make(chan struct{})
+	mgmtStop = stop
+
+	go func() {
+		for {
+			conn, err := net.Dial("unix", _DefaultManagementPath)
+			if err == nil {
+				go func() {
+					<-stop
+					conn.Close()
+				}()
+				logrus.Debug("management interface is connected")
+				serveManagement(conn)
+				logrus.Debug("management interface is disconnected")
+			}
+			select {
+			case <-stop:
+				return
+			case <-time.After(DefaultManagementRetryInterval):
+			}
+		}
+	}()
+}
+
+// stopManagement disconnects from the management interface.
+func stopManagement() {
+	mgmtMu.Lock()
+	defer mgmtMu.Unlock()
+	if mgmtStop != nil {
+		close(mgmtStop)
+		mgmtStop = nil
+	}
+}
+
+// serveManagement serves the management connection until it's closed.
+func serveManagement(conn net.Conn) {
+	c := &managementClient{conn: conn, replies: make(chan string, 1)}
+	mgmtMu.Lock()
+	mgmtClient = c
+	mgmtMu.Unlock()
+	defer func() {
+		mgmtMu.Lock()
+		if mgmtClient == c {
+			mgmtClient = nil
+		}
+		mgmtMu.Unlock()
+		conn.Close()
+	}()
+	c.serve()
+}
+
+// managementClient is a connection to the management interface of the OpenVPN process.
+type managementClient struct {
+	conn    net.Conn
+	cmdMu   sync.Mutex  // one command at a time, since the replies aren't tagged
+	replies chan string // replies of the commands, i.e. SUCCESS: and ERROR: lines
+}
+
+// command sends the command and returns its reply.
+func (c *managementClient) command(cmd string) (string, error) {
+	c.cmdMu.Lock()
+	defer c.cmdMu.Unlock()
+	// Drop the late reply of a command that timed out.
+	select {
+	case <-c.replies:
+	default:
+	}
+	if _, err := fmt.Fprintf(c.conn, "%s\n", cmd); err != nil {
+		return "", fmt.Errorf("management command failed: %v", err)
+	}
+	select {
+	case reply, ok := <-c.replies:
+		if !ok {
+			return "", fmt.Errorf("management interface is disconnected")
+		}
+		return reply, nil
+	case <-time.After(DefaultManagementTimeout):
+		return "", fmt.Errorf("management command timed out: %s", strings.Fields(cmd)[0])
+	}
+}
+
+// serve reads the replies and the notifications of the management interface until the connection
+// is closed. The clients are authorized in their own goroutines, since authorizing them may take a
+// while (e.g. LDAP) and it sends commands whose replies are read here.
+func (c *managementClient) serve() {
+	defer close(c.replies)
+	var event []string // >CLIENT:CONNECT or >CLIENT:REAUTH and its ids, while its env is being read
+	var env map[string]string
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "SUCCESS:"), strings.HasPrefix(line, "ERROR:"):
+			select {
+			case c.replies <- line:
+			default:
+				logrus.Debugf("unexpected management reply: %s", line)
+			}
+		case strings.HasPrefix(line, ">CLIENT:CONNECT,"), strings.HasPrefix(line, ">CLIENT:REAUTH,"):
+			event = strings.Split(strings.TrimPrefix(line, ">CLIENT:"), ",")
+			env = map[string]string{}
+		case line == ">CLIENT:ENV,END":
+			if len(event) == 3 {
+				go c.authorize(event[1], event[2], env, event[0] == "REAUTH")
+			}
+			event, env = nil, nil
+		case strings.HasPrefix(line, ">CLIENT:ENV,"):
+			if env != nil {
+				kv := strings.SplitN(strings.TrimPrefix(line, ">CLIENT:ENV,"), "=", 2)
+				if len(kv) == 2 {
+					env[kv[0]] = kv[1]
+				}
+			}
+		}
+	}
+}
+
+// authorize lets the client connect, or denies it.
+func (c *managementClient) authorize(cid, kid string, env map[string]string, reauth bool) {
+	cmd := fmt.Sprintf("client-auth-nt %s %s", cid, kid)
+	if err := authorizeClient(env, reauth); err != nil {
+		logrus.Infof("vpn client is rejected: %s: %v", env["common_name"], err)
+		cmd = fmt.Sprintf("client-deny %s %s %s", cid, kid, quoteManagementArg(err.Error()))
+	}
+	if reply, err := c.command(cmd); err != nil || !strings.HasPrefix(reply, "SUCCESS:") {
+		logrus.Errorf("vpn client can not be authorized: %s: %v %s", env["common_name"], err, reply)
+	}
+}
+
+// quoteManagementArg quotes the argument of a management command.
+func quoteManagementArg(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
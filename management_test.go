@@ -0,0 +1,162 @@
+package ovpm
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAuthorizeClient(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	srv := setupLDAPTest(t)
+	defer srv.Close()
+	defer SetLDAPConfig(nil)
+	CreateNewUser("local", "localpass", false, 0, false, "")
+	CreateNewUser("disabled", "pass", false, 0, false, "")
+	db.Model(&dbUserModel{}).Where("username = ?", "disabled").Update("Disabled", true)
+
+	// Test:
+	env := func(cn, username, password string) map[string]string {
+		return map[string]string{"common_name": cn, "username": username, "password": password, "untrusted_ip": "192.0.2.1"}
+	}
+
+	// Only the certs are checked without the password auth.
+	for _, tt := range []struct {
+		env map[string]string
+		ok  bool
+	}{
+		{env("local", "", ""), true},
+		{env("nobody", "", ""), false},
+		{env("disabled", "", ""), false},
+	} {
+		if err := authorizeClient(tt.env, false); (err == nil) != tt.ok {
+			t.Errorf("authorizeClient(%s) error = %v, want ok %t", tt.env["common_name"], err, tt.ok)
+		}
+	}
+
+	// LDAP users log in to the vpn with their directory passwords.
+	SetVPNPasswordAuth(true)
+	defer SetVPNPasswordAuth(false)
+	for _, tt := range []struct {
+		env map[string]string
+		ok  bool
+	}{
+		{env("alice", "alice", "alicepass"), true},
+		{env("alice", "alice", "wrongpass"), false},
+		{env("alice", "local", "localpass"), false}, // cert of another user
+		{env("local", "local", "localpass"), true},
+		{env("local", "local", ""), false},
+		{env("disabled", "disabled", "pass"), false},
+	} {
+		if err := authorizeClient(tt.env, false); (err == nil) != tt.ok {
+			t.Errorf("authorizeClient(%s, %s) error = %v, want ok %t", tt.env["common_name"], tt.env["password"], err, tt.ok)
+		}
+	}
+
+	// Renegotiations don't ask for the passwords again.
+	if err := authorizeClient(env("local", "", ""), true); err != nil {
+		t.Fatalf("renegotiation of local is expected to be authorized: %v", err)
+	}
+	if err := authorizeClient(env("disabled", "", ""), true); err == nil {
+		t.Fatalf("renegotiation of a disabled user is expected to be rejected")
+	}
+	if profile, _ := TheServer().DumpsClientConfig("local"); !strings.Contains(profile, "auth-user-pass") {
+		t.Fatalf("client config is expected to ask for the password:\n%s", profile)
+	}
+}
+
+func TestManagementClient(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+
+	// The other end of the connection plays the management interface.
+	conn, mgmt := net.Pipe()
+	defer mgmt.Close()
+	go serveManagement(conn)
+	r := bufio.NewReader(mgmt)
+	expect := func(prefix, reply string) {
+		t.Helper()
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(line, prefix) {
+			t.Fatalf("management command is expected to start with %q: %q", prefix, line)
+		}
+		fmt.Fprintf(mgmt, "%s\n", reply)
+	}
+
+	// Test:
+	fmt.Fprint(mgmt, ">INFO:OpenVPN Management Interface Version 1\n")
+	fmt.Fprint(mgmt, ">CLIENT:CONNECT,0,1\n>CLIENT:ENV,common_name=alice\n>CLIENT:ENV,untrusted_ip=192.0.2.1\n>CLIENT:ENV,END\n")
+	expect("client-auth-nt 0 1\n", "SUCCESS: client-auth command succeeded")
+	fmt.Fprint(mgmt, ">CLIENT:CONNECT,1,1\n>CLIENT:ENV,common_name=nobody\n>CLIENT:ENV,END\n")
+	expect(`client-deny 1 1 "user not found: nobody"`, "SUCCESS: client-deny command succeeded")
+	fmt.Fprint(mgmt, ">CLIENT:ESTABLISHED,0\n>CLIENT:ENV,common_name=alice\n>CLIENT:ENV,END\n")
+	fmt.Fprint(mgmt, ">CLIENT:REAUTH,0,2\n>CLIENT:ENV,common_name=alice\n>CLIENT:ENV,END\n")
+	expect("client-auth-nt 0 2\n", "SUCCESS: client-auth command succeeded")
+
+	// Sessions of single users are killed.
+	done := make(chan error)
+	go func() { done <- killClient("alice") }()
+	expect("kill alice\n", "SUCCESS: common name 'alice' found, 1 client(s) killed")
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	go func() { done <- killClient("bob") }()
+	expect("kill bob\n", "ERROR: common name 'bob' not found")
+	if err := <-done; err != nil {
+		t.Fatalf("killing a user that isn't connected is expected to be a no-op: %v", err)
+	}
+}
+
+// fakeManagement plays the management interface of the OpenVPN process, replying SUCCESS to all of
+// the commands. It returns a function that returns the commands that are received so far.
+func fakeManagement(t *testing.T) func() []string {
+	t.Helper()
+	conn, mgmt := net.Pipe()
+	t.Cleanup(func() { mgmt.Close() })
+	go serveManagement(conn)
+
+	var mu sync.Mutex
+	var commands []string
+	go func() {
+		r := bufio.NewReader(mgmt)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			commands = append(commands, strings.TrimSpace(line))
+			mu.Unlock()
+			fmt.Fprint(mgmt, "SUCCESS: ok\n")
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		mgmtMu.Lock()
+		connected := mgmtClient != nil
+		mgmtMu.Unlock()
+		if connected {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), commands...)
+	}
+}
@@ -4,15 +4,15 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"log"
 	"net"
-
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/asaskevich/govalidator"
-	"github.com/coreos/go-iptables/iptables"
 	"github.com/jinzhu/gorm"
+	"github.com/master312/ovpm/firewall"
 )
 
 // NetworkType distinguishes different types of networks that is defined in the networks table.
@@ -23,6 +23,7 @@ const (
 	UNDEFINEDNET NetworkType = iota
 	SERVERNET
 	ROUTE
+	CLIENTNET
 )
 
 var networkTypes = [...]struct {
@@ -33,6 +34,7 @@ var networkTypes = [...]struct {
 	{UNDEFINEDNET, "UNDEFINEDNET", "unknown network type"},
 	{SERVERNET, "SERVERNET", "network behind vpn server"},
 	{ROUTE, "ROUTE", "network to be pushed as route"},
+	{CLIENTNET, "CLIENTNET", "network behind a vpn client"},
 }
 
 // NetworkTypeFromString returns string representation of the network type.
@@ -89,11 +91,22 @@ type dbNetworkModel struct {
 	ServerID uint
 	Server   dbServerModel
 
-	Name  string `gorm:"unique_index"`
-	CIDR  string
-	Type  NetworkType
-	Via   string
-	Users []*dbUserModel `gorm:"many2many:network_users;"`
+	Name   string `gorm:"unique_index"`
+	CIDR   string
+	Type   NetworkType
+	Via    string
+	Users  []*dbUserModel  `gorm:"many2many:network_users;"`
+	Groups []*dbGroupModel `gorm:"many2many:network_groups;"`
+
+	OwnerID uint // User that the network is behind. Only for CLIENTNET.
+
+	MappedCIDR string // Virtual network that the clients reach the network at through NETMAP, if any.
+
+	DNSDomain  string // Domain that is resolved by DNSServers for the associated clients (split dns), if any.
+	DNSServers string // Comma separated dns servers of DNSDomain.
+
+	NATMode string // Translation of the clients' traffic to the network; the server's if empty. Only for SERVERNET.
+	SNATTo  string // Source address of the clients' traffic to the network when NATMode is snat.
 }
 
 // Network represents a VPN related network.
@@ -112,7 +125,7 @@ func GetNetwork(name string) (*Network, error) {
 	}
 
 	var network dbNetworkModel
-	db.Preload("Users").Where(&dbNetworkModel{Name: name}).First(&network)
+	db.Preload("Users").Preload("Groups").Where(&dbNetworkModel{Name: name}).First(&network)
 
 	if db.NewRecord(&network) {
 		return nil, fmt.Errorf("network not found %s", name)
@@ -125,7 +138,7 @@ func GetNetwork(name string) (*Network, error) {
 func GetAllNetworks() []*Network {
 	var networks []*Network
 	var dbNetworks []*dbNetworkModel
-	db.Preload("Users").Find(&dbNetworks)
+	db.Preload("Users").Preload("Groups").Find(&dbNetworks)
 	for _, n := range dbNetworks {
 		networks = append(networks, &Network{dbNetworkModel: *n})
 	}
@@ -133,7 +146,32 @@ func GetAllNetworks() []*Network {
 }
 
 // CreateNewNetwork creates a new network definition in the system.
+//
+// CLIENTNET networks are created with CreateNewClientNetwork.
 func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Network, error) {
+	if nettype == CLIENTNET {
+		return nil, fmt.Errorf("validation error: %s networks must have an owner", CLIENTNET)
+	}
+	return createNetwork(name, cidr, nettype, via, nil)
+}
+
+// CreateNewClientNetwork creates a new CLIENTNET network definition, which is behind the given
+// user's vpn client.
+//
+// The owner announces the network with iroute, and the users that are associated with it get it
+// pushed as a route.
+func CreateNewClientNetwork(name, cidr, owner string) (*Network, error) {
+	if svr := TheServer(); !svr.IsInitialized() {
+		return nil, fmt.Errorf("you first need to create server")
+	}
+	user, err := GetUser(owner)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: owner `%s` can not be found", owner)
+	}
+	return createNetwork(name, cidr, CLIENTNET, "", user)
+}
+
+func createNetwork(name, cidr string, nettype NetworkType, via string, owner *User) (*Network, error) {
 	if svr := TheServer(); !svr.IsInitialized() {
 		return nil, fmt.Errorf("you first need to create server")
 	}
@@ -161,6 +199,10 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 	if err != nil {
 		return nil, fmt.Errorf("can not parse CIDR %s: %v", cidr, err)
 	}
+	block := CIDRBlock{Kind: CIDRNetwork, Name: name, CIDR: ipnet.String(), clientNet: nettype == CLIENTNET}
+	if err := checkCIDRConflict(block, nil); err != nil {
+		return nil, err
+	}
 
 	// Overwrite via with the parsed IPv4 string.
 	if nettype == ROUTE && via != "" {
@@ -181,6 +223,9 @@ func CreateNewNetwork(name, cidr string, nettype NetworkType, via string) (*Netw
 		Users: []*dbUserModel{},
 		Via:   via,
 	}
+	if owner != nil {
+		network.OwnerID = owner.ID
+	}
 	db.Save(&network)
 
 	if db.NewRecord(&network) {
@@ -199,12 +244,82 @@ func (n *Network) Delete() error {
 		return fmt.Errorf("you first need to create server")
 	}
 
+	db.Model(&n.dbNetworkModel).Association("Groups").Clear()
+	deleteNetworkACLs("network_id = ?", n.ID)
 	db.Unscoped().Delete(n.dbNetworkModel)
 	svr.EmitWithRestart()
 	logrus.Infof("network deleted: %s", n.Name)
 	return nil
 }
 
+// Update updates the name, the CIDR and the via of the network, keeping its associations.
+//
+// Empty name and cidr leave them as they are. via can only be set for ROUTE networks; empty via
+// makes the network be routed via the vpn server, and nil leaves it as it is.
+func (n *Network) Update(name, cidr string, via *string) error {
+	if svr := TheServer(); !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+
+	newName, newCIDR, newVia := n.Name, n.CIDR, n.Via
+	if name != "" && name != n.Name {
+		if !govalidator.Matches(name, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
+			return fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", name)
+		}
+		if _, err := GetNetwork(name); err == nil {
+			return fmt.Errorf("validation error: network `%s` already exists", name)
+		}
+		newName = name
+	}
+	if cidr != "" {
+		if !govalidator.IsCIDR(cidr) {
+			return fmt.Errorf("validation error: `%s` must be a network in the CIDR form", cidr)
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("can not parse CIDR %s: %v", cidr, err)
+		}
+		if n.MappedCIDR != "" {
+			_, mapped, err := net.ParseCIDR(n.MappedCIDR)
+			if err != nil {
+				return fmt.Errorf("can not parse CIDR %s: %v", n.MappedCIDR, err)
+			}
+			mappedOnes, _ := mapped.Mask.Size()
+			realOnes, _ := ipnet.Mask.Size()
+			if mappedOnes != realOnes {
+				return fmt.Errorf("validation error: `%s` must be of the same size as its virtual network %s", ipnet, n.MappedCIDR)
+			}
+		}
+		block := n.cidrBlock()
+		block.Name, block.CIDR = newName, ipnet.String()
+		own := func(b CIDRBlock) bool { return b.network == n.ID && b.Kind == CIDRNetwork }
+		if err := checkCIDRConflict(block, own); err != nil {
+			return err
+		}
+		newCIDR = ipnet.String()
+	}
+	if via != nil {
+		if n.Type != ROUTE {
+			return fmt.Errorf("validation error: via can only be set for %s networks", ROUTE)
+		}
+		newVia = ""
+		if *via != "" {
+			if !govalidator.IsIPv4(*via) {
+				return fmt.Errorf("validation error: `%s` must be a network in the IPv4 form", *via)
+			}
+			newVia = net.ParseIP(*via).To4().String()
+		}
+	}
+
+	if err := db.Model(&n.dbNetworkModel).Updates(map[string]interface{}{"Name": newName, "CIDR": newCIDR, "Via": newVia}).Error; err != nil {
+		return err
+	}
+	n.Name, n.CIDR, n.Via = newName, newCIDR, newVia
+	TheServer().EmitWithRestart()
+	logrus.Infof("network updated: %s (%s)", n.Name, n.CIDR)
+	return nil
+}
+
 // Associate allows the given user access to this network.
 func (n *Network) Associate(username string) error {
 	if svr := TheServer(); !svr.IsInitialized() {
@@ -268,11 +383,102 @@ func (n *Network) Dissociate(username string) error {
 	if userAssoc.Error != nil {
 		return fmt.Errorf("disassociation failed: %v", userAssoc.Error)
 	}
+	deleteNetworkACLs("network_id = ? AND user_id = ?", n.ID, user.ID)
 	svr.EmitWithRestart()
 	logrus.Infof("user '%s' is dissociated with the network '%s'", user.GetUsername(), n.Name)
 	return nil
 }
 
+// AssociateGroup allows the members of the given group access to this network.
+func (n *Network) AssociateGroup(groupName string) error {
+	if svr := TheServer(); !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	group, err := GetGroup(groupName)
+	if err != nil {
+		return fmt.Errorf("group can not be fetched: %v", err)
+	}
+	if n.hasGroup(group.ID) {
+		return fmt.Errorf("group %s is already associated with the network %s", group.Name, n.Name)
+	}
+
+	groupAssoc := db.Model(&n.dbNetworkModel).Association("Groups")
+	groupAssoc.Append(&group.dbGroupModel)
+	if groupAssoc.Error != nil {
+		return fmt.Errorf("association failed: %v", groupAssoc.Error)
+	}
+	TheServer().EmitWithRestart()
+	logrus.Infof("group '%s' is associated with the network '%s'", group.Name, n.Name)
+	return nil
+}
+
+// DissociateGroup breaks up the given group's association to the said network.
+func (n *Network) DissociateGroup(groupName string) error {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	group, err := GetGroup(groupName)
+	if err != nil {
+		return fmt.Errorf("group can not be fetched: %v", err)
+	}
+	if !n.hasGroup(group.ID) {
+		return fmt.Errorf("group %s is already not associated with the network %s", group.Name, n.Name)
+	}
+
+	groupAssoc := db.Model(&n.dbNetworkModel).Association("Groups")
+	groupAssoc.Delete(&group.dbGroupModel)
+	if groupAssoc.Error != nil {
+		return fmt.Errorf("disassociation failed: %v", groupAssoc.Error)
+	}
+	deleteNetworkACLs("network_id = ? AND group_id = ?", n.ID, group.ID)
+	svr.EmitWithRestart()
+	logrus.Infof("group '%s' is dissociated with the network '%s'", group.Name, n.Name)
+	return nil
+}
+
+// hasUser returns whether the user with the given id is associated with the network.
+func (n *Network) hasUser(userID uint) bool {
+	var users []dbUserModel
+	db.Model(&n.dbNetworkModel).Association("Users").Find(&users)
+	for _, u := range users {
+		if u.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroup returns whether the group with the given id is associated with the network.
+func (n *Network) hasGroup(groupID uint) bool {
+	var groups []dbGroupModel
+	db.Model(&n.dbNetworkModel).Association("Groups").Find(&groups)
+	for _, g := range groups {
+		if g.ID == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// isAssociated returns whether the user is allowed access to the network, either directly or
+// through one of the given groups of the user.
+func (n *Network) isAssociated(user *User, groups []*Group) bool {
+	for _, u := range n.Users {
+		if u.ID == user.ID {
+			return true
+		}
+	}
+	for _, ng := range n.Groups {
+		for _, g := range groups {
+			if ng.ID == g.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetName returns network's name.
 func (n *Network) GetName() string {
 	return n.Name
@@ -312,11 +518,224 @@ func (n *Network) GetAssociatedUsernames() []string {
 	return usernames
 }
 
+// GetAssociatedGroupNames returns the names of the network's associated groups.
+func (n *Network) GetAssociatedGroupNames() []string {
+	var names []string
+	for _, g := range n.Groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
 // GetVia returns network' via.
 func (n *Network) GetVia() string {
 	return n.Via
 }
 
+// GetOwnerUsername returns the name of the user that the network is behind.
+//
+// It's empty if the network isn't a CLIENTNET.
+func (n *Network) GetOwnerUsername() string {
+	if n.OwnerID == 0 {
+		return ""
+	}
+	var owner dbUserModel
+	if db.First(&owner, n.OwnerID).RecordNotFound() {
+		return ""
+	}
+	return owner.Username
+}
+
+// GetMappedCIDR returns the virtual network that the clients reach the network at.
+//
+// It's empty if the network isn't mapped.
+func (n *Network) GetMappedCIDR() string {
+	return n.MappedCIDR
+}
+
+// reachableCIDR returns the network that the clients reach the network at.
+func (n *Network) reachableCIDR() string {
+	if n.MappedCIDR != "" {
+		return n.MappedCIDR
+	}
+	return n.CIDR
+}
+
+// SetMapping maps the network 1:1 to the given virtual network, which is what the clients reach
+// it at. This way the networks with the same addresses, e.g. of the different sites, can be told
+// apart by the clients.
+//
+// The virtual network must be of the same size as the network and must not overlap with the vpn
+// network or with the other networks. Empty mappedCIDR removes the mapping.
+func (n *Network) SetMapping(mappedCIDR string) error {
+	if svr := TheServer(); !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	if mappedCIDR != "" {
+		if !govalidator.IsCIDR(mappedCIDR) {
+			return fmt.Errorf("validation error: `%s` must be a network in the CIDR form", mappedCIDR)
+		}
+		_, mapped, err := net.ParseCIDR(mappedCIDR)
+		if err != nil {
+			return fmt.Errorf("can not parse CIDR %s: %v", mappedCIDR, err)
+		}
+		_, real, err := net.ParseCIDR(n.CIDR)
+		if err != nil {
+			return fmt.Errorf("can not parse CIDR %s: %v", n.CIDR, err)
+		}
+		mappedOnes, _ := mapped.Mask.Size()
+		realOnes, _ := real.Mask.Size()
+		if mappedOnes != realOnes {
+			return fmt.Errorf("validation error: `%s` must be of the same size as %s", mapped, n.CIDR)
+		}
+		mappedCIDR = mapped.String()
+		block := CIDRBlock{Kind: CIDRMapped, Name: n.Name, CIDR: mappedCIDR, network: n.ID}
+		ownMapping := func(b CIDRBlock) bool { return b.network == n.ID && b.Kind == CIDRMapped }
+		if err := checkCIDRConflict(block, ownMapping); err != nil {
+			return err
+		}
+	} else if n.MappedCIDR != "" {
+		// The network must not overlap with the other networks once it's reached at its own addresses.
+		block := n.cidrBlock()
+		block.mapped = false
+		own := func(b CIDRBlock) bool { return b.network == n.ID }
+		if err := checkCIDRConflict(block, own); err != nil {
+			return err
+		}
+	}
+
+	if err := db.Model(&n.dbNetworkModel).Updates(map[string]interface{}{"MappedCIDR": mappedCIDR}).Error; err != nil {
+		return err
+	}
+	n.MappedCIDR = mappedCIDR
+	TheServer().EmitWithRestart()
+	if mappedCIDR == "" {
+		logrus.Infof("network unmapped: %s", n.Name)
+	} else {
+		logrus.Infof("network mapped: %s (%s -> %s)", n.Name, mappedCIDR, n.CIDR)
+	}
+	return nil
+}
+
+// GetDNSDomain returns the domain that is resolved by the dns servers of the network for the
+// associated clients.
+func (n *Network) GetDNSDomain() string {
+	return n.DNSDomain
+}
+
+// GetDNSServers returns the dns servers that resolve the domain of the network.
+func (n *Network) GetDNSServers() []string {
+	return splitList(n.DNSServers)
+}
+
+// SetDNS makes the associated clients resolve the domain with the given dns servers, and the rest
+// of the names with their usual dns servers (split dns).
+//
+// Empty domain removes the split dns of the network.
+func (n *Network) SetDNS(domain string, servers []string) error {
+	if svr := TheServer(); !svr.IsInitialized() {
+		return fmt.Errorf("you first need to create server")
+	}
+	if domain == "" {
+		if len(servers) > 0 {
+			return fmt.Errorf("validation error: dns servers can only be set with a domain")
+		}
+	} else {
+		if !govalidator.IsDNSName(domain) {
+			return fmt.Errorf("validation error: `%s` must be a domain name", domain)
+		}
+		if len(servers) == 0 {
+			return fmt.Errorf("validation error: at least one dns server is required for `%s`", domain)
+		}
+		for _, ip := range servers {
+			if !govalidator.IsIPv4(ip) {
+				return fmt.Errorf("validation error: dns server `%s` must be an IPv4 address", ip)
+			}
+		}
+	}
+
+	dnsServers := strings.Join(servers, ",")
+	if err := db.Model(&n.dbNetworkModel).Updates(map[string]interface{}{"DNSDomain": domain, "DNSServers": dnsServers}).Error; err != nil {
+		return err
+	}
+	n.DNSDomain, n.DNSServers = domain, dnsServers
+	TheServer().EmitWithRestart()
+	if domain == "" {
+		logrus.Infof("network dns removed: %s", n.Name)
+	} else {
+		logrus.Infof("network dns set: %s (%s -> %s)", n.Name, domain, dnsServers)
+	}
+	return nil
+}
+
+// natMaps returns the 1:1 translations of the mapped networks.
+func natMaps() []firewall.NetMap {
+	var maps []firewall.NetMap
+	for _, n := range GetAllNetworks() {
+		if n.MappedCIDR != "" {
+			maps = append(maps, firewall.NetMap{Virtual: n.MappedCIDR, Real: n.CIDR})
+		}
+	}
+	return maps
+}
+
+// getOwnedNetworkNames returns the names of the CLIENTNET networks that are behind the user.
+func getOwnedNetworkNames(userID uint) []string {
+	var names []string
+	db.Model(&dbNetworkModel{}).Where("owner_id = ?", userID).Pluck("name", &names)
+	return names
+}
+
+// GetNATMode returns how the clients' traffic to the network is translated.
+//
+// It's empty if the network doesn't override the server's nat mode.
+func (n *Network) GetNATMode() string {
+	return n.NATMode
+}
+
+// GetSNATTo returns the source address of the clients' traffic to the network when the nat mode is snat.
+func (n *Network) GetSNATTo() string {
+	return n.SNATTo
+}
+
+// SetNAT overrides the server's nat mode for the clients' traffic to the network.
+//
+// Only the SERVERNET networks can override the nat mode. Empty mode removes the override.
+func (n *Network) SetNAT(mode, snatTo string) error {
+	if n.Type != SERVERNET {
+		return fmt.Errorf("validation error: nat mode can only be overridden for %s networks", SERVERNET)
+	}
+	if mode != "" && !firewall.IsNATMode(mode) {
+		return fmt.Errorf("validation error: unknown nat mode `%s`", mode)
+	}
+	if mode == string(firewall.SNAT) {
+		if !govalidator.IsIPv4(snatTo) {
+			return fmt.Errorf("validation error: snat mode requires an IPv4 address to translate to, got `%s`", snatTo)
+		}
+	} else if snatTo != "" {
+		return fmt.Errorf("validation error: snat address can only be set with the snat mode")
+	}
+
+	if err := db.Model(&n.dbNetworkModel).Updates(map[string]interface{}{"NATMode": mode, "SNATTo": snatTo}).Error; err != nil {
+		return err
+	}
+	n.NATMode, n.SNATTo = mode, snatTo
+	reapplyNat()
+	logrus.Infof("nat of the network %s is set to %q", n.Name, mode)
+	return nil
+}
+
+// natOverrides returns the nat overrides of the networks.
+func natOverrides() []firewall.NATOverride {
+	var overrides []firewall.NATOverride
+	for _, n := range GetAllNetworks() {
+		if n.Type == SERVERNET && n.NATMode != "" {
+			overrides = append(overrides, firewall.NATOverride{Destination: n.CIDR, Mode: firewall.NATMode(n.NATMode), SNATTo: n.SNATTo})
+		}
+	}
+	return overrides
+}
+
 // interfaceOfIP returns a network interface that has the given IP.
 func interfaceOfIP(ipnet *net.IPNet) *net.Interface {
 	ifaces, err := net.Interfaces()
@@ -377,7 +796,8 @@ func routedInterface(network string, flags net.Flags) *net.Interface {
 func getOutboundInterface() *net.Interface {
 	conn, err := net.Dial("udp", "8.8.8.8:80")
 	if err != nil {
-		log.Fatal(err)
+		logrus.Debugf("can not find the outbound interface: %v", err)
+		return nil
 	}
 	defer conn.Close()
 
@@ -452,72 +872,159 @@ func routableIP(network string, ip net.IP) net.IP {
 	return nil
 }
 
-// ensureNatEnabled launches a goroutine that constantly tries to enable nat.
+// NATStatus is the status of the nat of the vpn server.
+type NATStatus struct {
+	Enabled     bool      // whether the nat is in place
+	Attempts    int       // number of attempts to enable the nat since the last change
+	LastError   string    // error of the last failed attempt
+	NextAttempt time.Time // time of the next attempt, if the last one is failed
+}
+
+var natStatus NATStatus
+var natStop chan struct{}
+var natMu sync.Mutex
+
+// GetNATStatus returns the status of the nat of the vpn server.
+func GetNATStatus() NATStatus {
+	natMu.Lock()
+	defer natMu.Unlock()
+	return natStatus
+}
+
+// ensureNatEnabled launches a goroutine that tries to enable nat until it succeeds, backing off
+// exponentially between the attempts.
+//
+// The goroutine of the previous call, if it's still trying, is stopped.
 func ensureNatEnabled() {
-	// Nat enablerer
+	natMu.Lock()
+	defer natMu.Unlock()
+	if natStop != nil {
+		close(natStop)
+	}
+	stop := make(chan struct{})
+	natStop = stop
+	natStatus = NATStatus{}
+
+	// When testing, apply the nat to the fake firewall right away instead of racing with the
+	// tests on the emitted files.
+	if Testing {
+		natStatus.Attempts = 1
+		if err := enableNat(); err != nil {
+			natStatus.LastError = err.Error()
+			return
+		}
+		natStatus.Enabled = true
+		natStop = nil
+		return
+	}
+
 	go func() {
+		backoff := DefaultNATRetryMin
 		for {
 			err := enableNat()
+
+			natMu.Lock()
+			select {
+			case <-stop:
+				natMu.Unlock()
+				return
+			default:
+			}
+			natStatus.Attempts++
 			if err == nil {
+				natStatus.Enabled, natStatus.LastError, natStatus.NextAttempt = true, "", time.Time{}
+				natStop = nil
+				natMu.Unlock()
 				logrus.Debug("nat is enabled")
 				return
 			}
-			logrus.Debugf("can not enable nat: %v", err)
-			// TODO(cad): employ a exponential back-off approach here
-			// instead of sleeping for the constant duration.
-			time.Sleep(1 * time.Second)
+			natStatus.LastError = err.Error()
+			natStatus.NextAttempt = time.Now().Add(backoff)
+			attempts := natStatus.Attempts
+			natMu.Unlock()
+
+			logrus.Warnf("can not enable nat (attempt %d), retrying in %s: %v", attempts, backoff, err)
+			select {
+			case <-stop:
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > DefaultNATRetryMax {
+				backoff = DefaultNATRetryMax
+			}
 		}
 	}()
 }
 
-// enableNat is an idempotent command that ensures nat is enabled for the vpn server.
-func enableNat() error {
+// stopNatEnabler stops trying to enable nat.
+func stopNatEnabler() {
+	natMu.Lock()
+	defer natMu.Unlock()
+	if natStop != nil {
+		close(natStop)
+		natStop = nil
+	}
+	natStatus = NATStatus{}
+}
+
+// natInterfaces returns the names of the vpn network interface and the interface that the
+// traffic of the vpn clients goes out of.
+func natInterfaces() (string, string, error) {
+	egress := TheServer().GetEgressIface()
+	// When testing, there are no interfaces. Just pretend there are.
 	if Testing {
-		return nil
+		if egress != "" {
+			return "tun0", egress, nil
+		}
+		return "tun0", "eth0", nil
 	}
 	// rif := routedInterface("ip", net.FlagUp|net.FlagBroadcast)
 	// if rif == nil {
 	// 	return fmt.Errorf("can not get routable network interface")
 	// }
-	rif := getOutboundInterface()
-	if rif == nil {
-		return fmt.Errorf("can not get default gw interface")
+	var rif *net.Interface
+	if egress != "" {
+		var err error
+		if rif, err = net.InterfaceByName(egress); err != nil {
+			return "", "", fmt.Errorf("can not get egress interface %s: %v", egress, err)
+		}
+	} else if rif = getOutboundInterface(); rif == nil {
+		return "", "", fmt.Errorf("can not get default gw interface")
 	}
 
 	vpnIfc := vpnInterface()
 	if vpnIfc == nil {
-		return fmt.Errorf("can not get vpn network interface on the system")
+		return "", "", fmt.Errorf("can not get vpn network interface on the system")
 	}
+	return vpnIfc.Name, rif.Name, nil
+}
 
-	// Enable ip forwarding.
-	TheServer().emitToFile("/proc/sys/net/ipv4/ip_forward", "1", 0)
-	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
+// enableNat is an idempotent command that ensures nat is enabled for the vpn server.
+//
+// The nat rules of the previous vpn network or outbound interface are replaced.
+func enableNat() error {
+	vpnIfc, rif, err := natInterfaces()
 	if err != nil {
-		return fmt.Errorf("can not create new iptables object: %v", err)
-	}
-
-	svr := TheServer()
-
-	mask := net.IPMask(net.ParseIP(svr.Mask))
-	prefix := net.ParseIP(svr.Net)
-	netw := prefix.Mask(mask).To4()
-	netw[3] = byte(1) // Server is always gets xxx.xxx.xxx.1
-	ipnet := net.IPNet{IP: netw, Mask: mask}
-
-	// Append iptables nat rules.
-	if err := ipt.AppendUnique("nat", "POSTROUTING", "-s", ipnet.String(), "-o", rif.Name, "-j", "MASQUERADE"); err != nil {
 		return err
 	}
 
-	if err := ipt.AppendUnique("filter", "FORWARD", "-i", rif.Name, "-o", vpnIfc.Name, "-m", "state", "--state", "RELATED,ESTABLISHED", "-j", "ACCEPT"); err != nil {
-		return err
-	}
+	// Enable ip forwarding.
+	svr := TheServer()
+	svr.emitToFile("/proc/sys/net/ipv4/ip_forward", "1", 0)
 
-	if err := ipt.AppendUnique("filter", "FORWARD", "-i", vpnIfc.Name, "-o", rif.Name, "-j", "ACCEPT"); err != nil {
+	fw, err := svr.getFirewall()
+	if err != nil {
 		return err
 	}
-	return nil
-
+	return fw.EnsureNAT(firewall.NAT{
+		Source:    svr.clientSource(),
+		VPNIface:  vpnIfc,
+		OutIface:  rif,
+		Mode:      firewall.NATMode(svr.GetNATMode()),
+		SNATTo:    svr.GetSNATTo(),
+		Overrides: natOverrides(),
+		Maps:      natMaps(),
+	})
 }
 
 // HostID2IP converts a host id (32-bit unsigned integer) to an IP address.
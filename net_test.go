@@ -3,8 +3,12 @@ package ovpm
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"go.uber.org/thriftrw/ptr"
 )
 
 func TestVPNCreateNewNetwork(t *testing.T) {
@@ -68,7 +72,7 @@ func TestVPNCreateNewNetwork(t *testing.T) {
 	}
 
 	for i, tt := range networknametests {
-		_, err := CreateNewNetwork(tt.networkname, fmt.Sprintf("192.168.%d.0/24", i), SERVERNET, "")
+		_, err := CreateNewNetwork(tt.networkname, fmt.Sprintf("192.168.%d.0/24", i+10), SERVERNET, "")
 		if ok := (err == nil); ok != tt.ok {
 			t.Fatalf("expcted condition failed '%s': %v", tt.networkname, err)
 		}
@@ -194,6 +198,71 @@ func TestVPNGetAllNetworks(t *testing.T) {
 	}
 }
 
+func TestNetworkUpdate(t *testing.T) {
+	// Initialize:
+	setupTestCase()
+	CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", true, 0, true, "")
+	office, _ := CreateNewNetwork("office", "10.10.0.0/16", ROUTE, "")
+	lan, _ := CreateNewNetwork("lan", "192.168.1.0/24", SERVERNET, "")
+	office.Associate("alice")
+	office.SetRules("alice", []string{"tcp/22"})
+
+	// Test:
+	for _, tt := range []struct {
+		name, cidr string
+		via        *string
+	}{
+		{"lan", "", nil},
+		{"hq-1", "", nil},
+		{"", "10.10.0.0", nil},
+		{"", "192.168.0.0/16", nil},
+		{"", "10.9.0.0/24", nil},
+		{"", "", ptr.String("gw")},
+	} {
+		if err := office.Update(tt.name, tt.cidr, tt.via); err == nil {
+			t.Errorf("Update(%q, %q) is expected to fail", tt.name, tt.cidr)
+		}
+	}
+	if err := lan.Update("", "", ptr.String("192.168.1.1")); err == nil {
+		t.Fatalf("via is not expected to be set for SERVERNET networks")
+	}
+
+	if err := office.Update("hq", "10.20.0.0/16", ptr.String("10.9.0.5")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetNetwork("office"); err == nil {
+		t.Fatalf("network is not expected to be found by the old name")
+	}
+	hq, err := GetNetwork("hq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hq.GetCIDR() != "10.20.0.0/16" || hq.GetVia() != "10.9.0.5" {
+		t.Fatalf("network is not updated: %s via %s", hq.GetCIDR(), hq.GetVia())
+	}
+	if users := hq.GetAssociatedUsernames(); len(users) != 1 || users[0] != "alice" {
+		t.Fatalf("associations are expected to be kept: %v", users)
+	}
+	if acls := hq.GetACLs(); len(acls) != 1 || acls[0].GetUsername() != "alice" {
+		t.Fatalf("rules are expected to be kept: %+v", acls)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, `push "route 10.20.0.0 255.255.0.0 10.9.0.5"`) {
+		t.Fatalf("ccd is expected to push the updated route:\n%s", ccd)
+	}
+
+	// Empty via routes the network via the vpn server again.
+	if err := hq.Update("", "", ptr.String("")); err != nil {
+		t.Fatal(err)
+	}
+	if hq.GetVia() != "" {
+		t.Fatalf("via is expected to be reset: %s", hq.GetVia())
+	}
+}
+
 func TestNetAssociate(t *testing.T) {
 	// Initialize:
 	setupTestCase()
@@ -318,6 +387,67 @@ func TestNetGetAssociatedUsers(t *testing.T) {
 	}
 }
 
+func TestClientNetwork(t *testing.T) {
+	// Initialize:
+	setupTestCase()
+	CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("branch1", "1234", false, 0, true, "")
+	CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, true, "")
+
+	// Test:
+	if _, err := CreateNewNetwork("branch", "192.168.50.0/24", CLIENTNET, ""); err == nil {
+		t.Fatalf("CLIENTNET is not expected to be created without an owner")
+	}
+	if _, err := CreateNewClientNetwork("branch", "192.168.50.0/24", "nobody"); err == nil {
+		t.Fatalf("CLIENTNET is not expected to be created with an unknown owner")
+	}
+	if _, err := CreateNewClientNetwork("branch", "10.9.0.0/16", "branch1"); err == nil {
+		t.Fatalf("CLIENTNET is not expected to overlap with the vpn network")
+	}
+	branch, err := CreateNewClientNetwork("branch", "192.168.50.0/24", "branch1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateNewNetwork("lan", "192.168.50.128/25", SERVERNET, ""); err == nil {
+		t.Fatalf("networks are not expected to overlap with a CLIENTNET")
+	}
+	if _, err := CreateNewNetwork("lan", "192.168.60.0/24", SERVERNET, ""); err != nil {
+		t.Fatal(err)
+	}
+	if branch.GetOwnerUsername() != "branch1" {
+		t.Fatalf("owner is expected to be branch1, got %s", branch.GetOwnerUsername())
+	}
+	branch.Associate("alice")
+	svr.emitServerConf()
+	svr.emitCCD()
+
+	if !strings.Contains(fs[_DefaultVPNConfPath], "route 192.168.50.0 255.255.255.0") {
+		t.Fatalf("server.conf is expected to route the CLIENTNET:\n%s", fs[_DefaultVPNConfPath])
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "branch1")]; !strings.Contains(ccd, "iroute 192.168.50.0 255.255.255.0") || strings.Contains(ccd, `push "route 192.168.50.0`) {
+		t.Fatalf("owner's ccd is expected to iroute the CLIENTNET:\n%s", ccd)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, `push "route 192.168.50.0 255.255.255.0"`) || strings.Contains(ccd, "iroute") {
+		t.Fatalf("associated user's ccd is expected to push the CLIENTNET:\n%s", ccd)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "bob")]; strings.Contains(ccd, "192.168.50.0") {
+		t.Fatalf("unassociated user's ccd is not expected to have the CLIENTNET:\n%s", ccd)
+	}
+
+	owner, _ := GetUser("branch1")
+	if err := owner.Delete(); err == nil {
+		t.Fatalf("owner is not expected to be deleted while the CLIENTNET is behind it")
+	}
+	branch.Delete()
+	if err := owner.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNetworkTypeFromString(t *testing.T) {
 	// Initialize:
 	setupTestCase()
@@ -336,6 +466,7 @@ func TestNetworkTypeFromString(t *testing.T) {
 	}{
 		{"servernet", args{"SERVERNET"}, SERVERNET},
 		{"route", args{"ROUTE"}, ROUTE},
+		{"clientnet", args{"CLIENTNET"}, CLIENTNET},
 		{"unknown", args{"aasdfsafdASDF"}, UNDEFINEDNET},
 	}
 	for _, tt := range tests {
@@ -359,7 +490,7 @@ func TestGetAllNetworkTypes(t *testing.T) {
 		name string
 		want []NetworkType
 	}{
-		{"default", []NetworkType{UNDEFINEDNET, SERVERNET, ROUTE}},
+		{"default", []NetworkType{UNDEFINEDNET, SERVERNET, ROUTE, CLIENTNET}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -388,6 +519,7 @@ func TestIsNetworkType(t *testing.T) {
 	}{
 		{"servernet", args{"SERVERNET"}, true},
 		{"route", args{"ROUTE"}, true},
+		{"clientnet", args{"CLIENTNET"}, true},
 		{"invalid", args{"ADSF"}, false},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,363 @@
+package ovpm
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/sirupsen/logrus"
+)
+
+// TLS modes of the SMTP connection.
+const (
+	SMTPTLSNone     = "none"     // plaintext connection
+	SMTPTLSStartTLS = "starttls" // plaintext connection that is upgraded with STARTTLS
+	SMTPTLS         = "tls"      // implicit TLS connection
+)
+
+// Names of the mail templates. Templates can be overridden by the <name>.tmpl files in the
+// template directory of the SMTP config.
+//
+// The first line of a rendered template is the subject of the message, the rest is the body.
+const (
+	MailWelcome       = "welcome"        // .Username, .Hostname, .URL
+	MailCertExpiring  = "cert-expiring"  // .Username, .Hostname, .URL, .ExpiresAt, .Renewed
+	MailPasswordReset = "password-reset" // .Username, .Hostname, .Link, .ExpiresAt
+	MailAdminDigest   = "admin-digest"   // .Hostname, .Since, .ExpiringCerts (.Username, .ExpiresAt, .Renewable), .Failures
+	MailInvitation    = "invitation"     // .Username, .Hostname, .Link, .ExpiresAt
+)
+
+var defaultMailTemplates = map[string]string{
+	MailWelcome:       welcomeMailTemplate,
+	MailCertExpiring:  certExpiringMailTemplate,
+	MailPasswordReset: passwordResetMailTemplate,
+	MailAdminDigest:   adminDigestMailTemplate,
+	MailInvitation:    invitationMailTemplate,
+}
+
+// SMTPConfig holds the settings of the email notifications.
+type SMTPConfig struct {
+	Host        string   // Host of the SMTP server.
+	Port        string   // Port of the SMTP server.
+	TLS         string   // TLS mode of the connection: none, starttls or tls.
+	Username    string   // Username to authenticate with. If empty, no authentication is done.
+	Password    string   // Password to authenticate with.
+	From        string   // Address that the messages are sent from.
+	AdminEmails []string // Addresses that receive the admin digests besides the admin users' own.
+	TemplateDir string   // Directory of the templates that override the default ones.
+}
+
+var smtpConfig *SMTPConfig
+var smtpTemplates *template.Template
+var smtpConfigMu sync.RWMutex
+
+// SetSMTPConfig validates and sets the configuration of the email notifications.
+//
+// Passing nil disables the email notifications.
+func SetSMTPConfig(cfg *SMTPConfig) error {
+	var tmpl *template.Template
+	if cfg != nil {
+		if cfg.Host == "" {
+			return fmt.Errorf("validation error: smtp host can not be empty")
+		}
+		if cfg.Port == "" {
+			cfg.Port = DefaultSMTPPort
+		}
+		if !govalidator.IsPort(cfg.Port) {
+			return fmt.Errorf("validation error: smtp port `%s` must be a port number", cfg.Port)
+		}
+		switch cfg.TLS {
+		case "":
+			cfg.TLS = SMTPTLSStartTLS
+		case SMTPTLSNone, SMTPTLSStartTLS, SMTPTLS:
+		default:
+			return fmt.Errorf("validation error: smtp tls mode `%s` must be one of %s, %s or %s", cfg.TLS, SMTPTLSNone, SMTPTLSStartTLS, SMTPTLS)
+		}
+		if !govalidator.IsEmail(cfg.From) {
+			return fmt.Errorf("validation error: smtp from address `%s` must be an email address", cfg.From)
+		}
+		for _, addr := range cfg.AdminEmails {
+			if !govalidator.IsEmail(addr) {
+				return fmt.Errorf("validation error: admin address `%s` must be an email address", addr)
+			}
+		}
+		var err error
+		if tmpl, err = loadMailTemplates(cfg.TemplateDir); err != nil {
+			return err
+		}
+	}
+
+	smtpConfigMu.Lock()
+	defer smtpConfigMu.Unlock()
+	smtpConfig = cfg
+	smtpTemplates = tmpl
+	return nil
+}
+
+// GetSMTPConfig returns the configuration of the email notifications. It returns nil if they are
+// disabled.
+func GetSMTPConfig() *SMTPConfig {
+	smtpConfigMu.RLock()
+	defer smtpConfigMu.RUnlock()
+	return smtpConfig
+}
+
+// IsSMTPEnabled returns whether the email notifications are enabled.
+func IsSMTPEnabled() bool {
+	return GetSMTPConfig() != nil
+}
+
+// loadMailTemplates parses the default mail templates, overriding them with the ones in the
+// directory, if any.
+func loadMailTemplates(dir string) (*template.Template, error) {
+	tmpl := template.New("mail")
+	for name, text := range defaultMailTemplates {
+		if dir != "" {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name+".tmpl"))
+			switch {
+			case err == nil:
+				text = string(b)
+			case !os.IsNotExist(err):
+				return nil, fmt.Errorf("can not read mail template %s: %v", name, err)
+			}
+		}
+		if _, err := tmpl.New(name).Parse(text); err != nil {
+			return nil, fmt.Errorf("validation error: mail template %s is invalid: %v", name, err)
+		}
+	}
+	return tmpl, nil
+}
+
+// sendMail renders the named template with the data and sends it to the addresses.
+func sendMail(to []string, name string, data interface{}) error {
+	smtpConfigMu.RLock()
+	cfg, tmpl := smtpConfig, smtpTemplates
+	smtpConfigMu.RUnlock()
+	if cfg == nil {
+		return fmt.Errorf("email notifications are not enabled")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients to send %s to", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("can not render mail template %s: %v", name, err)
+	}
+	parts := strings.SplitN(buf.String(), "\n", 2)
+	subject, body := strings.TrimSpace(parts[0]), ""
+	if len(parts) > 1 {
+		body = parts[1]
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.Replace(strings.Replace(body, "\r\n", "\n", -1), "\n", "\r\n", -1))
+
+	if err := deliverMail(cfg, to, msg.Bytes()); err != nil {
+		return fmt.Errorf("can not send %s mail: %v", name, err)
+	}
+	logrus.Debugf("%s mail is sent to %s", name, strings.Join(to, ", "))
+	return nil
+}
+
+// deliverMail sends the message to the addresses through the SMTP server.
+func deliverMail(cfg *SMTPConfig, to []string, msg []byte) error {
+	addr := net.JoinHostPort(cfg.Host, cfg.Port)
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+
+	var c *smtp.Client
+	if cfg.TLS == SMTPTLS {
+		conn, err := tls.Dial("tcp", addr, tlsConfig)
+		if err != nil {
+			return err
+		}
+		if c, err = smtp.NewClient(conn, cfg.Host); err != nil {
+			conn.Close()
+			return err
+		}
+	} else {
+		var err error
+		if c, err = smtp.Dial(addr); err != nil {
+			return err
+		}
+	}
+	defer c.Close()
+
+	if cfg.TLS == SMTPTLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server doesn't support STARTTLS")
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// NotifyWelcome sends the user its welcome message with the link of the web ui, where it can
+// download its vpn profile.
+func (u *User) NotifyWelcome() error {
+	if u.Email == "" {
+		return fmt.Errorf("user has no email address: %s", u.Username)
+	}
+	return sendMail([]string{u.Email}, MailWelcome, struct {
+		Username, Hostname, URL string
+	}{u.Username, TheServer().GetHostname(), GetWebURL()})
+}
+
+// notifyCertExpiring warns the user that its cert expires at the time. If it's renewed, the user
+// is asked to download its new vpn profile.
+func (u *User) notifyCertExpiring(expiresAt time.Time, renewed bool) error {
+	if u.Email == "" || !IsSMTPEnabled() {
+		return nil
+	}
+	return sendMail([]string{u.Email}, MailCertExpiring, struct {
+		Username, Hostname, URL string
+		ExpiresAt               time.Time
+		Renewed                 bool
+	}{u.Username, TheServer().GetHostname(), GetWebURL(), expiresAt, renewed})
+}
+
+// expiringCert is a client cert that is listed in the admin digests.
+type expiringCert struct {
+	Username  string
+	ExpiresAt time.Time
+	Renewable bool // the CA outlives the cert, so it can be renewed
+}
+
+// sendAdminDigest sends the admins the certs that expire within the renewal period and the
+// process failures since the time. Nothing is sent if there is nothing to report.
+func sendAdminDigest(since, now time.Time) error {
+	svr := TheServer()
+	if !IsSMTPEnabled() || !svr.IsInitialized() {
+		return nil
+	}
+	users, err := GetAllUsers()
+	if err != nil {
+		return err
+	}
+	to := append([]string{}, GetSMTPConfig().AdminEmails...)
+	var certs []expiringCert
+	caExpiresAt := svr.CAExpiresAt()
+	for _, user := range users {
+		if user.IsAdmin() && user.Email != "" {
+			to = append(to, user.Email)
+		}
+		if user.IsCertExpiring(now) {
+			certs = append(certs, expiringCert{user.Username, user.ExpiresAt(), caExpiresAt.After(user.ExpiresAt())})
+		}
+	}
+	entries, err := GetAuditEntries(AuditProcessFailure, 0)
+	if err != nil {
+		return err
+	}
+	var failures []*AuditEntry
+	for _, e := range entries {
+		if e.GetCreatedAt().After(since) {
+			failures = append(failures, e)
+		}
+	}
+	if len(certs) == 0 && len(failures) == 0 {
+		return nil
+	}
+	if len(to) == 0 {
+		logrus.Warnf("admin digest can not be sent: there are no admin email addresses")
+		return nil
+	}
+
+	err = sendMail(to, MailAdminDigest, struct {
+		Hostname      string
+		Since         time.Time
+		ExpiringCerts []expiringCert
+		Failures      []*AuditEntry
+	}{svr.GetHostname(), since, certs, failures})
+	if err != nil {
+		return err
+	}
+	audit(AuditAdminDigest, "", strings.Join(to, ","), "", fmt.Sprintf("%d expiring certs, %d process failures", len(certs), len(failures)))
+	return nil
+}
+
+// lastAdminDigestAt returns the time that the last admin digest is sent at, or zero time if none
+// is sent yet.
+func lastAdminDigestAt() time.Time {
+	entries, err := GetAuditEntries(AuditAdminDigest, 1)
+	if err != nil || len(entries) == 0 {
+		return time.Time{}
+	}
+	return entries[0].GetCreatedAt()
+}
+
+// StartAdminDigestJob sends the admin digests periodically in the background until the returned
+// function is called.
+//
+// The first digest covers the process failures since the last digest, including the ones that
+// ovpmd exited with before it's started again.
+func StartAdminDigestJob(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		since := lastAdminDigestAt()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := sendAdminDigest(since, now); err != nil {
+					logrus.Errorf("admin digest failed: %v", err)
+					continue
+				}
+				since = now
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// recordProcessFailure records that the OpenVPN process failed in the audit log, so it's reported
+// in the next admin digest.
+func recordProcessFailure(details string) {
+	audit(AuditProcessFailure, "", "openvpn", "", details)
+}
@@ -0,0 +1,272 @@
+package ovpm
+
+import (
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage is a message that is received by the smtpStub.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// smtpStub is an in-process SMTP server that records the messages that are sent to it.
+type smtpStub struct {
+	ln   net.Listener
+	msgs chan smtpMessage
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen for the smtp stub: %v", err)
+	}
+	s := &smtpStub{ln: ln, msgs: make(chan smtpMessage, 16)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStub) port() string {
+	_, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	return port
+}
+
+func (s *smtpStub) close() {
+	s.ln.Close()
+}
+
+func (s *smtpStub) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+	var msg smtpMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		arg := ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			arg = strings.Trim(line[i+1:], " <>")
+		}
+		switch strings.ToUpper(strings.Fields(line)[0]) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg = smtpMessage{from: arg}
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, arg)
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			lines, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+			msg.data = strings.Join(lines, "\n")
+			s.msgs <- msg
+			tp.PrintfLine("250 ok")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+// receive returns the next message that is received, or fails the test if none is received.
+func (s *smtpStub) receive(t *testing.T) smtpMessage {
+	t.Helper()
+	select {
+	case msg := <-s.msgs:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no message is received")
+	}
+	return smtpMessage{}
+}
+
+func TestSetSMTPConfig(t *testing.T) {
+	defer SetSMTPConfig(nil)
+
+	for _, cfg := range []*SMTPConfig{
+		{From: "ovpm@example.com"},
+		{Host: "localhost", From: "ovpm"},
+		{Host: "localhost", From: "ovpm@example.com", TLS: "ssl"},
+		{Host: "localhost", From: "ovpm@example.com", Port: "smtp"},
+		{Host: "localhost", From: "ovpm@example.com", AdminEmails: []string{"ops"}},
+	} {
+		if err := SetSMTPConfig(cfg); err == nil {
+			t.Fatalf("smtp config is expected to be invalid: %+v", cfg)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "ovpm-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, MailWelcome+".tmpl"), []byte("{{ .Username"), 0644)
+	cfg := &SMTPConfig{Host: "localhost", From: "ovpm@example.com", TemplateDir: dir}
+	if err := SetSMTPConfig(cfg); err == nil {
+		t.Fatalf("invalid template is expected to be rejected")
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, MailWelcome+".tmpl"), []byte("Hi {{ .Username }}\nWelcome aboard."), 0644)
+	if err := SetSMTPConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !IsSMTPEnabled() {
+		t.Fatalf("email notifications are expected to be enabled")
+	}
+	if cfg.Port != DefaultSMTPPort || cfg.TLS != SMTPTLSStartTLS {
+		t.Fatalf("smtp config is expected to be defaulted: %+v", cfg)
+	}
+	SetSMTPConfig(nil)
+	if IsSMTPEnabled() {
+		t.Fatalf("email notifications are expected to be disabled")
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	defer SetCertConfig(CertConfig{RenewBefore: DefaultCertRenewBefore})
+	if err := SetCertConfig(CertConfig{CALifetime: 365 * 24 * time.Hour, ClientLifetime: 60 * 24 * time.Hour, RenewBefore: DefaultCertRenewBefore}); err != nil {
+		t.Fatal(err)
+	}
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	smtpd := newSMTPStub(t)
+	defer smtpd.close()
+	defer SetSMTPConfig(nil)
+	defer SetWebURL("")
+	if err := SetWebURL("https://vpn.example.com/"); err != nil {
+		t.Fatal(err)
+	}
+	err := SetSMTPConfig(&SMTPConfig{
+		Host:        "127.0.0.1",
+		Port:        smtpd.port(),
+		TLS:         SMTPTLSNone,
+		Username:    "ovpm",
+		Password:    "secret",
+		From:        "ovpm@example.com",
+		AdminEmails: []string{"ops@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	bob, _ := CreateNewUser("bob", "1234", false, 0, false, "")
+
+	// Test:
+	if err := alice.SetEmail("alice"); err == nil {
+		t.Fatalf("invalid email address is expected to be rejected")
+	}
+	if err := alice.SetEmail("alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := bob.NotifyWelcome(); err == nil {
+		t.Fatalf("bob is not expected to be notified without an email address")
+	}
+
+	// Welcome message links the web ui.
+	if err := alice.NotifyWelcome(); err != nil {
+		t.Fatal(err)
+	}
+	msg := smtpd.receive(t)
+	if msg.from != "ovpm@example.com" || len(msg.to) != 1 || msg.to[0] != "alice@example.com" {
+		t.Fatalf("welcome message is expected to be sent from ovpm to alice: %+v", msg)
+	}
+	if !strings.Contains(msg.data, "Subject: Welcome to localhost VPN") || !strings.Contains(msg.data, "https://vpn.example.com\n") {
+		t.Fatalf("welcome message is expected to link the web ui:\n%s", msg.data)
+	}
+
+	// Password reset links can only be used once.
+	if err := RequestPasswordReset("bob", ""); err == nil {
+		t.Fatalf("password reset of bob is not expected to be requested without an email address")
+	}
+	if err := RequestPasswordReset("alice", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	msg = smtpd.receive(t)
+	match := regexp.MustCompile(`https://vpn\.example\.com/reset-password\?token=([0-9a-f]+)`).FindStringSubmatch(msg.data)
+	if match == nil {
+		t.Fatalf("password reset message is expected to have the link:\n%s", msg.data)
+	}
+	if err := ResetPasswordWithToken("foo", "5678", ""); err == nil {
+		t.Fatalf("invalid password reset token is expected to be rejected")
+	}
+	if err := ResetPasswordWithToken(match[1], "5678", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Authenticate("alice", "5678"); err != nil {
+		t.Fatalf("alice is expected to log in with the new password: %v", err)
+	}
+	if err := ResetPasswordWithToken(match[1], "9012", ""); err == nil {
+		t.Fatalf("password reset token is not expected to be used twice")
+	}
+	if entries, _ := GetAuditEntries(AuditPasswordReset, 0); len(entries) != 1 {
+		t.Fatalf("password reset is expected to be audited: %v", entries)
+	}
+
+	// Users are asked to download their new profiles when their certs are renewed.
+	now := time.Now()
+	if err := renewExpiringCerts(now, now.Add(45*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	msg = smtpd.receive(t)
+	if msg.to[0] != "alice@example.com" || !strings.Contains(msg.data, "A new certificate is issued to you") {
+		t.Fatalf("cert renewal message is expected to be sent to alice:\n%s", msg.data)
+	}
+
+	// Admins get digests of the expiring certs and the process failures.
+	recordProcessFailure("process exited unexpectedly")
+	if err := sendAdminDigest(now.Add(-time.Minute), now.Add(45*24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	msg = smtpd.receive(t)
+	if strings.Join(msg.to, ",") != "ops@example.com,alice@example.com" {
+		t.Fatalf("admin digest is expected to be sent to ops and alice: %v", msg.to)
+	}
+	if !strings.Contains(msg.data, "    alice: ") || !strings.Contains(msg.data, "    bob: ") {
+		t.Fatalf("admin digest is expected to list the certs of alice and bob:\n%s", msg.data)
+	}
+	if !strings.Contains(msg.data, "openvpn: process exited unexpectedly") {
+		t.Fatalf("admin digest is expected to list the process failure:\n%s", msg.data)
+	}
+
+	// Nothing to report since the last digest.
+	if err := sendAdminDigest(lastAdminDigestAt(), now); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-smtpd.msgs:
+		t.Fatalf("no admin digest is expected to be sent:\n%s", msg.data)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
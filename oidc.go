@@ -0,0 +1,193 @@
+package ovpm
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OIDCConfig holds the settings of the OpenID Connect single sign-on.
+type OIDCConfig struct {
+	IssuerURL     string   // Issuer of the provider. e.g. https://keycloak.example.com/auth/realms/example
+	ClientID      string   // Client id registered on the provider.
+	ClientSecret  string   // Client secret registered on the provider.
+	RedirectURL   string   // Callback url of ovpm. e.g. https://vpn.example.com/api/v1/auth/oidc/callback
+	Scopes        []string // Extra scopes to request besides openid. e.g. profile, email, groups
+	UsernameClaim string   // Claim that is matched against the usernames. e.g. preferred_username, email
+	GroupsClaim   string   // Claim that lists the groups of the user. e.g. groups, roles
+	AdminGroups   []string // Members of these groups are given admin rights. If empty, admin rights aren't managed by OIDC.
+	CreateUsers   bool     // Create the users that don't exist yet on their first login.
+}
+
+// OIDCIdentity is the identity of a user, extracted from the verified ID token claims.
+type OIDCIdentity struct {
+	Subject   string    // sub claim
+	SessionID string    // sid claim, if the provider sends it
+	Username  string    // value of the username claim
+	Groups    []string  // values of the groups claim
+	ExpiresAt time.Time // expiry of the ID token
+}
+
+var oidcConfig *OIDCConfig
+var oidcConfigMu sync.RWMutex
+
+// SetOIDCConfig validates and sets the configuration of the OIDC single sign-on.
+//
+// Passing nil disables the OIDC single sign-on.
+func SetOIDCConfig(cfg *OIDCConfig) error {
+	if cfg != nil {
+		if _, err := url.ParseRequestURI(cfg.IssuerURL); err != nil {
+			return fmt.Errorf("validation error: oidc issuer url `%s` is invalid: %v", cfg.IssuerURL, err)
+		}
+		if _, err := url.ParseRequestURI(cfg.RedirectURL); err != nil {
+			return fmt.Errorf("validation error: oidc redirect url `%s` is invalid: %v", cfg.RedirectURL, err)
+		}
+		if cfg.ClientID == "" {
+			return fmt.Errorf("validation error: oidc client id can not be empty")
+		}
+		if cfg.UsernameClaim == "" {
+			cfg.UsernameClaim = DefaultOIDCUsernameClaim
+		}
+		if cfg.GroupsClaim == "" {
+			cfg.GroupsClaim = DefaultOIDCGroupsClaim
+		}
+	}
+
+	oidcConfigMu.Lock()
+	defer oidcConfigMu.Unlock()
+	oidcConfig = cfg
+	return nil
+}
+
+// GetOIDCConfig returns the configuration of the OIDC single sign-on. It returns nil if it is disabled.
+func GetOIDCConfig() *OIDCConfig {
+	oidcConfigMu.RLock()
+	defer oidcConfigMu.RUnlock()
+	return oidcConfig
+}
+
+// IsOIDCEnabled returns whether the OIDC single sign-on is configured or not.
+func IsOIDCEnabled() bool {
+	return GetOIDCConfig() != nil
+}
+
+// Identity extracts the identity of the user from the verified ID token claims.
+func (cfg *OIDCConfig) Identity(claims map[string]interface{}, expiresAt time.Time) (*OIDCIdentity, error) {
+	id := OIDCIdentity{ExpiresAt: expiresAt}
+	id.Subject, _ = claims["sub"].(string)
+	id.SessionID, _ = claims["sid"].(string)
+	id.Username, _ = claims[cfg.UsernameClaim].(string)
+	if id.Subject == "" {
+		return nil, fmt.Errorf("oidc: sub claim is missing")
+	}
+	if id.Username == "" {
+		return nil, fmt.Errorf("oidc: username claim %s is missing", cfg.UsernameClaim)
+	}
+
+	switch groups := claims[cfg.GroupsClaim].(type) {
+	case string:
+		id.Groups = []string{groups}
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				id.Groups = append(id.Groups, s)
+			}
+		}
+	}
+	return &id, nil
+}
+
+// isAdmin returns whether the identity should be given admin rights.
+func (cfg *OIDCConfig) isAdmin(id *OIDCIdentity) bool {
+	for _, adminGroup := range cfg.AdminGroups {
+		for _, g := range id.Groups {
+			if g == adminGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LoginOIDC logs in the user with the given identity and returns the user with a new auth token.
+//
+// The user is matched by the username claim, and created if it doesn't exist yet and just-in-time
+// creation is enabled. The auth token is tied to the OIDC session: it expires with the ID token and
+// is revoked when the provider ends the session.
+func LoginOIDC(id *OIDCIdentity) (*User, string, error) {
+	cfg := GetOIDCConfig()
+	if cfg == nil {
+		return nil, "", fmt.Errorf("oidc is not configured")
+	}
+	if !isValidUsername(id.Username) {
+		return nil, "", fmt.Errorf("`%s` is not a valid username", id.Username)
+	}
+
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil, "", fmt.Errorf("you first need to create server")
+	}
+
+	var changed bool
+	admin := cfg.isAdmin(id)
+	user, err := GetUser(id.Username)
+	if err != nil {
+		if !cfg.CreateUsers {
+			return nil, "", fmt.Errorf("oidc: user not found: %s", id.Username)
+		}
+		user, err = createNewUser(id.Username, "", false, 0, admin, "created by oidc")
+		if err != nil {
+			return nil, "", err
+		}
+		changed = true
+	}
+	if user.IsDisabled() {
+		return nil, "", fmt.Errorf("user is disabled: %s", id.Username)
+	}
+	if err := user.checkValidity(time.Now()); err != nil {
+		return nil, "", err
+	}
+	if len(cfg.AdminGroups) > 0 && user.Admin != admin {
+		user.Admin = admin
+		changed = true
+	}
+
+	if changed {
+		db.Save(&user.dbUserModel)
+	}
+	_, token, err := issueToken(user, dbTokenModel{
+		Name:          sessionTokenName,
+		Session:       true,
+		ExpiresAt:     id.ExpiresAt,
+		OIDCSubject:   id.Subject,
+		OIDCSessionID: id.SessionID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The vpn server isn't restarted, since logging in doesn't change anything that its
+	// connected clients need.
+	if changed {
+		if err := svr.Emit(); err != nil {
+			return nil, "", err
+		}
+	}
+	logrus.Infof("oidc: user logged in: %s", user.Username)
+	return user, token, nil
+}
+
+// EndOIDCSession revokes the tokens that are tied to the given OIDC session.
+//
+// If sessionID is empty, all of the sessions of the subject are ended.
+func EndOIDCSession(subject, sessionID string) error {
+	if subject == "" && sessionID == "" {
+		return fmt.Errorf("oidc: either subject or session id is required")
+	}
+	db.Unscoped().Where(&dbTokenModel{OIDCSubject: subject, OIDCSessionID: sessionID}).Delete(&dbTokenModel{})
+	logrus.Infof("oidc: session ended: %s %s", subject, sessionID)
+	return nil
+}
@@ -0,0 +1,135 @@
+package ovpm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetOIDCConfig(t *testing.T) {
+	defer SetOIDCConfig(nil)
+
+	tests := []struct {
+		name string
+		cfg  *OIDCConfig
+		ok   bool
+	}{
+		{"disabled", nil, true},
+		{"valid", &OIDCConfig{IssuerURL: "https://idp.example.com", ClientID: "ovpm", RedirectURL: "https://vpn.example.com/api/v1/auth/oidc/callback"}, true},
+		{"no issuer", &OIDCConfig{ClientID: "ovpm", RedirectURL: "https://vpn.example.com/api/v1/auth/oidc/callback"}, false},
+		{"no client id", &OIDCConfig{IssuerURL: "https://idp.example.com", RedirectURL: "https://vpn.example.com/api/v1/auth/oidc/callback"}, false},
+		{"no redirect url", &OIDCConfig{IssuerURL: "https://idp.example.com", ClientID: "ovpm"}, false},
+	}
+	for _, tt := range tests {
+		if err := SetOIDCConfig(tt.cfg); (err == nil) != tt.ok {
+			t.Errorf("%s: SetOIDCConfig() error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+
+	// Are the defaults set?
+	SetOIDCConfig(&OIDCConfig{IssuerURL: "https://idp.example.com", ClientID: "ovpm", RedirectURL: "https://vpn.example.com/cb"})
+	if cfg := GetOIDCConfig(); cfg.UsernameClaim != DefaultOIDCUsernameClaim || cfg.GroupsClaim != DefaultOIDCGroupsClaim {
+		t.Fatalf("oidc config defaults are expected to be set but they aren't: %+v", cfg)
+	}
+}
+
+func TestOIDCConfigIdentity(t *testing.T) {
+	cfg := &OIDCConfig{UsernameClaim: "preferred_username", GroupsClaim: "groups"}
+	exp := time.Now().Add(time.Hour)
+
+	id, err := cfg.Identity(map[string]interface{}{
+		"sub":                "1234",
+		"sid":                "session",
+		"preferred_username": "alice",
+		"groups":             []interface{}{"vpn", "admins"},
+	}, exp)
+	if err != nil {
+		t.Fatalf("identity is expected to be extracted: %v", err)
+	}
+	if id.Subject != "1234" || id.SessionID != "session" || id.Username != "alice" || len(id.Groups) != 2 || !id.ExpiresAt.Equal(exp) {
+		t.Fatalf("identity is extracted incorrectly: %+v", id)
+	}
+
+	// Single valued groups claim.
+	id, _ = cfg.Identity(map[string]interface{}{"sub": "1234", "preferred_username": "alice", "groups": "admins"}, exp)
+	if len(id.Groups) != 1 || id.Groups[0] != "admins" {
+		t.Fatalf("single valued groups claim is expected to be a group: %+v", id)
+	}
+
+	// Missing claims.
+	if _, err := cfg.Identity(map[string]interface{}{"sub": "1234"}, exp); err == nil {
+		t.Fatalf("identity without username claim is expected to be rejected")
+	}
+	if _, err := cfg.Identity(map[string]interface{}{"preferred_username": "alice"}, exp); err == nil {
+		t.Fatalf("identity without sub claim is expected to be rejected")
+	}
+}
+
+func TestLoginOIDC(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	defer SetOIDCConfig(nil)
+	cfg := &OIDCConfig{
+		IssuerURL:   "https://idp.example.com",
+		ClientID:    "ovpm",
+		RedirectURL: "https://vpn.example.com/api/v1/auth/oidc/callback",
+		AdminGroups: []string{"admins"},
+	}
+	if err := SetOIDCConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour)
+
+	// Unknown users are rejected unless just-in-time creation is enabled.
+	if _, _, err := LoginOIDC(&OIDCIdentity{Subject: "1", Username: "alice", Groups: []string{"admins"}, ExpiresAt: exp}); err == nil {
+		t.Fatalf("unknown user is not expected to be logged in")
+	}
+	cfg.CreateUsers = true
+	alice, token, err := LoginOIDC(&OIDCIdentity{Subject: "1", SessionID: "s1", Username: "alice", Groups: []string{"admins"}, ExpiresAt: exp})
+	if err != nil {
+		t.Fatalf("user is expected to be created on login: %v", err)
+	}
+	if !alice.IsAdmin() {
+		t.Fatalf("alice is expected to be admin")
+	}
+	if u, err := GetUserByToken(token); err != nil || u.Username != "alice" {
+		t.Fatalf("token is expected to belong to alice: %v", err)
+	}
+
+	// Admin rights follow the groups claim.
+	if _, _, err := LoginOIDC(&OIDCIdentity{Subject: "1", SessionID: "s2", Username: "alice", ExpiresAt: exp}); err != nil {
+		t.Fatal(err)
+	}
+	if alice, _ = GetUser("alice"); alice.IsAdmin() {
+		t.Fatalf("alice is not expected to be admin after leaving the admins group")
+	}
+
+	// Tokens expire with the OIDC session.
+	_, token, err = LoginOIDC(&OIDCIdentity{Subject: "1", SessionID: "s3", Username: "alice", ExpiresAt: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserByToken(token); err == nil {
+		t.Fatalf("expired token is not expected to be valid")
+	}
+
+	// Tokens are revoked when the OIDC session ends.
+	_, token, _ = LoginOIDC(&OIDCIdentity{Subject: "1", SessionID: "s4", Username: "alice", ExpiresAt: exp})
+	EndOIDCSession("1", "other")
+	if _, err := GetUserByToken(token); err != nil {
+		t.Fatalf("token is expected to survive the end of an unrelated session: %v", err)
+	}
+	EndOIDCSession("1", "s4")
+	if _, err := GetUserByToken(token); err == nil {
+		t.Fatalf("token is not expected to be valid after the session ends")
+	}
+
+	// Password logins aren't tied to a session.
+	alice, _ = GetUser("alice")
+	token, _ = alice.NewSessionToken()
+	EndOIDCSession("1", "")
+	if _, err := GetUserByToken(token); err != nil {
+		t.Fatalf("password login token is not expected to be revoked by oidc: %v", err)
+	}
+}
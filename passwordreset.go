@@ -0,0 +1,99 @@
+package ovpm
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// dbPasswordResetModel is database model for password reset tokens.
+//
+// Only the hash of the token is stored; the token itself is only sent to the user by email.
+type dbPasswordResetModel struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	Hash      string `gorm:"unique_index"` // hex encoded sha256 of the token
+	ExpiresAt time.Time
+}
+
+// RequestPasswordReset sends the user a link to reset its password by email. The link is valid
+// for DefaultPasswordResetTTL and it can only be used once.
+//
+// Previous links of the user are invalidated.
+func RequestPasswordReset(username, remoteAddr string) error {
+	if !IsSMTPEnabled() {
+		return fmt.Errorf("email notifications are not enabled")
+	}
+	base := GetWebURL()
+	if base == "" {
+		return fmt.Errorf("web url is not configured")
+	}
+	user, err := GetUser(username)
+	if err != nil {
+		return err
+	}
+	if user.IsLDAPUser() {
+		return fmt.Errorf("password of the user is managed by LDAP: %s", username)
+	}
+	if user.Email == "" {
+		return fmt.Errorf("user has no email address: %s", username)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	reset := dbPasswordResetModel{
+		UserID:    user.ID,
+		Hash:      hashToken(token),
+		ExpiresAt: time.Now().Add(DefaultPasswordResetTTL),
+	}
+	db.Unscoped().Where(&dbPasswordResetModel{UserID: user.ID}).Delete(&dbPasswordResetModel{})
+	db.Create(&reset)
+	if db.NewRecord(&reset) {
+		return fmt.Errorf("password reset can not be created: %s", username)
+	}
+
+	err = sendMail([]string{user.Email}, MailPasswordReset, struct {
+		Username, Hostname, Link string
+		ExpiresAt                time.Time
+	}{user.Username, TheServer().GetHostname(), base + "/reset-password?token=" + url.QueryEscape(token), reset.ExpiresAt})
+	if err != nil {
+		db.Unscoped().Delete(&reset)
+		return err
+	}
+	audit(AuditPasswordResetRequested, "", username, remoteAddr, "password reset link is sent to "+user.Email)
+	logrus.Infof("password reset requested: %s", username)
+	return nil
+}
+
+// ResetPasswordWithToken sets the password of the user that the password reset token is sent to.
+func ResetPasswordWithToken(token, password, remoteAddr string) error {
+	if password == "" {
+		return fmt.Errorf("validation error: password can not be empty")
+	}
+	var reset dbPasswordResetModel
+	db.Where(&dbPasswordResetModel{Hash: hashToken(token)}).First(&reset)
+	if db.NewRecord(&reset) {
+		return fmt.Errorf("password reset token is invalid")
+	}
+	db.Unscoped().Delete(&reset)
+	if !time.Now().Before(reset.ExpiresAt) {
+		return fmt.Errorf("password reset token is expired")
+	}
+
+	var u dbUserModel
+	db.First(&u, reset.UserID)
+	if db.NewRecord(&u) {
+		return fmt.Errorf("password reset token is invalid")
+	}
+	user := &User{dbUserModel: u}
+	if err := user.ResetPassword(password); err != nil {
+		return err
+	}
+	audit(AuditPasswordReset, user.Username, user.Username, remoteAddr, "password is reset with an emailed link")
+	return nil
+}
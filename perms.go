@@ -1,6 +1,10 @@
 package ovpm
 
-import "github.com/master312/ovpm/permset"
+import (
+	"fmt"
+
+	"github.com/master312/ovpm/permset"
+)
 
 // OVPM available permissions.
 const (
@@ -29,8 +33,103 @@ const (
 	GetNetworkAssociatedUsersPerm
 	AssociateNetworkUserPerm
 	DissociateNetworkUserPerm
+	UpdateNetworkPerm
+
+	// Token permissions
+	ManageSelfTokensPerm
+	ManageAnyTokensPerm
+
+	// Service account permissions
+	ManageServiceAccountsPerm
+
+	// Auth permissions
+	ManageLockoutsPerm
+
+	// Role permissions
+	ListRolesPerm
+	ManageRolesPerm
+
+	// Group permissions
+	ListGroupsPerm
+	ManageGroupsPerm
 )
 
+// permNames holds the stable names of the permissions.
+//
+// Names are what's persisted (e.g. as token scopes) and what's exposed through the api, so they must
+// never change.
+var permNames = map[permset.Perm]string{
+	CreateUserPerm:                "user:create",
+	GetAnyUserPerm:                "user:get-any",
+	GetSelfPerm:                   "user:get-self",
+	UpdateAnyUserPerm:             "user:update-any",
+	UpdateSelfPerm:                "user:update-self",
+	DeleteAnyUserPerm:             "user:delete-any",
+	RenewAnyUserPerm:              "user:renew-any",
+	GenConfigAnyUserPerm:          "user:genconfig-any",
+	GenConfigSelfPerm:             "user:genconfig-self",
+	GetVPNStatusPerm:              "vpn:status",
+	InitVPNPerm:                   "vpn:init",
+	UpdateVPNPerm:                 "vpn:update",
+	RestartVPNPerm:                "vpn:restart",
+	ListNetworksPerm:              "network:list",
+	CreateNetworkPerm:             "network:create",
+	DeleteNetworkPerm:             "network:delete",
+	GetNetworkTypesPerm:           "network:types",
+	GetNetworkAssociatedUsersPerm: "network:associated-users",
+	AssociateNetworkUserPerm:      "network:associate",
+	DissociateNetworkUserPerm:     "network:dissociate",
+	UpdateNetworkPerm:             "network:update",
+	ManageSelfTokensPerm:          "token:manage-self",
+	ManageAnyTokensPerm:           "token:manage-any",
+	ManageServiceAccountsPerm:     "service-account:manage",
+	ManageLockoutsPerm:            "auth:manage-lockouts",
+	ListRolesPerm:                 "role:list",
+	ManageRolesPerm:               "role:manage",
+	ListGroupsPerm:                "group:list",
+	ManageGroupsPerm:              "group:manage",
+}
+
+// PermName returns the stable name of the permission.
+func PermName(perm permset.Perm) string {
+	if name, ok := permNames[perm]; ok {
+		return name
+	}
+	return fmt.Sprintf("perm:%d", perm)
+}
+
+// PermFromName returns the permission with the given stable name.
+func PermFromName(name string) (permset.Perm, error) {
+	for perm, n := range permNames {
+		if n == name {
+			return perm, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown permission: %s", name)
+}
+
+// PermNames returns the stable names of the given permissions.
+func PermNames(perms []permset.Perm) []string {
+	var names []string
+	for _, perm := range perms {
+		names = append(names, PermName(perm))
+	}
+	return names
+}
+
+// PermsFromNames returns the permissions with the given stable names.
+func PermsFromNames(names []string) ([]permset.Perm, error) {
+	var perms []permset.Perm
+	for _, name := range names {
+		perm, err := PermFromName(name)
+		if err != nil {
+			return nil, err
+		}
+		perms = append(perms, perm)
+	}
+	return perms, nil
+}
+
 // AdminPerms returns the list of permissions that admin type user has.
 func AdminPerms() []permset.Perm {
 	return []permset.Perm{
@@ -54,6 +153,15 @@ func AdminPerms() []permset.Perm {
 		GetNetworkAssociatedUsersPerm,
 		AssociateNetworkUserPerm,
 		DissociateNetworkUserPerm,
+		UpdateNetworkPerm,
+		ManageSelfTokensPerm,
+		ManageAnyTokensPerm,
+		ManageServiceAccountsPerm,
+		ManageLockoutsPerm,
+		ListRolesPerm,
+		ManageRolesPerm,
+		ListGroupsPerm,
+		ManageGroupsPerm,
 	}
 }
 
@@ -63,5 +171,6 @@ func UserPerms() []permset.Perm {
 		GetSelfPerm,
 		UpdateSelfPerm,
 		GenConfigSelfPerm,
+		ManageSelfTokensPerm,
 	}
 }
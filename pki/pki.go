@@ -20,6 +20,17 @@ const (
 	_CrtKeyLength   = 2024
 )
 
+// DefaultLifetime is the lifetime of the certificates that are issued with zero lifetime.
+const DefaultLifetime = time.Duration(24*365*_CrtExpireYears) * time.Hour
+
+// notAfter returns the expiration time of a certificate that is issued now with the lifetime.
+func notAfter(now time.Time, lifetime time.Duration) time.Time {
+	if lifetime <= 0 {
+		lifetime = DefaultLifetime
+	}
+	return now.Add(lifetime).UTC()
+}
+
 // CertHolder encapsulates a public certificate and the corresponding private key.
 type CertHolder struct {
 	Cert string // PEM Encoded Certificate
@@ -32,10 +43,11 @@ type CA struct {
 	CSR string
 }
 
-// NewCA returns a newly generated CA.
+// NewCA returns a newly generated CA that expires after the lifetime, or DefaultLifetime if it's
+// zero.
 //
 // This will generate a public/private RSA keypair and a authority certificate signed by itself.
-func NewCA() (*CA, error) {
+func NewCA(lifetime time.Duration) (*CA, error) {
 	type basicConstraints struct {
 		IsCA       bool `asn1:"optional"`
 		MaxPathLen int  `asn1:"optional,default:-1"`
@@ -85,7 +97,7 @@ func NewCA() (*CA, error) {
 		SerialNumber:          serial,
 		Subject:               names,
 		NotBefore:             now.Add(-10 * time.Minute).UTC(),
-		NotAfter:              now.Add(time.Duration(24*365*_CrtExpireYears) * time.Hour).UTC(),
+		NotAfter:              notAfter(now, lifetime),
 		BasicConstraintsValid: true,
 		IsCA:     true,
 		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
@@ -118,17 +130,21 @@ func NewCA() (*CA, error) {
 }
 
 // NewServerCertHolder generates a RSA key-pair and a x509 certificate signed by the CA for the server.
-func NewServerCertHolder(ca *CA) (*CertHolder, error) {
-	return newCert(ca, true, "localhost")
+//
+// The certificate expires after the lifetime, or DefaultLifetime if it's zero, but not after the CA.
+func NewServerCertHolder(ca *CA, lifetime time.Duration) (*CertHolder, error) {
+	return newCert(ca, true, "localhost", lifetime)
 }
 
 // NewClientCertHolder generates a RSA key-pair and a x509 certificate signed by the CA for the client.
-func NewClientCertHolder(ca *CA, username string) (*CertHolder, error) {
-	return newCert(ca, false, username)
+//
+// The certificate expires after the lifetime, or DefaultLifetime if it's zero, but not after the CA.
+func NewClientCertHolder(ca *CA, username string, lifetime time.Duration) (*CertHolder, error) {
+	return newCert(ca, false, username, lifetime)
 }
 
 // newCert generates a RSA key-pair and a x509 certificate signed by the CA.
-func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
+func newCert(ca *CA, server bool, cn string, lifetime time.Duration) (*CertHolder, error) {
 	// Get CA private key
 	block, _ := pem.Decode([]byte(ca.Key))
 	if block == nil {
@@ -162,9 +178,13 @@ func newCert(ca *CA, server bool, cn string) (*CertHolder, error) {
 	}
 
 	now := time.Now()
+	expiresAt := notAfter(now, lifetime)
+	if expiresAt.After(caCert.NotAfter) {
+		expiresAt = caCert.NotAfter
+	}
 	tml := x509.Certificate{
 		NotBefore:    now.Add(-10 * time.Minute).UTC(),
-		NotAfter:     now.Add(time.Duration(24*365*_CrtExpireYears) * time.Hour).UTC(),
+		NotAfter:     expiresAt,
 		SerialNumber: serial,
 		Subject: pkix.Name{
 			CommonName:   cn,
@@ -15,7 +15,7 @@ import (
 func TestNewCA(t *testing.T) {
 	// Initialize:
 	// Prepare:
-	ca, err := pki.NewCA()
+	ca, err := pki.NewCA(0)
 	if err != nil {
 		t.Fatalf("can not create CA in test: %v", err)
 	}
@@ -53,14 +53,14 @@ func TestNewCA(t *testing.T) {
 // TestNewCertHolders tests pki.NewServerCertHolder and pki.NewClientCertHolder functions.
 func TestNewCertHolders(t *testing.T) {
 	// Initialize:
-	ca, _ := pki.NewCA()
+	ca, _ := pki.NewCA(0)
 
 	// Prepare:
-	sch, err := pki.NewServerCertHolder(ca)
+	sch, err := pki.NewServerCertHolder(ca, 0)
 	if err != nil {
 		t.Fatalf("can not create server cert holder: %v", err)
 	}
-	cch, err := pki.NewClientCertHolder(ca, "test-user")
+	cch, err := pki.NewClientCertHolder(ca, "test-user", 0)
 	if err != nil {
 		t.Fatalf("can not create client cert holder: %v", err)
 	}
@@ -101,17 +101,47 @@ func TestNewCertHolders(t *testing.T) {
 
 }
 
+func TestCertLifetimes(t *testing.T) {
+	// Initialize:
+	ca, _ := pki.NewCA(365 * 24 * time.Hour)
+	now := time.Now()
+
+	// Test:
+	var lifetimetests = []struct {
+		name     string
+		lifetime time.Duration
+		want     time.Duration // expected lifetime
+	}{
+		{"client", 30 * 24 * time.Hour, 30 * 24 * time.Hour},
+		{"default", 0, 365 * 24 * time.Hour},                     // limited by the ca
+		{"long", 2 * 365 * 24 * time.Hour, 365 * 24 * time.Hour}, // limited by the ca
+	}
+	for _, tt := range lifetimetests {
+		ch, err := pki.NewClientCertHolder(ca, "test-user", tt.lifetime)
+		if err != nil {
+			t.Fatalf("can not create client cert holder: %v", err)
+		}
+		crt, err := pki.ReadCertFromPEM(ch.Cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := crt.NotAfter.Sub(now); got < tt.want-time.Minute || got > tt.want+time.Minute {
+			t.Errorf("%s cert is expected to expire after %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
 func TestNewCRL(t *testing.T) {
 	// Initialize:
 	max := 5
 	n := randomBetween(1, max)
-	ca, _ := pki.NewCA()
+	ca, _ := pki.NewCA(0)
 
 	// Prepare:
 	var certHolders []*pki.CertHolder
 	for i := 0; i < max; i++ {
 		username := fmt.Sprintf("user-%d", i)
-		ch, _ := pki.NewClientCertHolder(ca, username)
+		ch, _ := pki.NewClientCertHolder(ca, username, 0)
 		certHolders = append(certHolders, ch)
 	}
 
@@ -169,7 +199,7 @@ func TestNewCRL(t *testing.T) {
 
 func TestReadCertFromPEM(t *testing.T) {
 	// Initialize:
-	ca, _ := pki.NewCA()
+	ca, _ := pki.NewCA(0)
 
 	// Prepare:
 
@@ -0,0 +1,208 @@
+package ovpm
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// ProfileLinkPath is the path of the REST server that the profile links are served under.
+const ProfileLinkPath = "/api/v1/profile-link/"
+
+// profileLinkPasscodeDigits is the length of the passcodes of the profile links.
+const profileLinkPasscodeDigits = 8
+
+// dbProfileLinkModel is database model for the one-time profile download links.
+//
+// Only the hashes of the token and the passcode are stored; they are shown once, when the link
+// is created.
+type dbProfileLinkModel struct {
+	gorm.Model
+	UserID uint `gorm:"index"`
+
+	Hash         string `gorm:"unique_index"` // hex encoded sha256 of the token
+	PasscodeHash string // hex encoded sha256 of the passcode; empty if the user's password is required instead
+	IssuedBy     string // username of the admin that created the link
+	ExpiresAt    time.Time
+	RedeemedAt   *time.Time // set once the profile is downloaded, after which the link is invalid
+	Failures     int        // failed attempts to unlock the link
+}
+
+// ProfileLink represents a one-time, expiring link to download the vpn profile of a user.
+type ProfileLink struct {
+	dbProfileLinkModel
+}
+
+// CreateProfileLink creates a link that serves the vpn profile of the user once, until it
+// expires after the ttl, or DefaultProfileLinkTTL if it's zero.
+//
+// The link must be unlocked with the user's password, unless withPasscode is set; then a random
+// passcode is generated to unlock it instead, which is meant to be shared over another channel
+// than the link itself. It's static for the link, unlike the TOTP codes of the users. The url of
+// the link and the passcode, if any, are returned along with it.
+func CreateProfileLink(username, issuer string, ttl time.Duration, withPasscode bool) (*ProfileLink, string, string, error) {
+	if ttl < 0 {
+		return nil, "", "", fmt.Errorf("validation error: profile link ttl can not be negative")
+	}
+	if ttl == 0 {
+		ttl = DefaultProfileLinkTTL
+	}
+	base := GetWebURL()
+	if base == "" {
+		return nil, "", "", fmt.Errorf("web url is not configured")
+	}
+	user, err := GetUser(username)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if user.IsDisabled() {
+		return nil, "", "", fmt.Errorf("user is disabled: %s", username)
+	}
+	if !withPasscode && !user.IsLDAPUser() && user.Hash == "" {
+		return nil, "", "", fmt.Errorf("user has no password, a passcode is required: %s", username)
+	}
+	purgeExpiredProfileLinks()
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", "", err
+	}
+	link := dbProfileLinkModel{
+		UserID:    user.ID,
+		Hash:      hashToken(token),
+		IssuedBy:  issuer,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	var passcode string
+	if withPasscode {
+		if passcode, err = newPasscode(); err != nil {
+			return nil, "", "", err
+		}
+		link.PasscodeHash = hashToken(passcode)
+	}
+	db.Create(&link)
+	if db.NewRecord(&link) {
+		return nil, "", "", fmt.Errorf("profile link can not be created: %s", username)
+	}
+
+	unlock := "password"
+	if withPasscode {
+		unlock = "passcode"
+	}
+	audit(AuditProfileLinkIssued, issuer, username, "", fmt.Sprintf("expires at %s, unlocked with %s", link.ExpiresAt.Format(time.RFC3339), unlock))
+	logrus.Infof("profile link created: %s", username)
+	return &ProfileLink{dbProfileLinkModel: link}, base + ProfileLinkPath + token, passcode, nil
+}
+
+// RedeemProfileLink returns the username and the vpn profile that the link is created for, if the
+// secret unlocks it. The secret is the passcode of the link, or the user's password if it
+// has none; then the totp code is required as well if the user is enrolled in TOTP.
+//
+// The link becomes invalid once the profile is returned, or after DefaultProfileLinkMaxFailures
+// failed attempts.
+func RedeemProfileLink(token, secret, code, remoteAddr string) (string, string, error) {
+	var link dbProfileLinkModel
+	db.Where(&dbProfileLinkModel{Hash: hashToken(token)}).First(&link)
+	if db.NewRecord(&link) || link.RedeemedAt != nil {
+		return "", "", fmt.Errorf("profile link is invalid")
+	}
+	if !time.Now().Before(link.ExpiresAt) {
+		return "", "", fmt.Errorf("profile link is expired")
+	}
+	var u dbUserModel
+	db.Where("service_account = ?", false).First(&u, link.UserID)
+	if db.NewRecord(&u) {
+		return "", "", fmt.Errorf("profile link is invalid")
+	}
+	user := &User{dbUserModel: u}
+
+	if err := link.unlock(user, secret, code, remoteAddr); err != nil {
+		link.Failures++
+		db.Model(&link).Update("Failures", link.Failures)
+		if link.Failures >= DefaultProfileLinkMaxFailures {
+			db.Model(&link).Update("ExpiresAt", time.Now())
+			audit(AuditProfileLinkRevoked, "", user.Username, remoteAddr, fmt.Sprintf("revoked after %d failed attempts", link.Failures))
+		}
+		return "", "", err
+	}
+
+	// The profile is dumped before the link is invalidated, so that the link isn't used up if it
+	// can't be; it's only returned to the one that invalidates the link, so that it can't be
+	// redeemed twice concurrently.
+	profile, err := TheServer().DumpsClientConfig(user.Username)
+	if err != nil {
+		return "", "", err
+	}
+	now := time.Now()
+	q := db.Model(&dbProfileLinkModel{}).Where("id = ? AND redeemed_at IS NULL", link.ID).Update("RedeemedAt", &now)
+	if q.Error != nil || q.RowsAffected != 1 {
+		return "", "", fmt.Errorf("profile link is invalid")
+	}
+	audit(AuditProfileLinkRedeemed, user.Username, user.Username, remoteAddr, fmt.Sprintf("issued by '%s'", link.IssuedBy))
+	logrus.Infof("profile link redeemed: %s", user.Username)
+	return user.Username, profile, nil
+}
+
+// unlock returns an error if the secret, and the totp code if it's required, don't unlock the link.
+func (l *dbProfileLinkModel) unlock(user *User, secret, code, remoteAddr string) error {
+	if l.PasscodeHash != "" {
+		if subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(l.PasscodeHash)) != 1 {
+			return fmt.Errorf("passcode is incorrect")
+		}
+		if user.IsDisabled() {
+			return fmt.Errorf("user is disabled: %s", user.Username)
+		}
+		return user.checkValidity(time.Now())
+	}
+	if _, err := AuthenticateWithTOTP(user.Username, secret, code, remoteAddr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RequiresPasscode returns whether the link is unlocked with a passcode instead of the user's
+// password.
+func (l *ProfileLink) RequiresPasscode() bool {
+	return l.PasscodeHash != ""
+}
+
+// GetExpiresAt returns the time that the link expires at.
+func (l *ProfileLink) GetExpiresAt() time.Time {
+	return l.ExpiresAt
+}
+
+// IsProfileLinkPasscode returns whether the link is unlocked with a passcode, or an error if
+// it's invalid or expired.
+func IsProfileLinkPasscode(token string) (bool, error) {
+	var link dbProfileLinkModel
+	db.Where(&dbProfileLinkModel{Hash: hashToken(token)}).First(&link)
+	if db.NewRecord(&link) || link.RedeemedAt != nil || !time.Now().Before(link.ExpiresAt) {
+		return false, fmt.Errorf("profile link is invalid or expired")
+	}
+	return link.PasscodeHash != "", nil
+}
+
+// purgeExpiredProfileLinks deletes the links that expired a day ago or before, so that the
+// recently expired ones are still reported as expired instead of invalid.
+func purgeExpiredProfileLinks() {
+	db.Unscoped().Where("expires_at < ?", time.Now().Add(-24*time.Hour)).Delete(&dbProfileLinkModel{})
+}
+
+// newPasscode generates a random numeric passcode.
+func newPasscode() (string, error) {
+	max := big.NewInt(10)
+	passcode := make([]byte, profileLinkPasscodeDigits)
+	for i := range passcode {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("can not generate passcode: %v", err)
+		}
+		passcode[i] = byte('0' + n.Int64())
+	}
+	return string(passcode), nil
+}
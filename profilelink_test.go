@@ -0,0 +1,116 @@
+package ovpm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileLinks(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, false, "")
+
+	// Test:
+	if _, _, _, err := CreateProfileLink("alice", "admin", 0, false); err == nil {
+		t.Fatalf("profile link is not expected to be created without a web url")
+	}
+	defer SetWebURL("")
+	if err := SetWebURL("https://vpn.example.com/"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := CreateProfileLink("alice", "admin", -time.Hour, false); err == nil {
+		t.Fatalf("negative ttl is expected to be rejected")
+	}
+	if _, _, _, err := CreateProfileLink("carol", "admin", 0, false); err == nil {
+		t.Fatalf("profile link is not expected to be created for a missing user")
+	}
+
+	// Links are unlocked with the user's password and can only be used once.
+	link, url, passcode, err := CreateProfileLink("alice", "admin", 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(url, "https://vpn.example.com"+ProfileLinkPath) || passcode != "" || link.RequiresPasscode() {
+		t.Fatalf("profile link is expected to be unlocked with the password: %s %s", url, passcode)
+	}
+	if ttl := time.Until(link.GetExpiresAt()); ttl <= DefaultProfileLinkTTL-time.Minute || ttl > DefaultProfileLinkTTL {
+		t.Fatalf("profile link is expected to expire after the default ttl: %s", link.GetExpiresAt())
+	}
+	token := strings.TrimPrefix(url, "https://vpn.example.com"+ProfileLinkPath)
+	if isPasscode, err := IsProfileLinkPasscode(token); err != nil || isPasscode {
+		t.Fatalf("profile link is expected to be valid without a passcode: %t %v", isPasscode, err)
+	}
+	if _, _, err := RedeemProfileLink("foo", "1234", "", ""); err == nil {
+		t.Fatalf("invalid profile link is expected to be rejected")
+	}
+	if _, _, err := RedeemProfileLink(token, "5678", "", "10.0.0.1"); err == nil {
+		t.Fatalf("profile link is not expected to be unlocked with a wrong password")
+	}
+	username, profile, err := RedeemProfileLink(token, "1234", "", "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if username != "alice" || !strings.Contains(profile, "<cert>") {
+		t.Fatalf("profile of alice is expected to be returned: %s\n%s", username, profile)
+	}
+	if _, _, err := RedeemProfileLink(token, "1234", "", "10.0.0.1"); err == nil {
+		t.Fatalf("profile link is not expected to be redeemed twice")
+	}
+	if _, err := IsProfileLinkPasscode(token); err == nil {
+		t.Fatalf("redeemed profile link is expected to be invalid")
+	}
+
+	// Links with a passcode are unlocked with it instead of the password.
+	link, url, passcode, err = CreateProfileLink("bob", "admin", time.Hour, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passcode) != profileLinkPasscodeDigits || !link.RequiresPasscode() {
+		t.Fatalf("profile link is expected to have a passcode: %s", passcode)
+	}
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+ProfileLinkPath)
+	if _, _, err := RedeemProfileLink(token, "1234", "", ""); err == nil {
+		t.Fatalf("profile link is not expected to be unlocked with the password")
+	}
+	if username, _, err := RedeemProfileLink(token, passcode, "", ""); err != nil || username != "bob" {
+		t.Fatalf("profile link is expected to be unlocked with the passcode: %s %v", username, err)
+	}
+
+	// Links are revoked after too many failed attempts.
+	_, url, passcode, _ = CreateProfileLink("bob", "admin", time.Hour, true)
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+ProfileLinkPath)
+	for i := 0; i < DefaultProfileLinkMaxFailures; i++ {
+		RedeemProfileLink(token, "foo", "", "")
+	}
+	if _, _, err := RedeemProfileLink(token, passcode, "", ""); err == nil {
+		t.Fatalf("profile link is expected to be revoked after %d failed attempts", DefaultProfileLinkMaxFailures)
+	}
+
+	// Expired links can't be redeemed.
+	_, url, passcode, _ = CreateProfileLink("bob", "admin", time.Hour, true)
+	token = strings.TrimPrefix(url, "https://vpn.example.com"+ProfileLinkPath)
+	db.Model(&dbProfileLinkModel{}).Where(&dbProfileLinkModel{Hash: hashToken(token)}).Update("ExpiresAt", time.Now().Add(-time.Minute))
+	if _, _, err := RedeemProfileLink(token, passcode, "", ""); err == nil {
+		t.Fatalf("expired profile link is not expected to be redeemed")
+	}
+
+	// Issuing, redeeming and revoking the links are audited.
+	for action, count := range map[string]int{AuditProfileLinkIssued: 4, AuditProfileLinkRedeemed: 2, AuditProfileLinkRevoked: 1} {
+		entries, _ := GetAuditEntries(action, 0)
+		if len(entries) != count {
+			t.Fatalf("%s is expected to be audited %d times: %d", action, count, len(entries))
+		}
+	}
+	entries, _ := GetAuditEntries(AuditProfileLinkRedeemed, 0)
+	for _, e := range entries {
+		if e.GetTarget() == "alice" && (e.GetActor() != "alice" || e.GetRemoteAddr() != "10.0.0.1") {
+			t.Fatalf("redeeming the profile link is expected to be audited with the remote address: %+v", e)
+		}
+	}
+}
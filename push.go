@@ -0,0 +1,126 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/sirupsen/logrus"
+)
+
+// pushDirectives are the options that can be pushed to the clients. The rest, e.g. the ones that
+// run scripts or change the addresses of the clients, are left to ovpm.
+var pushDirectives = map[string]bool{
+	"route":                true,
+	"route-ipv6":           true,
+	"route-metric":         true,
+	"dhcp-option":          true,
+	"dns":                  true,
+	"redirect-gateway":     true,
+	"redirect-private":     true,
+	"block-outside-dns":    true,
+	"register-dns":         true,
+	"inactive":             true,
+	"ping":                 true,
+	"ping-restart":         true,
+	"ping-exit":            true,
+	"explicit-exit-notify": true,
+	"tun-mtu":              true,
+}
+
+// redirectGatewayFlags are the flags of the redirect-gateway option.
+var redirectGatewayFlags = map[string]bool{
+	"local":       true,
+	"autolocal":   true,
+	"def1":        true,
+	"bypass-dhcp": true,
+	"bypass-dns":  true,
+	"block-local": true,
+	"ipv6":        true,
+	"!ipv4":       true,
+}
+
+// defaultRedirectGateway is the flags of the redirect-gateway option that is pushed to the clients
+// that get the vpn server as their default gateway.
+const defaultRedirectGateway = "def1 bypass-dhcp"
+
+// UserPushOptions are the options that are pushed to a single user, on top of the ones of the
+// server and the user's groups.
+type UserPushOptions struct {
+	DNS        []string // dns servers, overrides the ones of the groups and the server
+	Routes     []string // networks to route through the vpn, in the CIDR form
+	RedirectGW string   // flags of the redirect-gateway option, e.g. "def1 block-local"
+	Pushes     []string // extra options to push
+	Inactive   uint32   // seconds of inactivity after which the client disconnects, 0 disables
+}
+
+// validate returns an error if the options can't be pushed.
+func (o UserPushOptions) validate() error {
+	for _, ip := range o.DNS {
+		if !govalidator.IsIPv4(ip) {
+			return fmt.Errorf("validation error: dns server `%s` must be an IPv4 address", ip)
+		}
+	}
+	for _, cidr := range o.Routes {
+		if ip, _, err := net.ParseCIDR(cidr); err != nil || ip.To4() == nil {
+			return fmt.Errorf("validation error: route `%s` must be an IPv4 network in the CIDR form", cidr)
+		}
+	}
+	for _, flag := range strings.Fields(o.RedirectGW) {
+		if !redirectGatewayFlags[flag] {
+			return fmt.Errorf("validation error: `%s` is not a redirect-gateway flag", flag)
+		}
+	}
+	for _, push := range o.Pushes {
+		if err := validatePushOption(push); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routes returns the routes in the [IP, Netmask] form.
+func (o UserPushOptions) routes() [][2]string {
+	var routes [][2]string
+	for _, cidr := range o.Routes {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		routes = append(routes, [2]string{ipnet.IP.To4().String(), net.IP(ipnet.Mask).To4().String()})
+	}
+	return routes
+}
+
+// GetPushOptions returns the options that are pushed to the user.
+func (u *User) GetPushOptions() UserPushOptions {
+	var pushes []string
+	if u.Pushes != "" {
+		pushes = strings.Split(u.Pushes, "\n")
+	}
+	return UserPushOptions{
+		DNS:        splitList(u.DNS),
+		Routes:     splitList(u.Routes),
+		RedirectGW: u.RedirectGW,
+		Pushes:     pushes,
+		Inactive:   u.Inactive,
+	}
+}
+
+// SetPushOptions replaces the options that are pushed to the user.
+func (u *User) SetPushOptions(opts UserPushOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	db.Model(&u.dbUserModel).Updates(map[string]interface{}{
+		"DNS":        strings.Join(opts.DNS, ","),
+		"Routes":     strings.Join(opts.Routes, ","),
+		"RedirectGW": strings.Join(strings.Fields(opts.RedirectGW), " "),
+		"Pushes":     strings.Join(opts.Pushes, "\n"),
+		"Inactive":   opts.Inactive,
+	})
+	logrus.Infof("user push options updated: %s", u.Username)
+	return TheServer().EmitWithRestart()
+}
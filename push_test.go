@@ -0,0 +1,80 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUserPushOptions(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, true, "")
+	ops, _ := CreateGroup("ops", "")
+	ops.AddMember("alice")
+	ops.Update("", false, []string{"10.30.0.53"}, []string{"ping 10"})
+
+	// Test:
+	for _, opts := range []UserPushOptions{
+		{DNS: []string{"ns1"}},
+		{Routes: []string{"10.20.0.0"}},
+		{Routes: []string{"fd00::/64"}},
+		{RedirectGW: "def1 bypass-everything"},
+		{Pushes: []string{"up /bin/sh"}},
+		{Pushes: []string{"ifconfig 10.9.0.10 255.255.255.0"}},
+		{Pushes: []string{`route 10.0.0.0 255.0.0.0" "foo`}},
+	} {
+		if err := alice.SetPushOptions(opts); err == nil {
+			t.Errorf("SetPushOptions(%+v) is expected to fail", opts)
+		}
+	}
+	opts := UserPushOptions{
+		DNS:        []string{"10.10.0.53"},
+		Routes:     []string{"10.20.0.0/16"},
+		RedirectGW: "def1  block-local",
+		Pushes:     []string{"dhcp-option DOMAIN corp.example.com"},
+		Inactive:   3600,
+	}
+	if err := alice.SetPushOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ = GetUser("alice")
+	if got := alice.GetPushOptions(); got.RedirectGW != "def1 block-local" || len(got.Routes) != 1 || got.Inactive != 3600 {
+		t.Fatalf("push options of alice are expected to be persisted: %+v", got)
+	}
+
+	ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]
+	for _, push := range []string{
+		`push "redirect-gateway def1 block-local"`,
+		`push "route 10.20.0.0 255.255.0.0"`,
+		`push "dhcp-option DNS 10.10.0.53"`,
+		`push "ping 10"`,
+		`push "dhcp-option DOMAIN corp.example.com"`,
+		`push "inactive 3600"`,
+	} {
+		if !strings.Contains(ccd, push) {
+			t.Fatalf("ccd of alice is expected to have %s:\n%s", push, ccd)
+		}
+	}
+	if strings.Contains(ccd, "10.30.0.53") {
+		t.Fatalf("dns of alice is expected to override the dns of ops:\n%s", ccd)
+	}
+	ccd = fs[filepath.Join(_DefaultVPNCCDPath, "bob")]
+	if !strings.Contains(ccd, `push "redirect-gateway def1 bypass-dhcp"`) || strings.Contains(ccd, "inactive") {
+		t.Fatalf("ccd of bob is expected to have the defaults:\n%s", ccd)
+	}
+
+	// Resetting the options restores the defaults.
+	if err := alice.SetPushOptions(UserPushOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	ccd = fs[filepath.Join(_DefaultVPNCCDPath, "alice")]
+	if !strings.Contains(ccd, `push "redirect-gateway def1 bypass-dhcp"`) || !strings.Contains(ccd, "10.30.0.53") || strings.Contains(ccd, "inactive") {
+		t.Fatalf("ccd of alice is expected to have the defaults after the reset:\n%s", ccd)
+	}
+}
@@ -0,0 +1,363 @@
+package ovpm
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/miekg/dns"
+	"github.com/sirupsen/logrus"
+)
+
+// ResolverConfig configures the embedded dns resolver of the vpn clients.
+type ResolverConfig struct {
+	Enabled bool
+	Domain  string // domain that the clients are resolved under, e.g. alice.<Domain>; the server's dns domain if empty
+	Port    string // port that the resolver listens on at the vpn server address; only 53 can be pushed to the clients
+}
+
+var resolverConfig = ResolverConfig{Port: DefaultResolverPort}
+var resolverMu sync.Mutex
+var resolverStop chan struct{}
+var resolverServers []*dns.Server
+var resolverAddr string
+var resolverHandler *Resolver
+
+// SetResolverConfig sets the configuration of the embedded dns resolver.
+//
+// When it's enabled, the resolver is pushed to the clients as their dns server, and it runs along
+// with the vpn server.
+func SetResolverConfig(cfg ResolverConfig) error {
+	if cfg.Port == "" {
+		cfg.Port = DefaultResolverPort
+	}
+	if !govalidator.IsPort(cfg.Port) {
+		return fmt.Errorf("validation error: resolver port `%s` must be a port number", cfg.Port)
+	}
+	// The dns servers are pushed to the clients without ports, so they only ask the standard one.
+	if cfg.Enabled && cfg.Port != DefaultResolverPort {
+		return fmt.Errorf("validation error: resolver port must be %s, since the clients can't be pushed another one", DefaultResolverPort)
+	}
+	if cfg.Domain != "" && !govalidator.IsDNSName(cfg.Domain) {
+		return fmt.Errorf("validation error: resolver domain `%s` must be a domain name", cfg.Domain)
+	}
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	resolverConfig = cfg
+	return nil
+}
+
+// GetResolverConfig returns the configuration of the embedded dns resolver.
+func GetResolverConfig() ResolverConfig {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	return resolverConfig
+}
+
+// resolverDomain returns the domain that the clients are resolved under.
+func (svr *Server) resolverDomain() string {
+	if domain := GetResolverConfig().Domain; domain != "" {
+		return domain
+	}
+	if svr.Domain != "" {
+		return svr.Domain
+	}
+	return DefaultResolverDomain
+}
+
+// serverIP returns the address of the vpn server on the vpn network.
+func (svr *Server) serverIP() net.IP {
+	ip := svr.vpnNet().IP.To4()
+	return net.IPv4(ip[0], ip[1], ip[2], ip[3]+1).To4() // Server is always gets xxx.xxx.xxx.1
+}
+
+// clientDHCPOptions returns the dhcp options to push to the clients.
+//
+// The embedded resolver replaces the dns servers, which it forwards the queries to.
+func (svr *Server) clientDHCPOptions() DHCPOptions {
+	opts := svr.GetDHCPOptions()
+	if GetResolverConfig().Enabled {
+		opts.DNS = []string{svr.serverIP().String()}
+		if opts.Domain == "" {
+			opts.Domain = svr.resolverDomain()
+		}
+	}
+	return opts
+}
+
+// Resolver is a dns handler that answers the queries for the names of the vpn clients, i.e.
+// <username>.<Domain>, and the reverse queries for their addresses. The rest of the queries are
+// forwarded to the upstream dns servers.
+type Resolver struct {
+	Domain   string   // domain that the clients are resolved under
+	Upstream []string // dns servers in the host:port form
+}
+
+// ServeDNS implements dns.Handler.
+func (r *Resolver) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	if len(req.Question) != 1 {
+		r.fail(w, req, dns.RcodeFormatError)
+		return
+	}
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+	domain := dns.Fqdn(strings.ToLower(r.Domain))
+
+	switch {
+	case dns.IsSubDomain(domain, name):
+		r.answerName(w, req, strings.TrimSuffix(strings.TrimSuffix(name, domain), "."))
+	case q.Qtype == dns.TypePTR && r.isVPNReverse(name):
+		r.answerReverse(w, req, name)
+	default:
+		r.forward(w, req)
+	}
+}
+
+// answerName answers the query for the name of the client.
+func (r *Resolver) answerName(w dns.ResponseWriter, req *dns.Msg, username string) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+
+	if username == "" {
+		// Nothing but the clients under the domain.
+		w.WriteMsg(resp)
+		return
+	}
+	var ip net.IP
+	if user := findUserByName(username); user != nil {
+		ip = user.getIP()
+	}
+	if ip == nil {
+		resp.Rcode = dns.RcodeNameError
+		w.WriteMsg(resp)
+		return
+	}
+	q := req.Question[0]
+	if q.Qtype == dns.TypeA || q.Qtype == dns.TypeANY {
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: DefaultResolverTTL},
+			A:   ip,
+		})
+	}
+	// Clients don't have IPv6 addresses, so the AAAA queries get empty answers.
+	w.WriteMsg(resp)
+}
+
+// answerReverse answers the reverse query for an address on the vpn network.
+func (r *Resolver) answerReverse(w dns.ResponseWriter, req *dns.Msg, name string) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+
+	user := findUserByIP(reverseIP(name))
+	if user == nil {
+		resp.Rcode = dns.RcodeNameError
+		w.WriteMsg(resp)
+		return
+	}
+	resp.Answer = append(resp.Answer, &dns.PTR{
+		Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypePTR, Class: dns.ClassINET, Ttl: DefaultResolverTTL},
+		Ptr: dns.Fqdn(strings.ToLower(user.Username) + "." + r.Domain),
+	})
+	w.WriteMsg(resp)
+}
+
+// isVPNReverse returns whether the reverse name is of an address of a vpn client.
+func (r *Resolver) isVPNReverse(name string) bool {
+	ip := reverseIP(name)
+	if ip == nil {
+		return false
+	}
+	for _, ipnet := range TheServer().clientNets() {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forward forwards the query to the upstream dns servers, and writes the first answer back.
+func (r *Resolver) forward(w dns.ResponseWriter, req *dns.Msg) {
+	client := &dns.Client{Net: w.LocalAddr().Network(), Timeout: DefaultResolverTimeout}
+	for _, upstream := range r.Upstream {
+		resp, _, err := client.Exchange(req, upstream)
+		if err != nil {
+			logrus.Debugf("resolver: can not forward %s to %s: %v", req.Question[0].Name, upstream, err)
+			continue
+		}
+		w.WriteMsg(resp)
+		return
+	}
+	r.fail(w, req, dns.RcodeServerFailure)
+}
+
+func (r *Resolver) fail(w dns.ResponseWriter, req *dns.Msg, rcode int) {
+	resp := new(dns.Msg)
+	resp.SetRcode(req, rcode)
+	w.WriteMsg(resp)
+}
+
+// reverseIP returns the IPv4 address of the reverse name, e.g. 5.0.9.10.in-addr.arpa.
+func reverseIP(name string) net.IP {
+	name = strings.TrimSuffix(strings.ToLower(name), ".in-addr.arpa.")
+	labels := strings.Split(name, ".")
+	if len(labels) != 4 {
+		return nil
+	}
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return net.ParseIP(strings.Join(labels, ".")).To4()
+}
+
+// findUserByName returns the user whose username matches the name, ignoring the case, if it's
+// resolvable.
+func findUserByName(name string) *User {
+	var user dbUserModel
+	q := db.Where("lower(username) = ? AND service_account = ?", strings.ToLower(name), false).First(&user)
+	if q.RecordNotFound() || q.Error != nil {
+		return nil
+	}
+	return resolvable(&User{dbUserModel: user})
+}
+
+// findUserByIP returns the user that has the vpn address, if it's resolvable.
+func findUserByIP(ip net.IP) *User {
+	if ip == nil {
+		return nil
+	}
+	user, err := getUserByIP(ip)
+	if err != nil {
+		return nil
+	}
+	return resolvable(user)
+}
+
+// resolvable returns the user, or nil if it can't connect; i.e. it's disabled, suspended, pending
+// or out of its validity window. Their leased addresses aren't theirs while they can't use them.
+func resolvable(user *User) *User {
+	if user.IsDisabled() || user.checkValidity(time.Now()) != nil {
+		return nil
+	}
+	return user
+}
+
+// ensureResolverRunning starts the embedded resolver on the vpn server address, if it's enabled,
+// or restarts it if the address has changed.
+//
+// The vpn server address only exists once OpenVPN is up, so listening is retried with exponential
+// backoff.
+func ensureResolverRunning() {
+	cfg := GetResolverConfig()
+	if !cfg.Enabled {
+		stopResolver()
+		return
+	}
+	svr := TheServer()
+	addr := net.JoinHostPort(svr.serverIP().String(), cfg.Port)
+	var upstream []string
+	for _, ip := range svr.GetDHCPOptions().DNS {
+		if ip != svr.serverIP().String() { // don't forward to itself
+			upstream = append(upstream, net.JoinHostPort(ip, "53"))
+		}
+	}
+	handler := &Resolver{Domain: svr.resolverDomain(), Upstream: upstream}
+
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	if resolverStop != nil || len(resolverServers) > 0 {
+		if resolverAddr == addr && reflect.DeepEqual(resolverHandler, handler) {
+			return
+		}
+	}
+	stopResolverLocked()
+	stop := make(chan struct{})
+	resolverStop, resolverAddr, resolverHandler = stop, addr, handler
+
+	go func() {
+		backoff := DefaultResolverRetryMin
+		for {
+			servers, err := listenResolver(addr, handler)
+
+			resolverMu.Lock()
+			select {
+			case <-stop:
+				resolverMu.Unlock()
+				for _, s := range servers {
+					s.Shutdown()
+				}
+				return
+			default:
+			}
+			if err == nil {
+				resolverServers, resolverStop = servers, nil
+				resolverMu.Unlock()
+				logrus.Infof("resolver is listening on %s for *.%s", addr, handler.Domain)
+				return
+			}
+			resolverMu.Unlock()
+
+			logrus.Warnf("can not start the resolver on %s, retrying in %s: %v", addr, backoff, err)
+			select {
+			case <-stop:
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > DefaultResolverRetryMax {
+				backoff = DefaultResolverRetryMax
+			}
+		}
+	}()
+}
+
+// listenResolver starts serving the handler on the udp and tcp addr.
+func listenResolver(addr string, handler dns.Handler) ([]*dns.Server, error) {
+	pc, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		pc.Close()
+		return nil, err
+	}
+	var started sync.WaitGroup
+	servers := []*dns.Server{
+		{PacketConn: pc, Handler: handler, NotifyStartedFunc: started.Done},
+		{Listener: l, Handler: handler, NotifyStartedFunc: started.Done},
+	}
+	started.Add(len(servers))
+	for _, s := range servers {
+		go func(s *dns.Server) {
+			if err := s.ActivateAndServe(); err != nil {
+				logrus.Errorf("resolver stopped: %v", err)
+			}
+		}(s)
+	}
+	// Servers can only be shut down once they're started.
+	started.Wait()
+	return servers, nil
+}
+
+// stopResolver stops the embedded resolver.
+func stopResolver() {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	stopResolverLocked()
+}
+
+func stopResolverLocked() {
+	if resolverStop != nil {
+		close(resolverStop)
+		resolverStop = nil
+	}
+	for _, s := range resolverServers {
+		s.Shutdown()
+	}
+	resolverServers, resolverAddr, resolverHandler = nil, "", nil
+}
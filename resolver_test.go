@@ -0,0 +1,130 @@
+package ovpm
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// serveDNS serves the handler on a local udp port and returns its address.
+func serveDNS(t *testing.T, handler dns.Handler) (string, func()) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	s := &dns.Server{PacketConn: pc, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go s.ActivateAndServe()
+	<-started
+	return pc.LocalAddr().String(), func() { s.Shutdown() }
+}
+
+func TestResolver(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	carol, _ := CreateNewUser("carol", "1234", false, 0, true, "")
+	dave, _ := CreateNewUser("dave", "1234", false, 0, true, "")
+	db.Model(&dave.dbUserModel).Update("Suspended", true)
+	erin, _ := CreateNewUser("erin", "1234", false, 0, true, "")
+	expiry := time.Now().Add(-time.Minute)
+	db.Model(&erin.dbUserModel).Update("ValidUntil", &expiry)
+	releaseLease(carol.ID)
+
+	upstream, stopUpstream := serveDNS(t, dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("93.184.216.34"),
+		})
+		w.WriteMsg(resp)
+	}))
+	defer stopUpstream()
+	addr, stop := serveDNS(t, &Resolver{Domain: "vpn.example.com", Upstream: []string{upstream}})
+	defer stop()
+
+	query := func(name string, qtype uint16) *dns.Msg {
+		req := new(dns.Msg)
+		req.SetQuestion(dns.Fqdn(name), qtype)
+		resp, _, err := new(dns.Client).Exchange(req, addr)
+		if err != nil {
+			t.Fatalf("query %s: %v", name, err)
+		}
+		return resp
+	}
+
+	// Test:
+	resp := query("Alice.vpn.example.com", dns.TypeA)
+	if len(resp.Answer) != 1 || !resp.Answer[0].(*dns.A).A.Equal(alice.getIP()) {
+		t.Fatalf("alice is expected to resolve to %s: %v", alice.getIP(), resp.Answer)
+	}
+	if resp := query("alice.vpn.example.com", dns.TypeAAAA); resp.Rcode != dns.RcodeSuccess || len(resp.Answer) != 0 {
+		t.Fatalf("alice is expected to have no AAAA records: %v", resp)
+	}
+	if resp := query("bob.vpn.example.com", dns.TypeA); resp.Rcode != dns.RcodeNameError {
+		t.Fatalf("unknown users are expected to be NXDOMAIN: %v", resp)
+	}
+	if resp := query("carol.vpn.example.com", dns.TypeA); resp.Rcode != dns.RcodeNameError || carol.getLease() != nil {
+		t.Fatalf("users without addresses are expected to be NXDOMAIN without getting a lease: %v", resp)
+	}
+	for _, user := range []*User{dave, erin} {
+		if resp := query(user.Username+".vpn.example.com", dns.TypeA); resp.Rcode != dns.RcodeNameError {
+			t.Fatalf("users that can't connect are expected to be NXDOMAIN: %v", resp)
+		}
+		reverse, _ := dns.ReverseAddr(user.getIP().String())
+		if resp := query(reverse, dns.TypePTR); resp.Rcode != dns.RcodeNameError {
+			t.Fatalf("addresses of the users that can't connect are expected to be NXDOMAIN: %v", resp)
+		}
+	}
+	reverse, _ := dns.ReverseAddr(alice.getIP().String())
+	resp = query(reverse, dns.TypePTR)
+	if len(resp.Answer) != 1 || resp.Answer[0].(*dns.PTR).Ptr != "alice.vpn.example.com." {
+		t.Fatalf("address of alice is expected to resolve to alice: %v", resp.Answer)
+	}
+	resp = query("example.com", dns.TypeA)
+	if len(resp.Answer) != 1 || resp.Answer[0].(*dns.A).A.String() != "93.184.216.34" {
+		t.Fatalf("other names are expected to be forwarded upstream: %v", resp.Answer)
+	}
+}
+
+func TestResolverPush(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	defer SetResolverConfig(ResolverConfig{})
+
+	// Test:
+	if err := SetResolverConfig(ResolverConfig{Enabled: true, Port: "dns"}); err == nil {
+		t.Fatalf("resolver port is expected to be validated")
+	}
+	if err := SetResolverConfig(ResolverConfig{Enabled: true, Port: "5353"}); err == nil {
+		t.Fatalf("resolver port is expected to be 53 to be pushed")
+	}
+	if err := SetResolverConfig(ResolverConfig{Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	svr.Emit()
+	conf := fs[_DefaultVPNConfPath]
+	for _, push := range []string{
+		`push "dhcp-option DNS 10.9.0.1"`,
+		`push "dhcp-option DOMAIN ` + DefaultResolverDomain + `"`,
+	} {
+		if !strings.Contains(conf, push) {
+			t.Fatalf("server.conf is expected to have %s:\n%s", push, conf)
+		}
+	}
+	if strings.Contains(conf, DefaultVPNDNS) {
+		t.Fatalf("server.conf is not expected to push the upstream dns:\n%s", conf)
+	}
+}
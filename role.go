@@ -0,0 +1,305 @@
+package ovpm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/master312/ovpm/permset"
+	"github.com/sirupsen/logrus"
+)
+
+// Built-in roles.
+//
+// Every account has the user role. Accounts have the admin role if they are admin, so assigning
+// the admin role is the same as making an account admin.
+const (
+	AdminRoleName = "admin"
+	UserRoleName  = "user"
+)
+
+// dbRoleModel is database model for roles.
+type dbRoleModel struct {
+	gorm.Model
+
+	Name        string `gorm:"unique_index"`
+	Description string
+	Perms       string         // comma separated permission names
+	BuiltIn     bool           `gorm:"not null;default:false"` // built-in roles can't be modified or deleted
+	Users       []*dbUserModel `gorm:"many2many:role_users;"`
+}
+
+// Role represents a named set of permissions that can be assigned to users.
+type Role struct {
+	dbRoleModel
+}
+
+// builtInRoles returns the built-in roles along with their permissions.
+func builtInRoles() []dbRoleModel {
+	return []dbRoleModel{
+		{Name: AdminRoleName, Description: "Has all of the permissions", Perms: joinPerms(AdminPerms()), BuiltIn: true},
+		{Name: UserRoleName, Description: "Can manage itself; every user has this role", Perms: joinPerms(UserPerms()), BuiltIn: true},
+	}
+}
+
+// ensureBuiltInRoles creates the built-in roles and keeps their permissions up to date.
+func ensureBuiltInRoles(dbase *gorm.DB) {
+	for _, r := range builtInRoles() {
+		var role dbRoleModel
+		dbase.Where(&dbRoleModel{Name: r.Name}).First(&role)
+		if dbase.NewRecord(&role) {
+			dbase.Create(&r)
+			continue
+		}
+		if role.Perms != r.Perms || !role.BuiltIn {
+			role.Perms = r.Perms
+			role.BuiltIn = true
+			dbase.Save(&role)
+		}
+	}
+}
+
+// joinPerms returns the permission names of the given permissions, sorted and comma separated.
+func joinPerms(perms []permset.Perm) string {
+	names := PermNames(perms)
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// CreateRole creates a new custom role with the given permissions.
+func CreateRole(name, description string, perms []permset.Perm) (*Role, error) {
+	if !govalidator.Matches(name, "^[\\w\\.\\-]+$") {
+		return nil, fmt.Errorf("validation error: role name `%s` can only contain letters, numbers, underscores, dots and dashes", name)
+	}
+	if len(perms) == 0 {
+		return nil, fmt.Errorf("validation error: role %s should have at least one permission", name)
+	}
+	if _, err := GetRole(name); err == nil {
+		return nil, fmt.Errorf("role %s already exists", name)
+	}
+
+	role := dbRoleModel{
+		Name:        name,
+		Description: description,
+		Perms:       joinPerms(perms),
+	}
+	db.Create(&role)
+	if db.NewRecord(&role) {
+		return nil, fmt.Errorf("can not create role in database: %s", name)
+	}
+	logrus.Infof("role created: %s", name)
+	return &Role{dbRoleModel: role}, nil
+}
+
+// GetRole returns the role with the given name.
+func GetRole(name string) (*Role, error) {
+	var role dbRoleModel
+	db.Where(&dbRoleModel{Name: name}).First(&role)
+	if db.NewRecord(&role) {
+		return nil, fmt.Errorf("role not found: %s", name)
+	}
+	return &Role{dbRoleModel: role}, nil
+}
+
+// GetAllRoles returns all of the roles, built-in ones first.
+func GetAllRoles() ([]*Role, error) {
+	var dbRoles []*dbRoleModel
+	if err := db.Order("built_in desc, name").Find(&dbRoles).Error; err != nil {
+		return nil, err
+	}
+
+	var roles []*Role
+	for _, r := range dbRoles {
+		roles = append(roles, &Role{dbRoleModel: *r})
+	}
+	return roles, nil
+}
+
+// Update updates the description and the permissions of the custom role.
+//
+// If perms is nil, the permissions are left untouched.
+func (r *Role) Update(description string, perms []permset.Perm) error {
+	if r.BuiltIn {
+		return fmt.Errorf("built-in role %s can not be modified", r.Name)
+	}
+	if perms != nil && len(perms) == 0 {
+		return fmt.Errorf("validation error: role %s should have at least one permission", r.Name)
+	}
+	r.Description = description
+	if perms != nil {
+		r.Perms = joinPerms(perms)
+	}
+	db.Save(&r.dbRoleModel)
+	logrus.Infof("role updated: %s", r.Name)
+	return nil
+}
+
+// Delete deletes the custom role. The users that have the role lose it.
+func (r *Role) Delete() error {
+	if r.BuiltIn {
+		return fmt.Errorf("built-in role %s can not be deleted", r.Name)
+	}
+	db.Model(&r.dbRoleModel).Association("Users").Clear()
+	db.Unscoped().Delete(&r.dbRoleModel)
+	logrus.Infof("role deleted: %s", r.Name)
+	return nil
+}
+
+// Assign gives the role to the user or the service account with the given username.
+func (r *Role) Assign(username string) error {
+	user, err := getAccount(username)
+	if err != nil {
+		return err
+	}
+	switch r.Name {
+	case UserRoleName:
+		return fmt.Errorf("every user already has the %s role", r.Name)
+	case AdminRoleName:
+		if user.Admin {
+			return fmt.Errorf("user %s already has the role %s", username, r.Name)
+		}
+		db.Model(&user.dbUserModel).UpdateColumn("admin", true)
+	default:
+		if r.hasUser(user.ID) {
+			return fmt.Errorf("user %s already has the role %s", username, r.Name)
+		}
+		userAssoc := db.Model(&r.dbRoleModel).Association("Users")
+		userAssoc.Append(&user.dbUserModel)
+		if userAssoc.Error != nil {
+			return fmt.Errorf("role assignment failed: %v", userAssoc.Error)
+		}
+	}
+	logrus.Infof("role '%s' is assigned to '%s'", r.Name, username)
+	return nil
+}
+
+// Unassign takes the role from the user or the service account with the given username.
+func (r *Role) Unassign(username string) error {
+	user, err := getAccount(username)
+	if err != nil {
+		return err
+	}
+	switch r.Name {
+	case UserRoleName:
+		return fmt.Errorf("the %s role can not be taken from users", r.Name)
+	case AdminRoleName:
+		if !user.Admin {
+			return fmt.Errorf("user %s doesn't have the role %s", username, r.Name)
+		}
+		db.Model(&user.dbUserModel).UpdateColumn("admin", false)
+	default:
+		if !r.hasUser(user.ID) {
+			return fmt.Errorf("user %s doesn't have the role %s", username, r.Name)
+		}
+		userAssoc := db.Model(&r.dbRoleModel).Association("Users")
+		userAssoc.Delete(&user.dbUserModel)
+		if userAssoc.Error != nil {
+			return fmt.Errorf("role unassignment failed: %v", userAssoc.Error)
+		}
+	}
+	logrus.Infof("role '%s' is unassigned from '%s'", r.Name, username)
+	return nil
+}
+
+// hasUser returns whether the custom role is assigned to the account with the given id.
+func (r *Role) hasUser(userID uint) bool {
+	var users []dbUserModel
+	db.Model(&r.dbRoleModel).Association("Users").Find(&users)
+	for _, u := range users {
+		if u.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUsernames returns the usernames of the accounts that have the role.
+//
+// It returns nil for the user role, since every account has it.
+func (r *Role) GetUsernames() []string {
+	var users []dbUserModel
+	switch r.Name {
+	case UserRoleName:
+		return nil
+	case AdminRoleName:
+		db.Where("admin = ?", true).Order("username").Find(&users)
+	default:
+		db.Model(&r.dbRoleModel).Association("Users").Find(&users)
+	}
+
+	var usernames []string
+	for _, u := range users {
+		usernames = append(usernames, u.Username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// GetName returns the role's name.
+func (r *Role) GetName() string {
+	return r.Name
+}
+
+// GetDescription returns the role's description.
+func (r *Role) GetDescription() string {
+	return r.Description
+}
+
+// IsBuiltIn returns whether the role is a built-in one.
+func (r *Role) IsBuiltIn() bool {
+	return r.BuiltIn
+}
+
+// GetPerms returns the role's permissions.
+func (r *Role) GetPerms() []permset.Perm {
+	if r.Perms == "" {
+		return nil
+	}
+	perms, err := PermsFromNames(strings.Split(r.Perms, ","))
+	if err != nil {
+		logrus.Warnf("role %s has invalid permissions: %v", r.Name, err)
+	}
+	return perms
+}
+
+// GetRoles returns the roles of the user.
+func (u *User) GetRoles() ([]*Role, error) {
+	names := []string{UserRoleName}
+	if u.Admin {
+		names = append(names, AdminRoleName)
+	}
+	var ids []uint
+	db.Table("role_users").Where("db_user_model_id = ?", u.ID).Pluck("db_role_model_id", &ids)
+
+	var dbRoles []*dbRoleModel
+	if err := db.Where("name IN (?) OR id IN (?)", names, ids).Order("built_in desc, name").Find(&dbRoles).Error; err != nil {
+		return nil, err
+	}
+
+	var roles []*Role
+	for _, r := range dbRoles {
+		roles = append(roles, &Role{dbRoleModel: *r})
+	}
+	return roles, nil
+}
+
+// GetPerms returns the permissions of the user; the union of the permissions of its roles.
+func (u *User) GetPerms() []permset.Perm {
+	roles, err := u.GetRoles()
+	if err != nil {
+		logrus.Errorf("roles of %s can not be fetched: %v", u.Username, err)
+		return nil
+	}
+	ps := permset.New()
+	for _, r := range roles {
+		ps.Add(r.GetPerms()...)
+	}
+	return ps.Perms()
+}
+
+// deleteRoleAssignments takes all of the custom roles from the account with the given id.
+func deleteRoleAssignments(userID uint) {
+	db.Exec("DELETE FROM role_users WHERE db_user_model_id = ?", userID)
+}
@@ -0,0 +1,137 @@
+package ovpm
+
+import (
+	"testing"
+
+	"github.com/master312/ovpm/permset"
+)
+
+func TestBuiltInRoles(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	bob, _ := CreateNewUser("bob", "1234", false, 0, false, "")
+
+	// Test:
+	admin, err := GetRole(AdminRoleName)
+	if err != nil || !admin.IsBuiltIn() {
+		t.Fatalf("built-in admin role is expected to exist: %v", err)
+	}
+	if ps := permset.New(admin.GetPerms()...); !ps.ContainsAll(AdminPerms()...) {
+		t.Fatalf("admin role is expected to have all of the admin perms")
+	}
+	if err := admin.Update("", UserPerms()); err == nil {
+		t.Fatalf("built-in role is not expected to be modified")
+	}
+	if err := admin.Delete(); err == nil {
+		t.Fatalf("built-in role is not expected to be deleted")
+	}
+
+	// Perms follow the admin flag.
+	if ps := permset.New(alice.GetPerms()...); !ps.ContainsAll(AdminPerms()...) {
+		t.Fatalf("admin user is expected to have the admin perms")
+	}
+	if ps := permset.New(bob.GetPerms()...); len(ps.Perms()) != len(UserPerms()) || !ps.ContainsAll(UserPerms()...) {
+		t.Fatalf("regular user is expected to have the user perms: %v", PermNames(ps.Perms()))
+	}
+
+	// Assigning the admin role makes the user admin.
+	if err := admin.Assign("bob"); err != nil {
+		t.Fatal(err)
+	}
+	if bob, _ = GetUser("bob"); !bob.IsAdmin() {
+		t.Fatalf("bob is expected to be admin after the admin role is assigned")
+	}
+	if usernames := admin.GetUsernames(); len(usernames) != 2 {
+		t.Fatalf("admin role is expected to have 2 users: %v", usernames)
+	}
+	if err := admin.Unassign("bob"); err != nil {
+		t.Fatal(err)
+	}
+	if bob, _ = GetUser("bob"); bob.IsAdmin() {
+		t.Fatalf("bob is not expected to be admin after the admin role is unassigned")
+	}
+
+	// The user role can't be taken.
+	user, _ := GetRole(UserRoleName)
+	if err := user.Unassign("bob"); err == nil {
+		t.Fatalf("user role is not expected to be unassigned")
+	}
+}
+
+func TestCustomRoles(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+
+	// Test:
+	var createtests = []struct {
+		name  string
+		perms []permset.Perm
+		ok    bool
+	}{
+		{"helpdesk", []permset.Perm{RenewAnyUserPerm, GenConfigAnyUserPerm}, true},
+		{"auditor", []permset.Perm{GetAnyUserPerm, GetVPNStatusPerm, ListNetworksPerm, ListRolesPerm}, true},
+		{"helpdesk", []permset.Perm{RenewAnyUserPerm}, false}, // duplicate
+		{"admin", []permset.Perm{RenewAnyUserPerm}, false},    // built-in
+		{"noperms", nil, false},
+		{"with space", []permset.Perm{RenewAnyUserPerm}, false},
+	}
+	for _, tt := range createtests {
+		if _, err := CreateRole(tt.name, "", tt.perms); (err == nil) != tt.ok {
+			t.Errorf("CreateRole(%s) error = %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+
+	// Users can have multiple roles.
+	helpdesk, _ := GetRole("helpdesk")
+	auditor, _ := GetRole("auditor")
+	if err := helpdesk.Assign("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := helpdesk.Assign("alice"); err == nil {
+		t.Fatalf("role is not expected to be assigned twice")
+	}
+	if err := auditor.Assign("alice"); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := GetUser("alice")
+	roles, _ := alice.GetRoles()
+	if len(roles) != 3 {
+		t.Fatalf("alice is expected to have 3 roles but she has %d", len(roles))
+	}
+	ps := permset.New(alice.GetPerms()...)
+	if !ps.ContainsAll(RenewAnyUserPerm, GenConfigAnyUserPerm, GetAnyUserPerm, GetSelfPerm) || ps.Contains(CreateUserPerm) {
+		t.Fatalf("alice is expected to have the union of her roles' perms: %v", PermNames(ps.Perms()))
+	}
+
+	// Updating the role changes the perms of its users.
+	if err := helpdesk.Update("renews certs", []permset.Perm{RenewAnyUserPerm}); err != nil {
+		t.Fatal(err)
+	}
+	if ps := permset.New(alice.GetPerms()...); ps.Contains(GenConfigAnyUserPerm) {
+		t.Fatalf("alice is not expected to keep the perms removed from her role")
+	}
+
+	// Deleting the role takes it from its users.
+	if err := auditor.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if roles, _ := alice.GetRoles(); len(roles) != 2 {
+		t.Fatalf("alice is expected to lose the deleted role")
+	}
+
+	// Deleting the user takes its roles.
+	if err := helpdesk.Unassign("alice"); err != nil {
+		t.Fatal(err)
+	}
+	helpdesk.Assign("alice")
+	alice.Delete()
+	if usernames := helpdesk.GetUsernames(); len(usernames) != 0 {
+		t.Fatalf("deleted user is not expected to keep her roles: %v", usernames)
+	}
+}
@@ -88,6 +88,7 @@ type Process struct {
 	stop            chan bool
 	out             *bytes.Buffer
 	stateChangeCond *sync.Cond
+	failureHook     func(State)
 	// stdin     io.WriteCloser
 	// stdoutLog Logger
 	// stderrLog Logger
@@ -136,6 +137,12 @@ func WaitFor(process *Process, state State) {
 	process.waitFor(state)
 }
 
+// SetFailureHook sets the function that is called with the state when the process fails to launch
+// or exits unexpectedly, before the supervisor exits.
+func (p *Process) SetFailureHook(hook func(State)) {
+	p.failureHook = hook
+}
+
 // Start will run the process.
 func (p *Process) Start() {
 	p.transitionTo(STARTING)
@@ -194,6 +201,13 @@ func (p *Process) transitionTo(state State) {
 	return
 }
 
+// fail calls the failure hook, if any.
+func (p *Process) fail(state State) {
+	if p.failureHook != nil {
+		p.failureHook(state)
+	}
+}
+
 func (p *Process) newCommand() *exec.Cmd {
 	cmd := exec.Command(p.executable)
 	cmd.Stdout = p.out
@@ -332,11 +346,13 @@ func (p *Process) run(state State) func() {
 		}
 	case FAILED:
 		return func() {
+			p.fail(FAILED)
 			logrus.Fatalf("failed to launch process: %s", p.executable)
 		}
 	case EXITED:
 		return func() {
 			logrus.Errorf("process exited unexpectedly: %s", p.executable)
+			p.fail(EXITED)
 			os.Exit(1)
 		}
 	default: // UNKNOWN
@@ -1,20 +1,53 @@
 package ovpm
 
 const ccdFileTemplate = `
+{{if .Disabled }}
+disable
+{{ end }}
 ifconfig-push {{ .IP }} {{ .NetMask }}
+{{if .RouteGW }}
+push "route-gateway {{ .RouteGW }}"
+{{ end }}
 
 {{if .RedirectGW }}
-push "redirect-gateway def1 bypass-dhcp"
+push "redirect-gateway {{ .GWFlags }}"
 {{ end }}
 
 {{range .Servernets}}
 push "route {{index . 0}} {{index . 1}}"
 {{ end }}
 
+{{range .IRoutes}}
+iroute {{index . 0}} {{index . 1}}
+{{ end }}
+
 
 {{range .Routes}}
 push "route {{index . 0}} {{index . 1}} {{index . 2}}"
 {{ end }}
+
+{{if .DNS }}
+push-remove "dhcp-option DNS"
+{{ end }}
+{{range .DNS}}
+push "dhcp-option DNS {{ . }}"
+{{ end }}
+
+{{if .SplitDNS }}
+push "dns server 0 address {{ .DefaultDNS }}"
+{{ end }}
+{{range .SplitDNS}}
+push "dns server {{index . 0}} address {{index . 1}}"
+push "dns server {{index . 0}} resolve-domains {{index . 2}}"
+{{ end }}
+
+{{range .Pushes}}
+push "{{ . }}"
+{{ end }}
+
+{{if .Inactive }}
+push "inactive {{ .Inactive }}"
+{{ end }}
 `
 
 const clientOvpnTemplate = `
@@ -35,6 +68,8 @@ persist-tun
 {{ if .UseLZO }}comp-lzo{{ end }}
 verb 3
 auth-nocache
+{{ if .PasswordAuth }}auth-user-pass{{ end }}
+{{ if and .PasswordAuth .TOTP }}static-challenge "Authenticator code" 1{{ end }}
 
 <ca>
 {{ .CA }}</ca>
@@ -222,6 +257,9 @@ ifconfig-pool-persist ipp.txt
 # First uncomment out these lines:
 ;client-config-dir ccd
 client-config-dir {{ .CCDPath }}
+{{range .ClientNets}}
+route {{index . 0}} {{index . 1}}
+{{ end }}
 # Then add this line to ccd/Thelonious:
 #   ifconfig-push 10.9.0.1 10.9.0.2
 
@@ -256,7 +294,9 @@ crl-verify {{ .CRLPath }}
 # The addresses below refer to the public
 # DNS servers provided by opendns.com.
 ;push "dhcp-option DNS 208.67.222.222"
-push "dhcp-option DNS {{ .DNS }}"
+{{range .DHCPOptions}}
+push "{{ . }}"
+{{ end }}
 
 # Uncomment this directive to allow different
 # clients to be able to "see" each other.
@@ -340,6 +380,14 @@ persist-tun
 # and rewritten every minute.
 status openvpn-status.log 5
 
+# ovpmd authorizes the clients when they connect and
+# kills the sessions of single users through the
+# management interface. The clients only send their
+# passwords if the password auth is enabled.
+management {{ .ManagementPath }} unix
+management-client-auth
+{{ if not .PasswordAuth }}auth-user-pass-optional{{ end }}
+
 # By default, log messages will go to the syslog (or
 # on Windows, if running as a service, they will go to
 # the "\Program Files\OpenVPN\log" directory).
@@ -364,3 +412,62 @@ verb 3
 # category will be output to the log.
 ;mute 20
 `
+
+// Mail templates are rendered into the subject on the first line and the body after it.
+
+const welcomeMailTemplate = `Welcome to {{ .Hostname }} VPN
+Hello {{ .Username }},
+
+A VPN account is created for you on {{ .Hostname }}.
+{{ if .URL }}
+You can log in and download your VPN profile at:
+
+    {{ .URL }}
+{{ end }}
+Import the profile into your OpenVPN client to connect.
+`
+
+const certExpiringMailTemplate = `Your VPN certificate expires {{ .ExpiresAt.Format "2006-01-02" }}
+Hello {{ .Username }},
+
+Your VPN certificate on {{ .Hostname }} expires at {{ .ExpiresAt.Format "2006-01-02 15:04 MST" }}.
+{{ if .Renewed }}
+A new certificate is issued to you. Download your new VPN profile and import it into your
+OpenVPN client before the current one expires.
+{{ if .URL }}
+    {{ .URL }}
+{{ end }}{{ else }}
+Contact your VPN administrator to renew it.
+{{ end }}`
+
+const passwordResetMailTemplate = `Reset your VPN password
+Hello {{ .Username }},
+
+A password reset is requested for your VPN account on {{ .Hostname }}. Open the link below to
+set a new password. It expires at {{ .ExpiresAt.Format "2006-01-02 15:04 MST" }}.
+
+    {{ .Link }}
+
+If you didn't request it, you can ignore this message.
+`
+
+const invitationMailTemplate = `You are invited to {{ .Hostname }} VPN
+Hello {{ .Username }},
+
+A VPN account is created for you on {{ .Hostname }}. Open the link below to set your password
+and download your VPN profile. It expires at {{ .ExpiresAt.Format "2006-01-02 15:04 MST" }}.
+
+    {{ .Link }}
+
+Import the profile into your OpenVPN client to connect.
+`
+
+const adminDigestMailTemplate = `VPN digest of {{ .Hostname }}
+{{ if .ExpiringCerts }}Certificates that expire soon:
+{{ range .ExpiringCerts }}
+    {{ .Username }}: {{ .ExpiresAt.Format "2006-01-02 15:04 MST" }}{{ if not .Renewable }} (can not be renewed beyond the CA, the server needs to be renewed){{ end }}{{ end }}
+{{ end }}{{ if .Failures }}
+Process failures since {{ .Since.Format "2006-01-02 15:04 MST" }}:
+{{ range .Failures }}
+    {{ .GetCreatedAt.Format "2006-01-02 15:04 MST" }} {{ .GetTarget }}: {{ .GetDetails }}{{ end }}
+{{ end }}`
@@ -0,0 +1,359 @@
+package ovpm
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jinzhu/gorm"
+	"github.com/master312/ovpm/permset"
+	"github.com/sirupsen/logrus"
+)
+
+// sessionTokenName is the name of the tokens that are issued by logging in.
+const sessionTokenName = "session"
+
+// tokenLastUsedResolution is how often the last used time of a token is written to the database.
+const tokenLastUsedResolution = time.Minute
+
+// dbTokenModel is database model for api tokens.
+//
+// Only the hash of the token is stored; the token itself is shown once, when it's created.
+type dbTokenModel struct {
+	gorm.Model
+	UserID uint `gorm:"index"`
+
+	Name          string
+	Hash          string    `gorm:"unique_index"` // hex encoded sha256 of the token
+	Scopes        string    // comma separated permission names; empty if the token isn't scoped
+	Session       bool      // issued by logging in, as opposed to created explicitly
+	ExpiresAt     time.Time // zero if the token doesn't expire
+	LastUsedAt    time.Time
+	OIDCSubject   string // subject of the OIDC session that the token is tied to
+	OIDCSessionID string // id of the OIDC session that the token is tied to
+}
+
+// Token represents an api token of a user or a service account.
+type Token struct {
+	dbTokenModel
+}
+
+// hashToken returns the hex encoded sha256 of the token.
+//
+// Tokens are long random strings, so a fast hash is sufficient.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken generates a new random token.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("can not generate token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// getAccount returns the user or the service account with the given username.
+func getAccount(username string) (*User, error) {
+	user := dbUserModel{}
+	db.Where(&dbUserModel{Username: username}).First(&user)
+	if db.NewRecord(&user) {
+		return nil, fmt.Errorf("user not found: %s", username)
+	}
+	return &User{dbUserModel: user}, nil
+}
+
+// issueToken generates and persists a new token for the user, and returns it with its plaintext.
+func issueToken(user *User, t dbTokenModel) (*Token, string, error) {
+	plaintext, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	t.UserID = user.ID
+	t.Hash = hashToken(plaintext)
+	db.Create(&t)
+	if db.NewRecord(&t) {
+		return nil, "", fmt.Errorf("can not create token in database: %s", t.Name)
+	}
+	purgeExpiredSessionTokens()
+	return &Token{dbTokenModel: t}, plaintext, nil
+}
+
+// CreateToken creates a new named api token for the user or the service account with the given username.
+//
+// If ttl is zero, the token never expires. If scopes are given, the token is restricted to those of
+// the owner's permissions; it's refused if the owner has none of them, since it would be unscoped
+// otherwise. The returned plaintext token is not stored, so it can't be retrieved later.
+func CreateToken(username, name string, ttl time.Duration, scopes []permset.Perm) (*Token, string, error) {
+	if !govalidator.Matches(name, "^[\\w\\.\\-]+$") {
+		return nil, "", fmt.Errorf("validation error: token name `%s` can only contain letters, numbers, underscores, dots and dashes", name)
+	}
+	if name == sessionTokenName {
+		return nil, "", fmt.Errorf("validation error: token name %s is reserved", name)
+	}
+	if ttl < 0 {
+		return nil, "", fmt.Errorf("validation error: token ttl can not be negative")
+	}
+	user, err := getAccount(username)
+	if err != nil {
+		return nil, "", err
+	}
+	var count int
+	db.Model(&dbTokenModel{}).Where(&dbTokenModel{UserID: user.ID, Name: name}).Count(&count)
+	if count > 0 {
+		return nil, "", fmt.Errorf("token %s of %s already exists", name, username)
+	}
+
+	if len(scopes) > 0 {
+		owned := permset.New(user.GetPerms()...)
+		var kept []permset.Perm
+		for _, scope := range scopes {
+			if owned.Contains(scope) {
+				kept = append(kept, scope)
+			}
+		}
+		if len(kept) == 0 {
+			return nil, "", fmt.Errorf("%s has none of the scopes of the token", username)
+		}
+		scopes = kept
+	}
+
+	t := dbTokenModel{Name: name, Scopes: strings.Join(PermNames(scopes), ",")}
+	if ttl > 0 {
+		t.ExpiresAt = time.Now().Add(ttl)
+	}
+	token, plaintext, err := issueToken(user, t)
+	if err != nil {
+		return nil, "", err
+	}
+	logrus.Infof("token created: %s of %s", name, username)
+	return token, plaintext, nil
+}
+
+// NewSessionToken issues a new token for a login session of the user.
+//
+// Session tokens expire after DefaultSessionTokenTTL and are revoked by logging out. Logging in
+// doesn't end the other sessions of the user.
+func (u *User) NewSessionToken() (string, error) {
+	_, plaintext, err := issueToken(u, dbTokenModel{Name: sessionTokenName, Session: true, ExpiresAt: time.Now().Add(DefaultSessionTokenTTL)})
+	return plaintext, err
+}
+
+// GetTokens returns the api tokens of the user or the service account with the given username.
+func GetTokens(username string) ([]*Token, error) {
+	user, err := getAccount(username)
+	if err != nil {
+		return nil, err
+	}
+	var dbTokens []*dbTokenModel
+	db.Where(&dbTokenModel{UserID: user.ID}).Order("id").Find(&dbTokens)
+
+	var tokens []*Token
+	for _, t := range dbTokens {
+		tokens = append(tokens, &Token{dbTokenModel: *t})
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes the named api token of the user or the service account with the given username.
+func RevokeToken(username, name string) error {
+	user, err := getAccount(username)
+	if err != nil {
+		return err
+	}
+	var t dbTokenModel
+	db.Where(&dbTokenModel{UserID: user.ID, Name: name}).First(&t)
+	if db.NewRecord(&t) {
+		return fmt.Errorf("token not found: %s of %s", name, username)
+	}
+	return (&Token{dbTokenModel: t}).Revoke()
+}
+
+// AuthenticateToken returns the owner of the given api token along with the token itself.
+//
+// It fails if the token is unknown or expired, or if its owner is disabled.
+func AuthenticateToken(token string) (*User, *Token, error) {
+	if token == "" {
+		return nil, nil, fmt.Errorf("token can not be empty")
+	}
+	var t dbTokenModel
+	db.Where(&dbTokenModel{Hash: hashToken(token)}).First(&t)
+	if db.NewRecord(&t) {
+		return nil, nil, fmt.Errorf("token not found: <token>")
+	}
+	if t.isExpired() {
+		return nil, nil, fmt.Errorf("token is expired: %s", t.Name)
+	}
+
+	var u dbUserModel
+	db.First(&u, t.UserID)
+	if db.NewRecord(&u) {
+		return nil, nil, fmt.Errorf("owner of the token not found: %s", t.Name)
+	}
+	user := &User{dbUserModel: u}
+	if user.IsDisabled() {
+		return nil, nil, fmt.Errorf("user is disabled: %s", user.Username)
+	}
+	if err := user.checkValidity(time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	if now := time.Now(); now.Sub(t.LastUsedAt) > tokenLastUsedResolution {
+		t.LastUsedAt = now
+		db.Model(&t).UpdateColumn("last_used_at", now)
+	}
+	return user, &Token{dbTokenModel: t}, nil
+}
+
+// Revoke deletes the token, so that it can't be used anymore.
+func (t *Token) Revoke() error {
+	if err := db.Unscoped().Delete(&t.dbTokenModel).Error; err != nil {
+		return err
+	}
+	logrus.Infof("token revoked: %s", t.Name)
+	return nil
+}
+
+// GetName returns the token's name.
+func (t *Token) GetName() string {
+	return t.Name
+}
+
+// IsSession returns whether the token is issued by logging in.
+func (t *Token) IsSession() bool {
+	return t.Session
+}
+
+// IsScoped returns whether the token is restricted to a subset of its owner's permissions.
+func (t *Token) IsScoped() bool {
+	return t.Scopes != ""
+}
+
+// GetScopes returns the permissions that the token is restricted to.
+func (t *Token) GetScopes() []permset.Perm {
+	if !t.IsScoped() {
+		return nil
+	}
+	perms, err := PermsFromNames(strings.Split(t.Scopes, ","))
+	if err != nil {
+		logrus.Warnf("token %s has invalid scopes: %v", t.Name, err)
+	}
+	return perms
+}
+
+// Restrict returns the given permissions of the token's owner, restricted to the token's scopes.
+func (t *Token) Restrict(perms []permset.Perm) []permset.Perm {
+	if !t.IsScoped() {
+		return perms
+	}
+	scopes := permset.New(t.GetScopes()...)
+	var restricted []permset.Perm
+	for _, perm := range perms {
+		if scopes.Contains(perm) {
+			restricted = append(restricted, perm)
+		}
+	}
+	return restricted
+}
+
+// GetCreatedAt returns the token's creation time.
+func (t *Token) GetCreatedAt() time.Time {
+	return t.CreatedAt
+}
+
+// GetExpiresAt returns the token's expiry. It's zero if the token doesn't expire.
+func (t *Token) GetExpiresAt() time.Time {
+	return t.ExpiresAt
+}
+
+// GetLastUsedAt returns the last time that the token is used. It's zero if the token is never used.
+func (t *Token) GetLastUsedAt() time.Time {
+	return t.LastUsedAt
+}
+
+// IsExpired returns whether the token is expired.
+func (t *Token) IsExpired() bool {
+	return t.isExpired()
+}
+
+func (t *dbTokenModel) isExpired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt)
+}
+
+// purgeExpiredSessionTokens deletes the session tokens that are expired.
+//
+// Expired named tokens are kept, so that they can be listed until they are revoked.
+func purgeExpiredSessionTokens() {
+	db.Unscoped().Where("session = ? AND expires_at < ?", true, time.Now()).Delete(&dbTokenModel{})
+}
+
+// deleteTokens deletes all of the tokens of the user with the given id.
+func deleteTokens(userID uint) {
+	db.Unscoped().Where(&dbTokenModel{UserID: userID}).Delete(&dbTokenModel{})
+}
+
+// CreateServiceAccount creates a new service account.
+//
+// Service accounts are meant for automation; they have no vpn cert and can only authenticate with
+// api tokens. Admin service accounts have the same permissions as admin users.
+func CreateServiceAccount(name, description string, admin bool) (*User, error) {
+	if !isValidUsername(name) {
+		return nil, fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", name)
+	}
+	if name == "root" {
+		return nil, fmt.Errorf("forbidden: username root is reserved and can not be used")
+	}
+	sa := dbUserModel{
+		Username:       name,
+		Admin:          admin,
+		Description:    description,
+		ServiceAccount: true,
+	}
+	db.Create(&sa)
+	if db.NewRecord(&sa) {
+		return nil, fmt.Errorf("can not create service account in database: %s", name)
+	}
+	logrus.Infof("service account created: %s", name)
+	return &User{dbUserModel: sa}, nil
+}
+
+// GetServiceAccount returns the service account with the given name.
+func GetServiceAccount(name string) (*User, error) {
+	sa := dbUserModel{}
+	db.Where(&dbUserModel{Username: name, ServiceAccount: true}).First(&sa)
+	if db.NewRecord(&sa) {
+		return nil, fmt.Errorf("service account not found: %s", name)
+	}
+	return &User{dbUserModel: sa}, nil
+}
+
+// GetAllServiceAccounts returns all of the service accounts.
+func GetAllServiceAccounts() ([]*User, error) {
+	var dbUsers []*dbUserModel
+	db.Where(&dbUserModel{ServiceAccount: true}).Find(&dbUsers)
+
+	var sas []*User
+	for _, u := range dbUsers {
+		sas = append(sas, &User{dbUserModel: *u})
+	}
+	return sas, nil
+}
+
+// DeleteServiceAccount deletes the service account with the given name along with its tokens.
+func DeleteServiceAccount(name string) error {
+	sa, err := GetServiceAccount(name)
+	if err != nil {
+		return err
+	}
+	db.Unscoped().Delete(&sa.dbUserModel)
+	deleteTokens(sa.ID)
+	deleteRoleAssignments(sa.ID)
+	logrus.Infof("service account deleted: %s", name)
+	return nil
+}
@@ -0,0 +1,220 @@
+package ovpm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/master312/ovpm/permset"
+)
+
+func TestCreateToken(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	user, err := CreateNewUser("alice", "1234", false, 0, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test:
+	var createtests = []struct {
+		username string
+		name     string
+		ttl      time.Duration
+		ok       bool
+	}{
+		{"alice", "laptop", 0, true},
+		{"alice", "ci", time.Hour, true},
+		{"alice", "ci", time.Hour, false},        // duplicate name
+		{"alice", "session", 0, false},           // reserved name
+		{"alice", "with space", 0, false},        // invalid name
+		{"alice", "negative", -time.Hour, false}, // negative ttl
+		{"nobody", "laptop", 0, false},           // unknown user
+	}
+	for _, tt := range createtests {
+		_, plaintext, err := CreateToken(tt.username, tt.name, tt.ttl, nil)
+		if (err == nil) != tt.ok {
+			t.Errorf("CreateToken(%s, %s, %s) error = %v, want ok %t", tt.username, tt.name, tt.ttl, err, tt.ok)
+			continue
+		}
+		if !tt.ok {
+			continue
+		}
+
+		// Is the token usable?
+		u, token, err := AuthenticateToken(plaintext)
+		if err != nil || u.ID != user.ID || token.GetName() != tt.name {
+			t.Errorf("token %s is expected to authenticate %s: %v", tt.name, tt.username, err)
+		}
+	}
+
+	// Is only the hash stored?
+	tokens, _ := GetTokens("alice")
+	if len(tokens) != 2 {
+		t.Fatalf("alice is expected to have 2 tokens but she has %d", len(tokens))
+	}
+	for _, token := range tokens {
+		if len(token.Hash) != 64 {
+			t.Fatalf("token hash is expected to be a hex encoded sha256: %s", token.Hash)
+		}
+		if token.GetLastUsedAt().IsZero() {
+			t.Fatalf("token %s is expected to have last used time", token.GetName())
+		}
+	}
+}
+
+func TestTokenExpiryAndRevocation(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	user, _ := CreateNewUser("alice", "1234", false, 0, false, "")
+
+	// Expired tokens are rejected.
+	token, plaintext, err := CreateToken("alice", "short", time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Model(&token.dbTokenModel).UpdateColumn("expires_at", time.Now().Add(-time.Minute))
+	if _, _, err := AuthenticateToken(plaintext); err == nil {
+		t.Fatalf("expired token is not expected to authenticate")
+	}
+
+	// Revoked tokens are rejected.
+	_, plaintext, _ = CreateToken("alice", "revoked", 0, nil)
+	if err := RevokeToken("alice", "revoked"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := AuthenticateToken(plaintext); err == nil {
+		t.Fatalf("revoked token is not expected to authenticate")
+	}
+	if err := RevokeToken("alice", "revoked"); err == nil {
+		t.Fatalf("revoking an unknown token is expected to fail")
+	}
+
+	// Logging in doesn't end the other sessions.
+	session1, _ := user.NewSessionToken()
+	session2, _ := user.NewSessionToken()
+	for _, s := range []string{session1, session2} {
+		if _, _, err := AuthenticateToken(s); err != nil {
+			t.Fatalf("session token is expected to authenticate: %v", err)
+		}
+	}
+
+	// Tokens of disabled users are rejected.
+	user.Disabled = true
+	db.Save(&user.dbUserModel)
+	if _, _, err := AuthenticateToken(session1); err == nil {
+		t.Fatalf("token of a disabled user is not expected to authenticate")
+	}
+
+	// Tokens are deleted along with their owner.
+	user.Delete()
+	var count int
+	db.Model(&dbTokenModel{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("tokens are expected to be deleted with their owner but %d are left", count)
+	}
+}
+
+func TestTokenRestrict(t *testing.T) {
+	token := &Token{dbTokenModel{Name: "scoped"}}
+	if perms := token.Restrict(AdminPerms()); len(perms) != len(AdminPerms()) {
+		t.Fatalf("unscoped token is expected to keep all of the perms")
+	}
+
+	token.Scopes = "vpn:status,user:create"
+	ps := permset.New(token.Restrict(UserPerms())...)
+	if len(ps.Perms()) != 0 {
+		t.Fatalf("scopes are not expected to grant perms that the owner doesn't have: %v", ps.Perms())
+	}
+	ps = permset.New(token.Restrict(AdminPerms())...)
+	if !ps.ContainsAll(GetVPNStatusPerm, CreateUserPerm) || len(ps.Perms()) != 2 {
+		t.Fatalf("scoped token is expected to be restricted to its scopes: %v", PermNames(ps.Perms()))
+	}
+}
+
+func TestCreateScopedToken(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	if _, err := CreateNewUser("alice", "1234", false, 0, false, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test:
+	if _, _, err := CreateToken("alice", "admin", 0, []permset.Perm{CreateUserPerm}); err == nil {
+		t.Fatalf("token is not expected to be created with only the scopes that its owner doesn't have")
+	}
+	token, _, err := CreateToken("alice", "self", 0, []permset.Perm{GetSelfPerm, CreateUserPerm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scopes := token.GetScopes(); len(scopes) != 1 || scopes[0] != GetSelfPerm {
+		t.Fatalf("scopes that the owner doesn't have are expected to be dropped: %v", PermNames(scopes))
+	}
+}
+
+func TestPermNames(t *testing.T) {
+	for _, perm := range AdminPerms() {
+		p, err := PermFromName(PermName(perm))
+		if err != nil || p != perm {
+			t.Fatalf("perm %d is expected to round trip through its name %s", perm, PermName(perm))
+		}
+	}
+	if _, err := PermFromName("foo:bar"); err == nil {
+		t.Fatalf("unknown perm name is expected to be rejected")
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	// Init:
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	CreateNewUser("alice", "1234", false, 0, false, "")
+
+	// Test:
+	sa, err := CreateServiceAccount("ci", "deploys stuff", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateServiceAccount("alice", "", false); err == nil {
+		t.Fatalf("service account is not expected to take a username")
+	}
+	if sa.Cert != "" || !sa.IsServiceAccount() {
+		t.Fatalf("service account is not expected to have a vpn cert")
+	}
+
+	// Service accounts aren't vpn users.
+	if _, err := GetUser("ci"); err == nil {
+		t.Fatalf("service account is not expected to be found as a user")
+	}
+	users, _ := GetAllUsers()
+	if len(users) != 1 {
+		t.Fatalf("service account is not expected to be listed as a user")
+	}
+	if _, err := Authenticate("ci", ""); err == nil {
+		t.Fatalf("service account is not expected to authenticate with a password")
+	}
+
+	// Service accounts authenticate with tokens.
+	_, plaintext, err := CreateToken("ci", "deploy", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _, err := AuthenticateToken(plaintext)
+	if err != nil || u.Username != "ci" || !u.IsAdmin() {
+		t.Fatalf("service account is expected to authenticate with its token: %v", err)
+	}
+
+	// Deleting the service account revokes its tokens.
+	if err := DeleteServiceAccount("ci"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := AuthenticateToken(plaintext); err == nil {
+		t.Fatalf("token of a deleted service account is not expected to authenticate")
+	}
+}
@@ -0,0 +1,158 @@
+package ovpm
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Users can be enrolled in TOTP (RFC 6238) as a second factor. The invited users enrol when they
+// accept their invitations, if it's required; afterwards their codes are required besides their
+// passwords wherever they log in with them.
+
+const (
+	totpPeriod = 30 // seconds
+	totpDigits = 6
+	totpSkew   = 1 // steps that the clocks of the authenticators may be off by
+)
+
+var totpRequired bool
+var totpRequiredMu sync.RWMutex
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// SetTOTPRequired sets whether the invited users must enrol in TOTP when they accept their
+// invitations.
+func SetTOTPRequired(required bool) {
+	totpRequiredMu.Lock()
+	defer totpRequiredMu.Unlock()
+	totpRequired = required
+}
+
+// IsTOTPRequired returns whether the invited users must enrol in TOTP.
+func IsTOTPRequired() bool {
+	totpRequiredMu.RLock()
+	defer totpRequiredMu.RUnlock()
+	return totpRequired
+}
+
+// TOTPKeyURI returns the otpauth:// uri of the secret that the authenticator apps are set up with,
+// usually through a QR code.
+func TOTPKeyURI(username, secret string) string {
+	issuer := TheServer().GetHostname()
+	if issuer == "" {
+		issuer = "ovpm"
+	}
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(username), v.Encode())
+}
+
+// HasTOTP returns whether the user is enrolled in TOTP.
+func (u *User) HasTOTP() bool {
+	return u.TOTPSecret != ""
+}
+
+// ResetTOTP unenrols the user from TOTP, e.g. when it loses its authenticator. It can log in with
+// its password alone afterwards.
+func (u *User) ResetTOTP() error {
+	q := db.Model(&u.dbUserModel).Updates(map[string]interface{}{"TOTPSecret": "", "TOTPLastStep": 0})
+	if q.Error != nil {
+		return q.Error
+	}
+	u.TOTPSecret, u.TOTPLastStep = "", 0
+	logrus.Infof("user totp reset: %s", u.Username)
+	return nil
+}
+
+// checkTOTP returns an error if the code isn't valid for the user now. Each code is only accepted
+// once, so the codes that are seen by others can't be replayed.
+func (u *User) checkTOTP(code string) error {
+	step, err := matchTOTP(u.TOTPSecret, code, time.Now(), u.TOTPLastStep)
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, u.Username)
+	}
+	// The step is only advanced if it's not used concurrently.
+	q := db.Model(&dbUserModel{}).Where("id = ? AND totp_last_step < ?", u.ID, step).Update("TOTPLastStep", step)
+	if q.Error != nil || q.RowsAffected != 1 {
+		return fmt.Errorf("totp code is already used: %s", u.Username)
+	}
+	u.TOTPLastStep = step
+	return nil
+}
+
+// authenticateTOTP is like Authenticate, but the users that are enrolled in TOTP must provide
+// their codes as well.
+func authenticateTOTP(username, password, code string) (*User, error) {
+	user, err := Authenticate(username, password)
+	if err != nil {
+		return nil, err
+	}
+	if user.HasTOTP() {
+		if err := user.checkTOTP(code); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// newTOTPSecret generates a random base32 encoded TOTP secret.
+func newTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("can not generate totp secret: %v", err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// matchTOTP returns the time step that the code is valid for at the time, allowing totpSkew steps
+// of clock drift. The steps up to the last one are rejected, since they are already used.
+func matchTOTP(secret, code string, t time.Time, lastStep int64) (int64, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(key) == 0 {
+		return 0, fmt.Errorf("totp secret is invalid")
+	}
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, fmt.Errorf("totp code is incorrect")
+	}
+	now := t.Unix() / totpPeriod
+	for step := now - totpSkew; step <= now+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+	return 0, fmt.Errorf("totp code is incorrect")
+}
+
+// totpCode returns the code of the key for the time step.
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	n := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, n%mod)
+}
@@ -0,0 +1,98 @@
+package ovpm
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatchTOTP(t *testing.T) {
+	// Test vectors of RFC 6238 for SHA1, truncated to 6 digits.
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	for _, tt := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		step, err := matchTOTP(secret, tt.code, time.Unix(tt.unix, 0), 0)
+		if err != nil || step != tt.unix/totpPeriod {
+			t.Errorf("matchTOTP(%d, %s) = %d, %v, want step %d", tt.unix, tt.code, step, err, tt.unix/totpPeriod)
+		}
+	}
+
+	now := time.Unix(1111111109, 0)
+	if _, err := matchTOTP(secret, "081804", now.Add(totpPeriod*time.Second), 0); err != nil {
+		t.Errorf("codes of the previous step are expected to be accepted: %v", err)
+	}
+	if _, err := matchTOTP(secret, "081804", now.Add(3*totpPeriod*time.Second), 0); err == nil {
+		t.Errorf("codes of the older steps are not expected to be accepted")
+	}
+	if _, err := matchTOTP(secret, "081804", now, now.Unix()/totpPeriod); err == nil {
+		t.Errorf("codes of the used steps are not expected to be accepted")
+	}
+	if _, err := matchTOTP(secret, "000000", now, 0); err == nil {
+		t.Errorf("incorrect codes are not expected to be accepted")
+	}
+}
+
+func TestTOTPAuthentication(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	TheServer().Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, false, "")
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Model(&alice.dbUserModel).Update("TOTPSecret", secret)
+	key, _ := totpEncoding.DecodeString(secret)
+	step := time.Now().Unix() / totpPeriod
+
+	// Test:
+	if _, err := AuthenticateFrom("alice", "1234", ""); err == nil {
+		t.Fatalf("alice is not expected to log in without its code")
+	}
+	if _, err := AuthenticateWithTOTP("alice", "5678", totpCode(key, step), ""); err == nil {
+		t.Fatalf("alice is not expected to log in with an incorrect password")
+	}
+	if _, err := AuthenticateWithTOTP("alice", "1234", totpCode(key, step-1), ""); err != nil {
+		t.Fatalf("alice is expected to log in with its code: %v", err)
+	}
+	if _, err := AuthenticateWithTOTP("alice", "1234", totpCode(key, step-1), ""); err == nil {
+		t.Fatalf("codes are not expected to be accepted twice")
+	}
+
+	// Enrolled users are asked for their codes on the tunnel as well.
+	SetVPNPasswordAuth(true)
+	defer SetVPNPasswordAuth(false)
+	profile, _ := TheServer().DumpsClientConfig("alice")
+	if !strings.Contains(profile, "static-challenge") {
+		t.Fatalf("profile of alice is expected to have a static challenge:\n%s", profile)
+	}
+	password := "SCRV1:" + base64.StdEncoding.EncodeToString([]byte("1234")) + ":" + base64.StdEncoding.EncodeToString([]byte(totpCode(key, step)))
+	env := map[string]string{"common_name": "alice", "username": "alice", "password": password}
+	if err := authorizeClient(env, false); err != nil {
+		t.Fatalf("alice is expected to connect with its code: %v", err)
+	}
+	if err := authorizeClient(map[string]string{"common_name": "alice", "username": "alice", "password": "1234"}, false); err == nil {
+		t.Fatalf("alice is not expected to connect without its code")
+	}
+
+	// Reset users log in with their passwords alone.
+	if err := alice.ResetTOTP(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AuthenticateFrom("alice", "1234", ""); err != nil {
+		t.Fatalf("alice is expected to log in without a code once it's reset: %v", err)
+	}
+	if profile, _ := TheServer().DumpsClientConfig("alice"); strings.Contains(profile, "static-challenge") {
+		t.Fatalf("profile of alice is not expected to have a static challenge once it's reset:\n%s", profile)
+	}
+}
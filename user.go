@@ -3,6 +3,7 @@ package ovpm
 import (
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	passlib "gopkg.in/hlandau/passlib.v1"
@@ -10,7 +11,6 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/asaskevich/govalidator"
 	"github.com/master312/ovpm/pki"
-	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 )
 
@@ -34,8 +34,28 @@ type dbUserModel struct {
 	NoGW               bool
 	HostID             uint32 // not user writable
 	Admin              bool
-	AuthToken          string // auth token
 	Description        string
+	Disabled           bool   // disabled users can neither connect nor log in
+	Suspended          bool   `gorm:"not null;default:false"` // suspended by an admin, unlike Disabled it's not touched by the LDAP sync
+	LDAPDN             string // distinguished name of the LDAP entry, if the user is managed by LDAP
+	ServiceAccount     bool   `gorm:"not null;default:false"` // service accounts have no vpn cert and only use api tokens
+	Pending            bool   `gorm:"not null;default:false"` // invited users are pending until they accept the invitation
+	DNS                string // comma separated dns servers to push to the user instead of the groups' and server's
+	Routes             string // comma separated networks to route through the vpn
+	RedirectGW         string // flags of the redirect-gateway option to push instead of the default ones
+	Pushes             string // newline separated extra options to push to the user
+	Inactive           uint32 // seconds of inactivity after which the client disconnects
+	IPPool             string // name of the ip pool that the user leases its address from
+	Email              string // address that the notifications are sent to
+
+	CertLifetime    time.Duration // lifetime of the client certs issued to the user, the server's default if zero
+	PreviousSerials string        // comma separated serial numbers of the renewed certs that are not revoked yet
+
+	TOTPSecret   string // base32 encoded secret of the user's authenticator, empty if it's not enrolled in TOTP
+	TOTPLastStep int64  // time step of the last accepted totp code, so that the codes can't be replayed
+
+	ValidFrom  *time.Time // user can't connect or log in before, if set
+	ValidUntil *time.Time // user can't connect or log in at and after, if set
 }
 
 // User represents a vpn user.
@@ -58,27 +78,12 @@ func (u *dbUserModel) setPassword(password string) error {
 	return nil
 }
 
-// RenewToken generates a new AuthToken and sets it to the db.
-func (u *User) RenewToken() (string, error) {
-	token := uuid.New().String()
-	u.AuthToken = token
-	db.Save(u.dbUserModel)
-	if db.Error != nil {
-		return "", db.Error
-	}
-	return token, nil
-}
-
-// ValidateToken returns whether the given token is valid or not.
-func (u *User) ValidateToken(token string) bool {
-	if u.AuthToken == "" {
-		return false
-	}
-	return u.AuthToken == token
-}
-
 // CheckPassword returns whether the given password is correct for the user.
 func (u *User) CheckPassword(password string) bool {
+	if u.Hash == "" {
+		// User doesn't have a local password.
+		return false
+	}
 	_, err := passlib.Verify(password, u.Hash)
 	if err != nil {
 		logrus.Error(err)
@@ -88,9 +93,11 @@ func (u *User) CheckPassword(password string) bool {
 }
 
 // GetUser finds and returns the user with the given username from database.
+//
+// Service accounts are not considered as users, see GetServiceAccount.
 func GetUser(username string) (*User, error) {
 	user := dbUserModel{}
-	db.Where(&dbUserModel{Username: username}).First(&user)
+	db.Where(&dbUserModel{Username: username}).Where("service_account = ?", false).First(&user)
 	if db.NewRecord(&user) {
 		// user is not found
 		return nil, fmt.Errorf("user not found: %s", username)
@@ -98,26 +105,17 @@ func GetUser(username string) (*User, error) {
 	return &User{dbUserModel: user}, nil
 }
 
-// GetUserByToken finds and returns the user with the given token from database.
+// GetUserByToken finds and returns the user with the given api token from database.
 func GetUserByToken(token string) (*User, error) {
-	if token == "" {
-		return nil, fmt.Errorf("token can not be empty")
-	}
-
-	user := dbUserModel{}
-	db.Where(&dbUserModel{AuthToken: token}).First(&user)
-	if db.NewRecord(&user) {
-		// user is not found
-		return nil, fmt.Errorf("user not found by token: <token>")
-	}
-	return &User{dbUserModel: user}, nil
+	user, _, err := AuthenticateToken(token)
+	return user, err
 }
 
 // GetAllUsers returns all recorded users in the database.
 func GetAllUsers() ([]*User, error) {
 	var users []*User
 	var dbUsers []*dbUserModel
-	db.Find(&dbUsers)
+	db.Where("service_account = ?", false).Find(&dbUsers)
 	for _, u := range dbUsers {
 		users = append(users, &User{dbUserModel: *u})
 	}
@@ -130,6 +128,22 @@ func GetAllUsers() ([]*User, error) {
 // It also generates the necessary client keys and signs certificates with the current
 // server's CA.
 func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bool, description string) (*User, error) {
+	user, err := createNewUser(username, password, nogw, hostid, admin, description)
+	if err != nil {
+		return nil, err
+	}
+
+	// EmitWithRestart server config
+	if err = TheServer().EmitWithRestart(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// createNewUser creates a new user in the database without emitting the server config.
+//
+// It is used by the bulk operations (e.g. LDAP sync) that emit once after all of the changes are made.
+func createNewUser(username, password string, nogw bool, hostid uint32, admin bool, description string) (*User, error) {
 	svr := TheServer()
 	if !svr.IsInitialized() {
 		return nil, fmt.Errorf("you first need to create server")
@@ -138,7 +152,7 @@ func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bo
 	if govalidator.IsNull(username) {
 		return nil, fmt.Errorf("validation error: %s can not be null", username)
 	}
-	if !govalidator.Matches(username, "^([\\w\\.]+)$") { // allow alphanumeric, underscore and dot
+	if !isValidUsername(username) {
 		return nil, fmt.Errorf("validation error: `%s` can only contain letters, numbers, underscores and dots", username)
 	}
 	if username == "root" {
@@ -150,7 +164,7 @@ func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bo
 		return nil, err
 	}
 
-	clientCert, err := pki.NewClientCertHolder(ca, username)
+	clientCert, err := pki.NewClientCertHolder(ca, username, GetCertConfig().ClientLifetime)
 	if err != nil {
 		return nil, fmt.Errorf("can not create client cert %s: %v", username, err)
 	}
@@ -194,19 +208,21 @@ func CreateNewUser(username, password string, nogw bool, hostid uint32, admin bo
 		Admin:              admin,
 		Description:		description,
 	}
-	user.setPassword(password)
+	// Users that are authenticated elsewhere (e.g. LDAP) have no local password.
+	if password != "" {
+		user.setPassword(password)
+	}
 
 	db.Create(&user)
 	if db.NewRecord(&user) {
 		// user is still not created
 		return nil, fmt.Errorf("can not create user in database: %s", user.Username)
 	}
-	logrus.Infof("user created: %s", username)
-
-	// EmitWithRestart server config
-	if err = svr.EmitWithRestart(); err != nil {
-		return nil, err
+	if _, err := leaseFor(&User{dbUserModel: user}); err != nil {
+		db.Unscoped().Delete(&user)
+		return nil, fmt.Errorf("can not lease an ip to %s: %v", user.Username, err)
 	}
+	logrus.Infof("user created: %s", username)
 	return &User{dbUserModel: user}, nil
 }
 
@@ -239,10 +255,9 @@ func (u *User) Update(password string, nogw bool, hostid uint32, admin bool, des
 		if !network.Contains(ip) {
 			return fmt.Errorf("ip %s, is out of vpn network %s", ip, network.String())
 		}
-
-		if u.HostID != hostid && hostIDsContains(getStaticHostIDs(), hostid) {
-			return fmt.Errorf("ip %s is already allocated", ip)
-		}
+	}
+	if _, err := leaseFor(u); err != nil {
+		return err
 	}
 	db.Save(u.dbUserModel)
 
@@ -255,14 +270,26 @@ func (u *User) Delete() error {
 		// user is not found
 		return fmt.Errorf("user is not initialized: %s", u.Username)
 	}
+	if owned := getOwnedNetworkNames(u.ID); len(owned) > 0 {
+		return fmt.Errorf("user %s can not be deleted because networks %s are behind it", u.Username, strings.Join(owned, ", "))
+	}
 	crt, err := pki.ReadCertFromPEM(u.Cert)
 	if err != nil {
 		return fmt.Errorf("can not get user's certificate: %v", err)
 	}
-	db.Create(&dbRevokedModel{
-		SerialNumber: crt.SerialNumber.Text(16),
-	})
+	// The renewed certs that are still valid are revoked along with the current one.
+	for _, serial := range append(u.previousSerials(), crt.SerialNumber.Text(16)) {
+		db.Create(&dbRevokedModel{
+			SerialNumber: serial,
+		})
+	}
 	db.Unscoped().Delete(u.dbUserModel)
+	releaseLease(u.ID)
+	deleteTokens(u.ID)
+	deleteInvitations(u.ID)
+	deleteRoleAssignments(u.ID)
+	deleteGroupMemberships(u.ID)
+	deleteNetworkACLs("user_id = ?", u.ID)
 	logrus.Infof("user deleted: %s", u.GetUsername())
 
 	if err = TheServer().EmitWithRestart(); err != nil {
@@ -295,26 +322,10 @@ func (u *User) ResetPassword(password string) error {
 //
 // Also it can be used when a user cert is expired or user's private key stolen, missing etc.
 func (u *User) Renew() error {
-	svr := TheServer()
-	if !svr.IsInitialized() {
-		return fmt.Errorf("you first need to create server")
-	}
-	ca, err := svr.GetSystemCA()
-	if err != nil {
+	if err := u.renewCert(); err != nil {
 		return err
 	}
-
-	clientCert, err := pki.NewClientCertHolder(ca, u.Username)
-	if err != nil {
-		return fmt.Errorf("can not create client cert %s: %v", u.Username, err)
-	}
-
-	u.Cert = clientCert.Cert
-	u.Key = clientCert.Key
-	u.ServerSerialNumber = svr.SerialNumber
-
-	db.Save(u.dbUserModel)
-	if err = svr.EmitWithRestart(); err != nil {
+	if err := TheServer().EmitWithRestart(); err != nil {
 		return err
 	}
 
@@ -351,50 +362,20 @@ func (u *User) GetCreatedAt() string {
 	return u.CreatedAt.Format(time.RFC3339)
 }
 
-// getIP returns user's vpn ip addr.
+// getIP returns user's vpn ip addr, or nil if the user has no lease yet.
 func (u *User) getIP() net.IP {
-	users := getNonStaticHostUsers()
-	staticHostIDs := getStaticHostIDs()
-	svr := TheServer()
-	mask := net.IPMask(net.ParseIP(svr.Mask).To4())
-	network := net.ParseIP(svr.Net).To4().Mask(mask)
-
-	// If the user has static ip address, return it immediately.
-	if u.HostID != 0 {
-		return HostID2IP(u.HostID)
-	}
-
-	// Calculate dynamic ip addresses from a deterministic address pool.
-	freeHostID := 0
-	for _, user := range users {
-		// Skip, if user is supposed to have static ip.
-		if user.HostID != 0 {
-			continue
-		}
-
-		// Try the next available host id.
-		hostID := IP2HostID(network) + uint32(freeHostID)
-		for hostIDsContains(staticHostIDs, hostID+2) {
-			freeHostID++ // Increase the host id and try again until it is available.
-			hostID = IP2HostID(network) + uint32(freeHostID)
-		}
-		if user.ID == u.ID {
-			return HostID2IP(hostID + 2)
-		}
-		freeHostID++
+	lease := u.getLease()
+	if lease == nil {
+		return nil
 	}
-	return nil
+	return HostID2IP(lease.HostID)
 }
 
 // GetIPNet returns user's vpn ip network. (e.g. 192.168.0.1/24)
 func (u *User) GetIPNet() string {
-	svr := TheServer()
-
-	mask := net.IPMask(net.ParseIP(svr.Mask).To4())
-
-	ipn := net.IPNet{
-		IP:   u.getIP(),
-		Mask: mask,
+	ipn := u.getIPNet()
+	if ipn == nil {
+		return ""
 	}
 	return ipn.String()
 }
@@ -422,6 +403,82 @@ func (u *User) GetDescription() string {
 	return u.Description
 }
 
+// GetEmail returns the address that the notifications of the user are sent to.
+func (u *User) GetEmail() string {
+	return u.Email
+}
+
+// ValidateEmail returns an error if the address isn't empty and it isn't an email address.
+func ValidateEmail(email string) error {
+	if email != "" && !govalidator.IsEmail(email) {
+		return fmt.Errorf("validation error: `%s` must be an email address", email)
+	}
+	return nil
+}
+
+// SetEmail sets the address that the notifications of the user are sent to. Empty address stops
+// the notifications of the user.
+func (u *User) SetEmail(email string) error {
+	if err := ValidateEmail(email); err != nil {
+		return err
+	}
+	db.Model(&u.dbUserModel).Update("Email", email)
+	u.Email = email
+	logrus.Infof("user email updated: %s", u.Username)
+	return nil
+}
+
+// IsDisabled returns whether the user is disabled or not, either by the LDAP sync, by being
+// suspended or by being pending until it accepts its invitation.
+func (u *User) IsDisabled() bool {
+	return u.Disabled || u.Suspended || u.Pending
+}
+
+// IsPending returns whether the user is invited and it hasn't accepted the invitation yet.
+func (u *User) IsPending() bool {
+	return u.Pending
+}
+
+// IsSuspended returns whether the user is suspended.
+func (u *User) IsSuspended() bool {
+	return u.Suspended
+}
+
+// Disable suspends the user: it's disabled in its ccd file, it's refused when it connects, its live
+// sessions are killed and it can no longer log in. Unlike Delete, the certificate, the address, the groups and the networks of
+// the user are kept, so Enable restores it as it was.
+func (u *User) Disable() error {
+	if u.Suspended {
+		return nil
+	}
+	db.Model(&u.dbUserModel).Update("Suspended", true)
+	logrus.Infof("user suspended: %s", u.Username)
+	if err := TheServer().Emit(); err != nil {
+		return err
+	}
+	return killClient(u.Username)
+}
+
+// Enable resumes the user that is suspended by Disable.
+func (u *User) Enable() error {
+	if !u.Suspended {
+		return nil
+	}
+	db.Model(&u.dbUserModel).Update("Suspended", false)
+	logrus.Infof("user resumed: %s", u.Username)
+	return TheServer().Emit()
+}
+
+// IsLDAPUser returns whether the user is managed by the LDAP backend.
+func (u *User) IsLDAPUser() bool {
+	return u.LDAPDN != ""
+}
+
+// IsServiceAccount returns whether the user is a service account.
+func (u *User) IsServiceAccount() bool {
+	return u.ServiceAccount
+}
+
 // ConnectionStatus returns information about user's connection to the VPN server.
 func (u *User) ConnectionStatus() (isConnected bool, connectedSince time.Time, bytesSent uint64, bytesReceived uint64) {
 	var found *clEntry
@@ -449,24 +506,13 @@ func (u *User) ConnectionStatus() (isConnected bool, connectedSince time.Time, b
 func getStaticHostUsers() []*User {
 	var users []*User
 	var dbUsers []*dbUserModel
-	db.Unscoped().Not(dbUserModel{HostID: 0}).Find(&dbUsers)
+	db.Unscoped().Not(dbUserModel{HostID: 0}).Where("service_account = ?", false).Find(&dbUsers)
 	for _, u := range dbUsers {
 		users = append(users, &User{dbUserModel: *u})
 	}
 	return users
 }
 
-func getNonStaticHostUsers() []*User {
-	var users []*User
-	var dbUsers []*dbUserModel
-	db.Unscoped().Where(dbUserModel{HostID: 0}).Find(&dbUsers)
-	for _, u := range dbUsers {
-		users = append(users, &User{dbUserModel: *u})
-	}
-
-	return users
-}
-
 func getStaticHostIDs() []uint32 {
 	var ids []uint32
 	users := getStaticHostUsers()
@@ -477,6 +523,11 @@ func getStaticHostIDs() []uint32 {
 	return ids
 }
 
+// isValidUsername returns whether the username only contains letters, numbers, underscores and dots.
+func isValidUsername(username string) bool {
+	return govalidator.Matches(username, "^([\\w\\.]+)$")
+}
+
 func hostIDsContains(s []uint32, e uint32) bool {
 	for _, a := range s {
 		if a == e {
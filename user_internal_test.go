@@ -2,7 +2,10 @@ package ovpm
 
 import (
 	"io"
+	"net"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -92,3 +95,57 @@ func TestUser_ConnectionStatus(t *testing.T) {
 func init() {
 	Testing = true
 }
+
+func TestUser_DisableEnable(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, IP2HostID(net.ParseIP("10.9.0.10").To4()), true, "")
+	ops, _ := CreateGroup("ops", "")
+	ops.AddMember("alice")
+	alice, _ = GetUser("alice")
+	cert, ipNet := alice.Cert, alice.GetIPNet()
+
+	// Test:
+	commands := fakeManagement(t)
+	if err := alice.Disable(); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ = GetUser("alice")
+	if !alice.IsDisabled() || !alice.IsSuspended() {
+		t.Fatalf("alice is expected to be suspended")
+	}
+	if got := commands(); !reflect.DeepEqual(got, []string{"kill alice"}) {
+		t.Fatalf("only the sessions of alice are expected to be killed, got %v", got)
+	}
+	if err := authorizeClient(map[string]string{"common_name": "alice"}, false); err == nil {
+		t.Fatalf("alice is not expected to connect while suspended")
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, "disable") {
+		t.Fatalf("ccd of alice is expected to be disabled:\n%s", ccd)
+	}
+	if _, err := Authenticate("alice", "1234"); err == nil {
+		t.Fatalf("alice is not expected to log in while suspended")
+	}
+
+	// Resuming restores the user as it was.
+	if err := alice.Enable(); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ = GetUser("alice")
+	if alice.IsDisabled() || alice.Cert != cert || alice.GetIPNet() != ipNet {
+		t.Fatalf("alice is expected to be restored: disabled=%v ip=%s", alice.IsDisabled(), alice.GetIPNet())
+	}
+	if groups, _ := alice.GetGroups(); len(groups) != 1 || groups[0].Name != "ops" {
+		t.Fatalf("alice is expected to stay in ops: %v", groups)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; strings.Contains(ccd, "disable") {
+		t.Fatalf("ccd of alice is not expected to be disabled:\n%s", ccd)
+	}
+	if _, err := Authenticate("alice", "1234"); err != nil {
+		t.Fatalf("alice is expected to log in after it's resumed: %v", err)
+	}
+}
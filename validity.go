@@ -0,0 +1,141 @@
+package ovpm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ValidateValidity returns an error if the validity window is empty. Nil bounds are open.
+func ValidateValidity(from, until *time.Time) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return fmt.Errorf("validation error: validity of the user ends (%s) before it starts (%s)", until.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// SetValidity sets the window that the user can connect and log in within. Nil bounds are open,
+// so nil for both makes the user valid indefinitely.
+//
+// Users that are out of their window are disabled in their ccd files and refused when they connect,
+// and the live sessions of the expired users are killed.
+func (u *User) SetValidity(from, until *time.Time) error {
+	if err := ValidateValidity(from, until); err != nil {
+		return err
+	}
+	wasValid := u.IsValidAt(time.Now())
+	db.Model(&u.dbUserModel).Updates(map[string]interface{}{
+		"ValidFrom":  from,
+		"ValidUntil": until,
+	})
+	u.ValidFrom, u.ValidUntil = from, until
+	logrus.Infof("user validity updated: %s", u.Username)
+
+	if err := TheServer().Emit(); err != nil {
+		return err
+	}
+	if wasValid && !u.IsValidAt(time.Now()) {
+		return killClient(u.Username)
+	}
+	return nil
+}
+
+// GetValidFrom returns the time that the user becomes valid at, or zero time if it's valid since
+// it's created.
+func (u *User) GetValidFrom() time.Time {
+	if u.ValidFrom == nil {
+		return time.Time{}
+	}
+	return *u.ValidFrom
+}
+
+// GetValidUntil returns the time that the user expires at, or zero time if it never expires.
+func (u *User) GetValidUntil() time.Time {
+	if u.ValidUntil == nil {
+		return time.Time{}
+	}
+	return *u.ValidUntil
+}
+
+// IsValidAt returns whether the time is within the validity window of the user.
+func (u *User) IsValidAt(t time.Time) bool {
+	return u.checkValidity(t) == nil
+}
+
+// checkValidity returns an error if the time is out of the validity window of the user.
+func (u *User) checkValidity(t time.Time) error {
+	if u.ValidFrom != nil && t.Before(*u.ValidFrom) {
+		return fmt.Errorf("user is not valid until %s: %s", u.ValidFrom.Format(time.RFC3339), u.Username)
+	}
+	if u.ValidUntil != nil && !t.Before(*u.ValidUntil) {
+		return fmt.Errorf("user is expired at %s: %s", u.ValidUntil.Format(time.RFC3339), u.Username)
+	}
+	return nil
+}
+
+// processUserValidity applies the changes of the users' validity between the times: ccd files of
+// the users that become valid or expire are emitted again, and the sessions of the expired users
+// are killed.
+func processUserValidity(since, now time.Time) error {
+	svr := TheServer()
+	if !svr.IsInitialized() {
+		return nil
+	}
+	crossed := func(t *time.Time) bool { return t != nil && !t.After(now) && t.After(since) }
+
+	var dbUsers []*dbUserModel
+	q := db.Where("valid_from IS NOT NULL OR valid_until IS NOT NULL").Find(&dbUsers)
+	if q.Error != nil {
+		return q.Error
+	}
+	var changed bool
+	var expired []string
+	for _, u := range dbUsers {
+		user := &User{dbUserModel: *u}
+		switch {
+		case crossed(user.ValidUntil):
+			logrus.Infof("user expired: %s", user.Username)
+			expired = append(expired, user.Username)
+			changed = true
+		case crossed(user.ValidFrom):
+			logrus.Infof("user became valid: %s", user.Username)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := svr.Emit(); err != nil {
+		return err
+	}
+	for _, username := range expired {
+		if err := killClient(username); err != nil {
+			logrus.Errorf("sessions of the expired user can not be killed: %v", err)
+		}
+	}
+	return nil
+}
+
+// StartValidityJob runs processUserValidity periodically in the background until the returned
+// function is called.
+func StartValidityJob(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		since := time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := processUserValidity(since, now); err != nil {
+					logrus.Errorf("user validity check failed: %v", err)
+				}
+				since = now
+			}
+		}
+	}()
+	return func() { close(done) }
+}
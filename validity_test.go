@@ -0,0 +1,79 @@
+package ovpm
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserValidity(t *testing.T) {
+	// Init:
+	setupTestCase()
+	db := CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	alice, _ := CreateNewUser("alice", "1234", false, 0, true, "")
+	CreateNewUser("bob", "1234", false, 0, true, "")
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	// Test:
+	if err := alice.SetValidity(&future, &past); err == nil {
+		t.Fatalf("validity is not expected to end before it starts")
+	}
+
+	// Users out of their windows are disabled, and can neither log in nor connect.
+	commands := fakeManagement(t)
+	for _, window := range [][2]*time.Time{{nil, &past}, {&future, nil}} {
+		if err := alice.SetValidity(window[0], window[1]); err != nil {
+			t.Fatal(err)
+		}
+		if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, "disable") {
+			t.Fatalf("ccd of alice is expected to be disabled out of %v:\n%s", window, ccd)
+		}
+		if _, err := Authenticate("alice", "1234"); err == nil {
+			t.Fatalf("alice is not expected to log in out of %v", window)
+		}
+		if err := authorizeClient(map[string]string{"common_name": "alice"}, false); err == nil {
+			t.Fatalf("alice is not expected to connect out of %v", window)
+		}
+	}
+	// Only the sessions of alice are killed, once it expires.
+	if got := commands(); !reflect.DeepEqual(got, []string{"kill alice"}) {
+		t.Fatalf("only the sessions of alice are expected to be killed, got %v", got)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "bob")]; strings.Contains(ccd, "disable") {
+		t.Fatalf("ccd of bob is not expected to be disabled:\n%s", ccd)
+	}
+
+	// Users within their windows are enabled.
+	if err := alice.SetValidity(&past, &future); err != nil {
+		t.Fatal(err)
+	}
+	alice, _ = GetUser("alice")
+	if alice.GetValidFrom().Unix() != past.Unix() || alice.GetValidUntil().Unix() != future.Unix() {
+		t.Fatalf("validity of alice is expected to be persisted: %s-%s", alice.GetValidFrom(), alice.GetValidUntil())
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; strings.Contains(ccd, "disable") {
+		t.Fatalf("ccd of alice is not expected to be disabled within its window:\n%s", ccd)
+	}
+	if _, err := Authenticate("alice", "1234"); err != nil {
+		t.Fatalf("alice is expected to log in within its window: %v", err)
+	}
+
+	// Users that expire since the last check are disabled by the job.
+	expiry := time.Now().Add(-time.Second)
+	db.Model(&alice.dbUserModel).Update("ValidUntil", &expiry)
+	if err := processUserValidity(expiry.Add(-time.Minute), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if ccd := fs[filepath.Join(_DefaultVPNCCDPath, "alice")]; !strings.Contains(ccd, "disable") {
+		t.Fatalf("ccd of alice is expected to be disabled after it expires:\n%s", ccd)
+	}
+	if got := commands(); len(got) != 2 || got[1] != "kill alice" {
+		t.Fatalf("sessions of alice are expected to be killed after it expires, got %v", got)
+	}
+}
@@ -16,9 +16,9 @@ import (
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/master312/ovpm/firewall"
 	"github.com/master312/ovpm/pki"
 	"github.com/master312/ovpm/supervisor"
-	"github.com/coreos/go-iptables/iptables"
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
@@ -46,10 +46,17 @@ type dbServerModel struct {
 	Net              string // VPN network.
 	Mask             string // VPN network mask.
 	CRL              string // Certificate Revocation List
-	DNS              string // DNS servers to push to the clients.
+	DNS              string // Comma separated DNS servers to push to the clients.
+	Domain           string // DNS domain to push to the clients.
+	SearchDomains    string // Comma separated DNS search domains to push to the clients.
+	NTP              string // Comma separated NTP servers to push to the clients.
+	WINS             string // Comma separated WINS servers to push to the clients.
 	KeepalivePeriod  string // Keepalive ping period
 	KeepaliveTimeout string // Keepalive timeout
 	UseLZO           bool   // Use LZO compression
+	NATMode          string // Translation of the clients' traffic: masquerade, snat or none.
+	SNATTo           string // Source address of the clients' traffic when NATMode is snat.
+	EgressIface      string // Interface that the clients' traffic goes out of; detected if empty.
 }
 
 var serverInstance *Server
@@ -183,7 +190,7 @@ func (svr *Server) GetCRL() string {
 	return svr.CRL
 }
 
-// GetDNS returns vpn server's dns.
+// GetDNS returns vpn server's comma separated dns servers.
 func (svr *Server) GetDNS() string {
 	if svr.DNS != "" {
 		return svr.DNS
@@ -214,6 +221,26 @@ func (svr *Server) IsUseLZO() bool {
 	return svr.UseLZO
 }
 
+// GetNATMode returns how the clients' traffic is translated when it goes out of the egress interface.
+func (svr *Server) GetNATMode() string {
+	if svr.NATMode != "" {
+		return svr.NATMode
+	}
+	return string(firewall.Masquerade)
+}
+
+// GetSNATTo returns the source address of the clients' traffic when the nat mode is snat.
+func (svr *Server) GetSNATTo() string {
+	return svr.SNATTo
+}
+
+// GetEgressIface returns the interface that the clients' traffic goes out of.
+//
+// It's empty if the interface is detected from the default route.
+func (svr *Server) GetEgressIface() string {
+	return svr.EgressIface
+}
+
 // Init regenerates keys and certs for a Root CA, gets initial settings for the VPN server
 // and saves them in the database.
 //
@@ -312,16 +339,19 @@ func (svr *Server) Init(hostname string, port string, proto string, ipblock stri
 		return fmt.Errorf("validation error: hostname:`%s` should be either an ip address or a FQDN", hostname)
 	}
 
-	if !govalidator.IsIPv4(dns) {
-		return fmt.Errorf("validation error: dns:`%s` should be an ip address", dns)
+	for _, ip := range splitList(dns) {
+		if !govalidator.IsIPv4(ip) {
+			return fmt.Errorf("validation error: dns:`%s` should be an ip address", ip)
+		}
 	}
+	dns = strings.Join(splitList(dns), ",")
 
-	ca, err := pki.NewCA()
+	ca, err := pki.NewCA(GetCertConfig().CALifetime)
 	if err != nil {
 		return fmt.Errorf("can not create ca creds: %s", err)
 	}
 
-	srv, err := pki.NewServerCertHolder(ca)
+	srv, err := pki.NewServerCertHolder(ca, GetCertConfig().ServerLifetime)
 	if err != nil {
 		return fmt.Errorf("can not create server cert creds: %s", err)
 	}
@@ -379,20 +409,43 @@ func (svr *Server) Update(ipblock string, dns string, useLzo *bool) error {
 		return fmt.Errorf("server is not initialized")
 	}
 
-	var changed bool
+	var changed, netChanged bool
 	if ipblock != "" && govalidator.IsCIDR(ipblock) {
 		var ipnet *net.IPNet
 		_, ipnet, err := net.ParseCIDR(ipblock)
 		if err != nil {
 			return fmt.Errorf("can not parse CIDR %s: %v", ipblock, err)
 		}
+		block := CIDRBlock{Kind: CIDRVPN, CIDR: ipnet.String()}
+		vpn := func(b CIDRBlock) bool { return b.Kind == CIDRVPN }
+		if err := checkCIDRConflict(block, vpn); err != nil {
+			return err
+		}
+		pools, err := getIPPools()
+		if err != nil {
+			return err
+		}
+		for _, pool := range pools {
+			if pool.Subnet != "" {
+				continue
+			}
+			if first, last := pool.hostIDs(); !ipnet.Contains(HostID2IP(first)) || !ipnet.Contains(HostID2IP(last)) {
+				return fmt.Errorf("validation error: ip pool %s (%s-%s) is out of %s, it should be deleted first", pool.Name, pool.Start, pool.End, ipnet)
+			}
+		}
 		svr.dbServerModel.Net = ipnet.IP.To4().String()
 		svr.dbServerModel.Mask = net.IP(ipnet.Mask).To4().String()
-		changed = true
+		changed, netChanged = true, true
 	}
 
-	if dns != "" && govalidator.IsIPv4(dns) {
-		svr.dbServerModel.DNS = dns
+	if dns != "" {
+		servers := splitList(dns)
+		for _, ip := range servers {
+			if !govalidator.IsIPv4(ip) {
+				return fmt.Errorf("validation error: dns:`%s` should be an ip address", ip)
+			}
+		}
+		svr.dbServerModel.DNS = strings.Join(servers, ",")
 		changed = true
 	}
 	if useLzo != nil {
@@ -401,24 +454,90 @@ func (svr *Server) Update(ipblock string, dns string, useLzo *bool) error {
 	}
 	if changed {
 		db.Save(svr.dbServerModel)
+	}
+	if netChanged {
 		users, err := GetAllUsers()
 		if err != nil {
 			return err
 		}
 
-		// Set all users to dynamic ip address.
-		// This way we prevent any ip range mismatch.
+		// Set all users to dynamic ip address, and lease them new ones in the order they're
+		// created. This way we prevent any ip range mismatch.
+		releaseAllLeases()
 		for _, user := range users {
 			user.HostID = 0
 			db.Save(user.dbUserModel)
+			if _, err := leaseFor(user); err != nil {
+				logrus.Errorf("can not lease an ip to %s: %v", user.Username, err)
+			}
 		}
-
+	}
+	if changed {
 		svr.EmitWithRestart()
 		logrus.Infof("server updated")
 	}
 	return nil
 }
 
+// UpdateNAT updates how the clients' traffic is translated.
+//
+// Empty mode and snatTo leave them as they are. snatTo is required for the snat mode, and it's
+// dropped when switching to another mode. Empty egressIface makes the egress interface be detected
+// from the default route, and nil leaves it as it is.
+func (svr *Server) UpdateNAT(mode, snatTo string, egressIface *string) error {
+	if !svr.IsInitialized() {
+		return fmt.Errorf("server is not initialized")
+	}
+
+	newMode, newSNATTo, newEgressIface := svr.GetNATMode(), svr.SNATTo, svr.EgressIface
+	if mode != "" {
+		if !firewall.IsNATMode(mode) {
+			return fmt.Errorf("validation error: unknown nat mode `%s`", mode)
+		}
+		newMode = mode
+	}
+	if snatTo != "" {
+		if !govalidator.IsIPv4(snatTo) {
+			return fmt.Errorf("validation error: snat address `%s` is not an IPv4 address", snatTo)
+		}
+		newSNATTo = snatTo
+	}
+	switch {
+	case newMode == string(firewall.SNAT) && newSNATTo == "":
+		return fmt.Errorf("validation error: snat mode requires an address to translate to")
+	case newMode != string(firewall.SNAT) && snatTo != "":
+		return fmt.Errorf("validation error: snat address can only be set with the snat mode")
+	case newMode != string(firewall.SNAT):
+		newSNATTo = ""
+	}
+	if egressIface != nil {
+		if len(*egressIface) > 15 || strings.ContainsAny(*egressIface, "/ \t") {
+			return fmt.Errorf("validation error: `%s` is not a valid interface name", *egressIface)
+		}
+		newEgressIface = *egressIface
+	}
+
+	svr.NATMode, svr.SNATTo, svr.EgressIface = newMode, newSNATTo, newEgressIface
+	db.Save(svr.dbServerModel)
+	reapplyNat()
+	logrus.Infof("server nat updated")
+	return nil
+}
+
+// reapplyNat replaces the nat rules if the vpn server is running.
+func reapplyNat() {
+	if vpnProc != nil && vpnProc.Status() == supervisor.RUNNING {
+		ensureNatEnabled()
+	}
+}
+
+// reapplyResolver restarts the resolver if the vpn server is running.
+func reapplyResolver() {
+	if vpnProc != nil && vpnProc.Status() == supervisor.RUNNING {
+		ensureResolverRunning()
+	}
+}
+
 // Deinit deletes the VPN server from the database and frees the allocated resources.
 func (svr *Server) Deinit() error {
 	if !svr.IsInitialized() {
@@ -427,6 +546,8 @@ func (svr *Server) Deinit() error {
 
 	db.Unscoped().Delete(&dbServerModel{})
 	db.Unscoped().Delete(&dbRevokedModel{})
+	db.Unscoped().Delete(&dbIPPoolModel{})
+	releaseAllLeases()
 	svr.EmitWithRestart()
 	return nil
 }
@@ -450,6 +571,8 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 		KeepalivePeriod  string
 		KeepaliveTimeout string
 		UseLZO           bool
+		PasswordAuth     bool
+		TOTP             bool
 	}{
 		Hostname:         svr.GetHostname(),
 		Port:             svr.GetPort(),
@@ -461,6 +584,8 @@ func (svr *Server) DumpsClientConfig(username string) (string, error) {
 		KeepalivePeriod:  svr.GetKeepalivePeriod(),
 		KeepaliveTimeout: svr.GetKeepaliveTimeout(),
 		UseLZO:           svr.IsUseLZO(),
+		PasswordAuth:     IsVPNPasswordAuth(),
+		TOTP:             user.HasTOTP(),
 	}
 
 	t, err := template.New("client.ovpn").Parse(clientOvpnTemplate)
@@ -522,6 +647,8 @@ func (svr *Server) StartVPNProc() {
 	svr.Emit()
 	vpnProc.Start()
 	ensureNatEnabled()
+	ensureResolverRunning()
+	ensureManagementConnected()
 }
 
 // RestartVPNProc restarts the OpenVPN process.
@@ -536,6 +663,8 @@ func (svr *Server) RestartVPNProc() {
 	svr.Emit()
 	vpnProc.Restart()
 	ensureNatEnabled()
+	ensureResolverRunning()
+	ensureManagementConnected()
 }
 
 // StopVPNProc stops the OpenVPN process.
@@ -543,6 +672,10 @@ func (svr *Server) StopVPNProc() {
 	if vpnProc == nil {
 		panic(fmt.Sprintf("vpnProc is not initialized!"))
 	}
+	stopNatEnabler()
+	stopResolver()
+	stopManagement()
+	svr.cleanupFirewall()
 	if vpnProc.Status() != supervisor.RUNNING {
 		logrus.Error("OpenVPN is already not running")
 		return
@@ -562,8 +695,8 @@ func (svr *Server) Emit() error {
 
 	}
 
-	if !checkIptablesExecutable() {
-		return fmt.Errorf("iptables executable can not be found")
+	if _, err := svr.getFirewall(); err != nil {
+		return fmt.Errorf("firewall is not available: %v", err)
 	}
 
 	if !svr.IsInitialized() {
@@ -598,10 +731,14 @@ func (svr *Server) Emit() error {
 		return fmt.Errorf("can not emit ccd: %s", err)
 	}
 
-	if err := svr.emitIptables(); err != nil {
-		return fmt.Errorf("can not emit iptables: %s", err)
+	if err := svr.emitFirewall(); err != nil {
+		return fmt.Errorf("can not emit firewall rules: %s", err)
 	}
 
+	// Replace the nat rules and the resolver in case the vpn network is changed.
+	reapplyNat()
+	reapplyResolver()
+
 	if err := svr.emitCRL(); err != nil {
 		return fmt.Errorf("can not emit crl: %s", err)
 	}
@@ -665,11 +802,6 @@ func (svr *Server) emitServerConf() error {
 		proto = serverInstance.Proto
 	}
 
-	dns := DefaultVPNDNS
-	if serverInstance.DNS != "" {
-		dns = serverInstance.DNS
-	}
-
 	var result bytes.Buffer
 
 	server := struct {
@@ -680,14 +812,17 @@ func (svr *Server) emitServerConf() error {
 		CCDPath          string
 		CRLPath          string
 		DHParamsPath     string
+		ManagementPath   string
+		PasswordAuth     bool
 		Net              string
 		Mask             string
 		Port             string
 		Proto            string
-		DNS              string
+		DHCPOptions      []string
 		KeepalivePeriod  string
 		KeepaliveTimeout string
 		UseLZO           bool
+		ClientNets       [][2]string // [0] is IP, [1] is Netmask
 	}{
 		CertPath:         _DefaultCertPath,
 		KeyPath:          _DefaultKeyPath,
@@ -696,16 +831,35 @@ func (svr *Server) emitServerConf() error {
 		CCDPath:          _DefaultVPNCCDPath,
 		CRLPath:          _DefaultCRLPath,
 		DHParamsPath:     _DefaultDHParamsPath,
+		ManagementPath:   _DefaultManagementPath,
+		PasswordAuth:     IsVPNPasswordAuth(),
 		Net:              svr.Net,
 		Mask:             svr.Mask,
 		Port:             port,
 		Proto:            proto,
-		DNS:              dns,
+		DHCPOptions:      svr.clientDHCPOptions().pushes(),
 		KeepalivePeriod:  svr.GetKeepalivePeriod(),
 		KeepaliveTimeout: svr.GetKeepaliveTimeout(),
 		UseLZO:           svr.IsUseLZO(),
 	}
 
+	// Route the ip pools beside the vpn network and the networks behind the clients to the vpn
+	// interface; OpenVPN routes them to the clients with the ifconfig-pushes and the iroutes in the
+	// ccd files.
+	for _, subnet := range subnetIPPools() {
+		server.ClientNets = append(server.ClientNets, [2]string{subnet.IP.To4().String(), net.IP(subnet.Mask).To4().String()})
+	}
+	for _, network := range GetAllNetworks() {
+		if network.Type != CLIENTNET {
+			continue
+		}
+		ip, mask, err := net.ParseCIDR(network.CIDR)
+		if err != nil {
+			return err
+		}
+		server.ClientNets = append(server.ClientNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+	}
+
 	t, err := template.New("server.conf").Parse(serverConfTemplate)
 	if err != nil {
 		return fmt.Errorf("can not parse server.conf.tmpl template: %s", err)
@@ -854,45 +1008,135 @@ func (svr *Server) emitCCD() error {
 		}
 	}
 	// Render ccd templates for the users.
+	networks := GetAllNetworks()
+	leases := getLeases()
+	now := time.Now()
+	plan, err := newIPPlan()
+	if err != nil {
+		return err
+	}
 	for _, user := range users {
+		// Networks can be associated with the user directly or with one of the user's groups.
+		groups, err := user.GetGroups()
+		if err != nil {
+			return err
+		}
+		// Leases that are out of the user's pools, e.g. after the user joins a group that has an
+		// ip pool, are renewed.
+		lease, ok := leases[user.ID]
+		if !ok || !plan.isValid(lease, user, groups) {
+			if lease, err = leaseFor(user); err != nil {
+				logrus.Errorf("can not lease an ip to %s: %v", user.Username, err)
+				continue
+			}
+		}
+		ipnet := plan.ipNet(lease)
+		noGW := user.isNoGW(groups)
+		var dns, pushes []string
+		for _, group := range groups {
+			dns = append(dns, group.GetDNS()...)
+			pushes = append(pushes, group.GetPushes()...)
+		}
+		// Options of the user take precedence over the ones of the groups.
+		userOpts := user.GetPushOptions()
+		if len(userOpts.DNS) > 0 {
+			dns = userOpts.DNS
+		}
+		pushes = append(pushes, userOpts.Pushes...)
+		redirectGW := defaultRedirectGateway
+		if userOpts.RedirectGW != "" {
+			redirectGW = userOpts.RedirectGW
+		}
+
 		var associatedRoutes [][3]string
-		var serverNets [][2]string
-		for _, network := range GetAllNetworks() {
+		serverNets := userOpts.routes()
+		// Clients in the ip pools beside the vpn network reach it through their own gateway.
+		var routeGW string
+		if gw := plan.gateway(lease); gw != nil {
+			routeGW = gw.String()
+			vpnNet := svr.vpnNet()
+			serverNets = append(serverNets, [2]string{vpnNet.IP.To4().String(), net.IP(vpnNet.Mask).To4().String()})
+		}
+		var iroutes [][2]string
+		var splitDNS [][3]string
+		for _, network := range networks {
+			if network.Type == CLIENTNET && network.OwnerID == user.ID {
+				ip, mask, err := net.ParseCIDR(network.CIDR)
+				if err != nil {
+					return err
+				}
+				iroutes = append(iroutes, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+				continue
+			}
+			if !network.isAssociated(user, groups) {
+				continue
+			}
+			if network.DNSDomain != "" {
+				priority := fmt.Sprintf("%d", len(splitDNS)+1)
+				splitDNS = append(splitDNS, [3]string{priority, strings.Join(network.GetDNSServers(), " "), network.DNSDomain})
+			}
+			if network.MappedCIDR != "" {
+				// Mapped networks are only reachable at their virtual addresses through the vpn server.
+				ip, mask, err := net.ParseCIDR(network.MappedCIDR)
+				if err != nil {
+					return err
+				}
+				serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+				continue
+			}
 			switch network.Type {
 			case ROUTE:
-				for _, assocUsername := range network.GetAssociatedUsernames() {
-					if assocUsername == user.Username {
-						via := network.Via
-						ip, mask, err := net.ParseCIDR(network.CIDR)
-						if err != nil {
-							return err
-						}
-						associatedRoutes = append(associatedRoutes, [3]string{ip.To4().String(), net.IP(mask.Mask).To4().String(), via})
-					}
+				via := network.Via
+				ip, mask, err := net.ParseCIDR(network.CIDR)
+				if err != nil {
+					return err
 				}
+				associatedRoutes = append(associatedRoutes, [3]string{ip.To4().String(), net.IP(mask.Mask).To4().String(), via})
 			case SERVERNET:
 				// Push associated servernets to client when client is not getting vpn server as default gw.
-				if user.IsNoGW() {
-					for _, assocUsername := range network.GetAssociatedUsernames() {
-						if assocUsername == user.Username {
-							ip, mask, err := net.ParseCIDR(network.CIDR)
-							if err != nil {
-								return err
-							}
-							serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
-						}
+				if noGW {
+					ip, mask, err := net.ParseCIDR(network.CIDR)
+					if err != nil {
+						return err
 					}
+					serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+				}
+			case CLIENTNET:
+				// Push associated clientnets to client regardless of the default gw, since they
+				// are only reachable through the vpn.
+				ip, mask, err := net.ParseCIDR(network.CIDR)
+				if err != nil {
+					return err
 				}
+				serverNets = append(serverNets, [2]string{ip.To4().String(), net.IP(mask.Mask).To4().String()})
+			}
+		}
+		// Clients that resolve the domains of the networks with their dns servers still need
+		// their usual dns servers for the rest of the names.
+		var defaultDNS string
+		if len(splitDNS) > 0 {
+			defaultDNS = strings.Join(svr.clientDHCPOptions().DNS, " ")
+			if len(dns) > 0 {
+				defaultDNS = strings.Join(dns, " ")
 			}
 		}
 		var result bytes.Buffer
 		params := struct {
 			IP         string
 			NetMask    string
+			RouteGW    string      // gateway of the client, if it's out of the vpn network
 			Routes     [][3]string // [0] is IP, [1] is Netmask, [2] is Via
 			Servernets [][2]string // [0] is IP, [1] is Netmask
+			IRoutes    [][2]string // [0] is IP, [1] is Netmask
 			RedirectGW bool
-		}{IP: user.getIP().String(), NetMask: svr.Mask, Routes: associatedRoutes, Servernets: serverNets, RedirectGW: !user.NoGW}
+			GWFlags    string // flags of the redirect-gateway option
+			Disabled   bool
+			DNS        []string    // overrides the dns servers pushed by the server
+			Pushes     []string    // extra options to push
+			SplitDNS   [][3]string // [0] is priority, [1] is dns servers, [2] is domain
+			DefaultDNS string      // dns servers to resolve the rest of the names with, when there's split dns
+			Inactive   uint32      // seconds of inactivity after which the client disconnects
+		}{IP: ipnet.IP.String(), NetMask: net.IP(ipnet.Mask).To4().String(), RouteGW: routeGW, Routes: associatedRoutes, Servernets: serverNets, IRoutes: iroutes, RedirectGW: !noGW, GWFlags: redirectGW, Inactive: userOpts.Inactive, Disabled: user.IsDisabled() || !user.IsValidAt(now), DNS: dns, Pushes: pushes, SplitDNS: splitDNS, DefaultDNS: defaultDNS}
 
 		t, err := template.New("ccd.file.tmpl").Parse(ccdFileTemplate)
 		if err != nil {
@@ -926,70 +1170,36 @@ func (svr *Server) emitDHParams() error {
 	return svr.emitToFile(_DefaultDHParamsPath, result.String(), 0)
 }
 
-func (svr *Server) emitIptables() error {
-	if Testing {
-		return nil
+func (svr *Server) emitFirewall() error {
+	fw, err := svr.getFirewall()
+	if err != nil {
+		return err
 	}
-	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
+	rules, err := svr.firewallRules()
 	if err != nil {
-		return fmt.Errorf("can not create new iptables object: %v", err)
+		return err
 	}
-
-	for _, network := range GetAllNetworks() {
-		associatedUsernames := network.GetAssociatedUsernames()
-		switch network.Type {
-		case SERVERNET:
-			users, err := GetAllUsers()
-			if err != nil {
-				return err
-			}
-
-			// Find associated users and emit iptables configs for the users
-			// regarding the network's type and attributes.
-			for _, user := range users {
-				// Find out if the user is associated or not.
-				var found bool
-				for _, auser := range associatedUsernames {
-					if user.Username == auser {
-						found = true
-						break
-					}
-				}
-
-				userIP, _, err := net.ParseCIDR(user.GetIPNet())
-				if err != nil {
-					return err
-				}
-				_, networkIPNet, err := net.ParseCIDR(network.CIDR)
-				if err != nil {
-					return err
-				}
-
-				// get destination network's iface
-				iface := interfaceOfIP(networkIPNet)
-				if iface == nil {
-					logrus.Warnf("network doesn't exist on server %s[SERVERNET]: cant find interface for %s", network.Name, networkIPNet.String())
-					return nil
-				}
-				// enable nat for the user to the destination network n
-// 				if found {
-// 					err = ipt.AppendUnique("nat", "POSTROUTING", "-s", userIP.String(), "-o", iface.Name, "-j", "MASQUERADE")
-// 					if err != nil {
-// 						logrus.Error(err)
-// 						return err
-// 					}
-// 				} else {
-// 					err = ipt.Delete("nat", "POSTROUTING", "-s", userIP.String(), "-o", iface.Name, "-j", "MASQUERADE")
-// 					if err != nil {
-// 						logrus.Debug(err)
-// 					}
-// 				}
-			}
-		}
+	if err := fw.SetForwardRules(svr.clientSource(), rules); err != nil {
+		return err
 	}
+	logrus.Debugf("%d firewall rules are emitted with %s", len(rules), fw.Name())
 	return nil
 }
 
+// cleanupFirewall removes the nat and the forward rules that are owned by ovpm.
+func (svr *Server) cleanupFirewall() {
+	fw, err := svr.getFirewall()
+	if err != nil {
+		logrus.Errorf("can not clean up firewall rules: %v", err)
+		return
+	}
+	if err := fw.Cleanup(); err != nil {
+		logrus.Errorf("can not clean up %s rules: %v", fw.Name(), err)
+		return
+	}
+	logrus.Debugf("%s rules are cleaned up", fw.Name())
+}
+
 func checkOpenVPNExecutable() bool {
 	executable := getOpenVPNExecutable()
 	if executable == "" {
@@ -1022,17 +1232,6 @@ func checkOpenSSLExecutable() bool {
 	return true
 }
 
-func checkIptablesExecutable() bool {
-	cmd := exec.Command("which", "iptables")
-	output, err := cmd.Output()
-	if err != nil {
-		logrus.Errorf("iptables is not installed: %s  ✘", err)
-		return false
-	}
-	logrus.Debugf("iptables executable detected: %s  ✔", strings.TrimSpace(string(output[:])))
-	return true
-}
-
 func ensureBaseDir() {
 	if Testing {
 		return
@@ -1042,9 +1241,17 @@ func ensureBaseDir() {
 
 func init() {
 	ensureBaseDir()
-	var err error
-	vpnProc, err = supervisor.NewProcess(getOpenVPNExecutable(), varBasePath, []string{"--config", _DefaultVPNConfPath})
+	proc, err := supervisor.NewProcess(getOpenVPNExecutable(), varBasePath, []string{"--config", _DefaultVPNConfPath})
 	if err != nil {
 		logrus.Errorf("can not create process: %v", err)
 	}
+	proc.SetFailureHook(func(state supervisor.State) {
+		switch state {
+		case supervisor.FAILED:
+			recordProcessFailure("process failed to launch")
+		case supervisor.EXITED:
+			recordProcessFailure("process exited unexpectedly")
+		}
+	})
+	vpnProc = proc
 }
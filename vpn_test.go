@@ -430,6 +430,35 @@ func TestVPNEmit(t *testing.T) {
 	// TODO(cad): Write test cases for ccd/ files as well.
 }
 
+func TestVPNEmitClientAuth(t *testing.T) {
+	// Init:
+	setupTestCase()
+	CreateDB("sqlite3", ":memory:")
+	defer db.Cease()
+	svr := TheServer()
+	svr.Init("localhost", "", UDPProto, "", "", "", "", false)
+	defer SetVPNPasswordAuth(false)
+
+	// Test:
+	for _, tt := range []struct {
+		passwordAuth bool
+		optional     bool
+	}{
+		{false, true}, // clients only send their certs
+		{true, false},
+	} {
+		SetVPNPasswordAuth(tt.passwordAuth)
+		svr.Emit()
+		conf := fs[_DefaultVPNConfPath]
+		if !strings.Contains(conf, "management-client-auth") {
+			t.Fatalf("server.conf is expected to authorize the clients through the management interface:\n%s", conf)
+		}
+		if got := strings.Contains(conf, "auth-user-pass-optional"); got != tt.optional {
+			t.Errorf("auth-user-pass-optional in server.conf = %t with password auth %t, want %t", got, tt.passwordAuth, tt.optional)
+		}
+	}
+}
+
 func TestVPNemitToFile(t *testing.T) {
 	// Initialize:
 
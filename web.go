@@ -0,0 +1,35 @@
+package ovpm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var webURL string
+var webURLMu sync.RWMutex
+
+// SetWebURL sets the public url of the web ui, which is linked in the emails and the profile links.
+// e.g. https://vpn.example.com
+//
+// Empty url disables the features that need absolute links, such as the password resets and the
+// profile links.
+func SetWebURL(u string) error {
+	if u != "" {
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return fmt.Errorf("validation error: web url `%s` is invalid: %v", u, err)
+		}
+	}
+	webURLMu.Lock()
+	defer webURLMu.Unlock()
+	webURL = strings.TrimSuffix(u, "/")
+	return nil
+}
+
+// GetWebURL returns the public url of the web ui, or empty string if it's not set.
+func GetWebURL() string {
+	webURLMu.RLock()
+	defer webURLMu.RUnlock()
+	return webURL
+}
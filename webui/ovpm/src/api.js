@@ -12,10 +12,30 @@ export const endpoints = {
 
     method: "POST"
   },
+  logout: {
+    path: "/auth/logout",
+    method: "POST"
+  },
   authStatus: {
     path: "/auth/status",
     method: "GET"
   },
+  oidcInfo: {
+    path: "/auth/oidc/info",
+    method: "GET"
+  },
+  oidcLogin: {
+    path: "/auth/oidc/login",
+    method: "GET"
+  },
+  requestPasswordReset: {
+    path: "/auth/password-reset/request",
+    method: "POST"
+  },
+  resetPassword: {
+    path: "/auth/password-reset",
+    method: "POST"
+  },
   genConfig: {
     path: "/user/genconfig",
     method: "POST"
@@ -23,8 +23,10 @@ export default class Login extends React.Component {
     this.state = {
       username: "",
       password: "",
+      totp: "",
       isAuthenticated: false,
       isAdmin: false,
+      isOIDCEnabled: false,
       error: null
     };
     this.api = new API(baseURL, endpoints);
@@ -39,6 +41,21 @@ export default class Login extends React.Component {
       isAuthenticated: IsAuthenticated(),
       isAdmin: isAdmin
     });
+
+    // Single sign-on callback redirects here with the token in the fragment.
+    let match = window.location.hash.match(/token=([^&]+)/);
+    if (match) {
+      window.history.replaceState(null, "", window.location.pathname);
+      this.handleAuthenticateSuccess({ data: { token: decodeURIComponent(match[1]) } });
+      return;
+    }
+    this.api.call(
+      "oidcInfo",
+      {},
+      false,
+      res => this.setState({ isOIDCEnabled: res.data.enabled }),
+      () => this.setState({ isOIDCEnabled: false })
+    );
     this.api.call(
       "authStatus",
       {},
@@ -56,6 +73,10 @@ export default class Login extends React.Component {
     this.setState({ password: e.target.value });
   }
 
+  handleTOTPChange(e) {
+    this.setState({ totp: e.target.value });
+  }
+
   handleGetUserInfoSuccess(res) {
     if (res.data.user.username === "root") {
       SetAuthToken("root");
@@ -65,7 +86,7 @@ export default class Login extends React.Component {
       SetItem("username", "root");
     } else {
       SetItem("isAdmin", res.data.user.is_admin);
-      SetItem("username", this.state.username);
+      SetItem("username", this.state.username || res.data.user.username);
     }
   }
 
@@ -107,7 +128,8 @@ export default class Login extends React.Component {
 
     let data = {
       username: this.state.username,
-      password: this.state.password
+      password: this.state.password,
+      totp: this.state.totp
     };
 
     this.api.call(
@@ -168,9 +190,28 @@ export default class Login extends React.Component {
                 required={true}
                 type="password"
               />
+              <Input
+                label="Authenticator Code (if enrolled)"
+                value={this.state.totp}
+                onChange={this.handleTOTPChange.bind(this)}
+                floatingLabel={true}
+                autoComplete="one-time-code"
+              />
               <Button type="submit" color="primary" required={true}>
                 Login
               </Button>
+              {this.state.isOIDCEnabled && (
+                <Button
+                  type="button"
+                  variant="flat"
+                  onClick={() =>
+                    (window.location.href = baseURL + endpoints.oidcLogin.path)
+                  }
+                >
+                  Sign in with SSO
+                </Button>
+              )}
+              <a href="/reset-password">Forgot your password?</a>
             </form>
           </Panel>
         </Container>
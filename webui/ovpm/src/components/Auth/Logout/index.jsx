@@ -1,8 +1,13 @@
 import React from "react";
 import { Redirect } from "react-router";
-import { ClearAuthToken } from "../../../utils/auth.js";
+import { ClearAuthToken, GetAuthToken } from "../../../utils/auth.js";
+import { API } from "../../../utils/restClient.js";
+import { baseURL, endpoints } from "../../../api.js";
 export default class Logout extends React.Component {
   componentWillMount() {
+    // Revoke the session token on the server, then forget it.
+    let api = new API(baseURL, endpoints, GetAuthToken());
+    api.call("logout", {}, true, () => {}, () => {});
     ClearAuthToken(); // Logout
   }
 
@@ -0,0 +1,144 @@
+import React from "react";
+import Button from "muicss/lib/react/button";
+import Input from "muicss/lib/react/input";
+import Panel from "muicss/lib/react/panel";
+import Container from "muicss/lib/react/container";
+
+import { API } from "../../../utils/restClient.js";
+import { baseURL, endpoints } from "../../../api.js";
+
+// ResetPassword requests a password reset link by email, or sets a new password with the token
+// of the link that the user is redirected here with.
+export default class ResetPassword extends React.Component {
+  constructor(props) {
+    super(props);
+
+    let match = window.location.search.match(/token=([^&]+)/);
+    this.state = {
+      token: match ? decodeURIComponent(match[1]) : "",
+      username: "",
+      password: "",
+      message: null,
+      error: null
+    };
+    this.api = new API(baseURL, endpoints);
+  }
+
+  handleUsernameChange(e) {
+    this.setState({ username: e.target.value });
+  }
+
+  handlePasswordChange(e) {
+    this.setState({ password: e.target.value });
+  }
+
+  handleFailure(error) {
+    let message = "Something went wrong, please try again.";
+    if (error.response && error.response.data && error.response.data.error) {
+      message = error.response.data.error;
+    }
+    this.setState({ error: message });
+  }
+
+  handleFormSubmit(e) {
+    e.preventDefault();
+    this.setState({ error: null, message: null });
+    if (!this.state.token) {
+      if (!this.state.username) {
+        return;
+      }
+      this.api.call(
+        "requestPasswordReset",
+        { username: this.state.username },
+        false,
+        () =>
+          this.setState({
+            message:
+              "If the user has an email address, a password reset link is sent to it."
+          }),
+        this.handleFailure.bind(this)
+      );
+      return;
+    }
+    if (!this.state.password) {
+      return;
+    }
+    this.api.call(
+      "resetPassword",
+      { token: this.state.token, password: this.state.password },
+      false,
+      () =>
+        this.setState({
+          token: "",
+          password: "",
+          message: "Your password is reset, you can log in with it now."
+        }),
+      this.handleFailure.bind(this)
+    );
+  }
+
+  render() {
+    let notice;
+    if (this.state.error || this.state.message) {
+      notice = (
+        <Panel
+          className="mui--text-center"
+          style={{
+            color: "#fff",
+            "background-color": this.state.error ? "#F44336" : "#4CAF50",
+            "margin-bottom": "0",
+            "padding-bottom": "0"
+          }}
+        >
+          <p>{this.state.error || this.state.message}</p>
+        </Panel>
+      );
+    }
+
+    let input;
+    if (this.state.token) {
+      input = (
+        <Input
+          label="New Password"
+          value={this.state.password}
+          onChange={this.handlePasswordChange.bind(this)}
+          floatingLabel={true}
+          required={true}
+          type="password"
+        />
+      );
+    } else {
+      input = (
+        <Input
+          label="Username"
+          value={this.state.username}
+          onChange={this.handleUsernameChange.bind(this)}
+          floatingLabel={true}
+          required={true}
+        />
+      );
+    }
+    return (
+      <div
+        style={{
+          maxWidth: "500px",
+          marginTop: "calc(50vh - 232px / 2)",
+          marginLeft: "calc(50% - 500px / 2)"
+        }}
+      >
+        <Container>
+          {notice}
+          <Panel>
+            <form onSubmit={this.handleFormSubmit.bind(this)}>
+              {input}
+              <Button type="submit" color="primary">
+                {this.state.token ? "Set Password" : "Send Reset Link"}
+              </Button>
+              <a href="/login">Back to login</a>
+            </form>
+          </Panel>
+        </Container>
+      </div>
+    );
+  }
+}
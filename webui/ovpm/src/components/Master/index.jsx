@@ -5,6 +5,7 @@ import { BrowserRouter as Router, Route, Redirect } from "react-router-dom";
 
 import Login from "../Auth/Login";
 import Logout from "../Auth/Logout";
+import ResetPassword from "../Auth/ResetPassword";
 import LoginRequired from "../Auth/LoginRequired";
 
 import Dashboard from "../Dashboard";
@@ -16,9 +17,10 @@ function Home(props) {
 const Master = props => (
   <Router>
     <div>
-      <Route path="/" component={Home} />
+      <Route exact path="/" component={Home} />
       <Route path="/login" component={Login} />
       <Route path="/logout" component={Logout} />
+      <Route path="/reset-password" component={ResetPassword} />
       <Route path="/dashboard" component={LoginRequired(Dashboard)} />
     </div>
   </Router>